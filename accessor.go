@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Accessor is bound to a container, to avoid passing the container for each call.
+// It is also a 'Container', so generic functions with suffix 'FromC' accept it too:
+//
+//	a := ioc.WithContainer(container)
+//	a.Inject(&client)
+//	handler := a.Build(ioc.TypeOf[*OrderHandler]()).Interface().(*OrderHandler)
+//	svc := ioc.GetServiceFromC[Service1](a)
+type Accessor struct {
+	Container
+}
+
+// WithContainer to create accessor bound to container.
+//
+// It will panic if 'c' is nil.
+func WithContainer(c Container) Accessor {
+	if c == nil {
+		panic(errors.New("param 'c' is null"))
+	}
+	return Accessor{Container: c}
+}
+
+// Inject to func or *struct or their's reflect.Value with service from bound container, see 'ioc.InjectFromC'.
+func (a Accessor) Inject(target any) {
+	InjectFromC(a.Container, target)
+}
+
+// Build to create a new instance of 'targetType' which is *struct or struct with services from bound container,
+// see 'ioc.Build'.
+//
+// It will panic if 'targetType' is not *struct or struct.
+func (a Accessor) Build(targetType reflect.Type) reflect.Value {
+	if targetType == nil {
+		panic(errors.New("param 'targetType' is null"))
+	}
+	instance, err := build(a.Container, targetType)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
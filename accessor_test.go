@@ -0,0 +1,82 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestWithContainer(t *testing.T) {
+	t.Run("accessor should be bound to container", func(t *testing.T) {
+		globalContainer = New()
+		c := New()
+		_ = c.AddSingleton(TypeOf[*serviceInstance7](), &serviceInstance7{name: "instance7"})
+		_ = c.AddSingleton(TypeOf[service1](), &serviceInstance1{name: "instance1"})
+		a := WithContainer(c)
+
+		if svc := a.Resolve(TypeOf[service1]()); !svc.IsValid() || svc.Interface().(service1).GetName() != "instance1" {
+			t.Error("resolve should be from bound container")
+			return
+		}
+		if svc := GetServiceFromC[service1](a); svc == nil || svc.GetName() != "instance1" {
+			t.Error("accessor should be accepted by generic functions")
+			return
+		}
+
+		var target buildTarget
+		a.Inject(&target)
+		if target.S1 == nil {
+			t.Error("inject should be from bound container")
+			return
+		}
+
+		instance, ok := a.Build(TypeOf[*buildTarget]()).Interface().(*buildTarget)
+		if !ok || instance.S1 == nil || instance.S7Name != "instance7" {
+			t.Error("build should be from bound container")
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("global container should not be used")
+			return
+		}
+	})
+
+	t.Run("invalid param should panic", func(t *testing.T) {
+		globalContainer = New()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("nil container should panic")
+				}
+			}()
+			WithContainer(nil)
+		}()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("build interface should panic")
+				}
+			}()
+			WithContainer(globalContainer).Build(TypeOf[service1]())
+		}()
+	})
+}
@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// PointerValueAdapter is implemented by container to adapt between '*T' and 'T' when resolving missed.
+type PointerValueAdapter interface {
+	// SetPointerValueAdapt to adapt between '*T' and 'T' when resolving missed, default is false.
+	//
+	// Rules when enabled:
+	//  - resolving struct 'T' missed, resolve '*T' and returns copy of the struct it points to, if not nil.
+	//  - resolving '*T' missed, resolve 'T' and returns it's address, only if it's addressable, that is from a parent resolver which returns addressable value,
+	//    because value of struct type can't be registered to container.
+	SetPointerValueAdapt(enabled bool)
+}
+
+var _ PointerValueAdapter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetPointerValueAdapt(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&c.pointerAdapt, 1)
+	} else {
+		atomic.StoreInt32(&c.pointerAdapt, 0)
+	}
+}
+
+func (c *defaultContainer) isPointerValueAdapt() bool {
+	return atomic.LoadInt32(&c.pointerAdapt) == 1
+}
+
+// adaptPointerValue to resolve '*T' for 'T', or 'T' for '*T', in the call of 'path'.
+func (c *defaultContainer) adaptPointerValue(serviceType reflect.Type, path *resolvePath) reflect.Value {
+	switch {
+	case serviceType.Kind() == reflect.Struct:
+		ptr := c.resolve(reflect.PtrTo(serviceType), c, path)
+		if !ptr.IsValid() || ptr.IsNil() {
+			return reflect.Value{}
+		}
+		// copy, so the singleton won't be changed by the value
+		val := reflect.New(serviceType).Elem()
+		val.Set(ptr.Elem())
+		return val
+	case serviceType.Kind() == reflect.Pointer && serviceType.Elem().Kind() == reflect.Struct:
+		val := c.resolve(serviceType.Elem(), c, path)
+		if !val.IsValid() || !val.CanAddr() {
+			// can't take address of unaddressable value
+			return reflect.Value{}
+		}
+		return val.Addr()
+	}
+	return reflect.Value{}
+}
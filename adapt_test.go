@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSetPointerValueAdapt(t *testing.T) {
+	t.Run("resolve value of registered pointer should success if enabled", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[*serviceInstance1](svc1)
+
+		if GetService[serviceInstance1]().name != "" {
+			t.Error("value should not be resolved if disabled")
+			return
+		}
+		globalContainer.(PointerValueAdapter).SetPointerValueAdapt(true)
+		val := GetService[serviceInstance1]()
+		if val.name != "instance1" {
+			t.Error("value should be resolved by dereferencing")
+			return
+		}
+		val.name = "changed"
+		if svc1.name != "instance1" {
+			t.Error("value should be a copy")
+			return
+		}
+		if GetService[serviceInstance2]().name != "" {
+			t.Error("value should not be resolved if pointer not registered")
+			return
+		}
+	})
+
+	t.Run("resolve pointer of value should success only if addressable", func(t *testing.T) {
+		globalContainer = New()
+		addressable := &serviceInstance1{name: "addressable"}
+		SetParent(valueResolver{
+			reflect.TypeOf(serviceInstance1{}): reflect.ValueOf(addressable).Elem(),
+			reflect.TypeOf(serviceInstance2{}): reflect.ValueOf(serviceInstance2{name: "unaddressable"}),
+		})
+		globalContainer.(PointerValueAdapter).SetPointerValueAdapt(true)
+
+		if GetService[*serviceInstance1]() != addressable {
+			t.Error("pointer should be resolved by taking address")
+			return
+		}
+		if GetService[*serviceInstance2]() != nil {
+			t.Error("pointer should not be resolved if unaddressable")
+			return
+		}
+	})
+}
+
+// valueResolver is a Resolver which resolves values by type.
+type valueResolver map[reflect.Type]reflect.Value
+
+func (r valueResolver) SetParent(parent Resolver) {}
+
+func (r valueResolver) Resolve(serviceType reflect.Type) reflect.Value {
+	return r[serviceType]
+}
@@ -0,0 +1,149 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// AdoptOption is option of 'Adopter.Adopt'.
+type AdoptOption func(a *adoption) error
+
+// adoption is the source adopted by 'Adopter.Adopt' with options.
+type adoption struct {
+	source      Resolver
+	namespace   string
+	packages    string
+	hasPackages bool
+}
+
+// AdoptNamespace to resolve services of source by name with namespace, such as "billing:*invoice.Service",
+// see 'NameResolver.ResolveByName'. Default is resolving by name as it is.
+func AdoptNamespace(namespace string) AdoptOption {
+	return func(a *adoption) error {
+		if namespace == "" {
+			return errors.New("param 'namespace' is empty")
+		}
+		a.namespace = namespace
+		return nil
+	}
+}
+
+// AdoptPackages to resolve only types whose package path is 'pkgPath' or under it from source, resolving by name is not limited.
+func AdoptPackages(pkgPath string) AdoptOption {
+	return func(a *adoption) error {
+		a.packages = pkgPath
+		a.hasPackages = true
+		return nil
+	}
+}
+
+// Adopter is implemented by container to resolve services of another resolver as fallback.
+type Adopter interface {
+	// Adopt to resolve services of 'source', such as container of plugin loaded at runtime, as fallback of current container
+	// without copying instances, which is checked after services of current container and before it's parent.
+	// Use 'ioc.AdoptNamespace' to resolve services of source by name with namespace, avoiding collision of type names,
+	// and 'ioc.AdoptPackages' to resolve only types under the package path from source.
+	//
+	// Types are matched by identity of reflect.Type, so host and plugin should share the package declaring service types,
+	// types with the same name declared in plugin and host are different and never matched.
+	//
+	//  err := host.(ioc.Adopter).Adopt(pluginContainer, ioc.AdoptNamespace("billing"))
+	//  val, ok := host.(ioc.NameResolver).ResolveByName("billing:*invoice.Service")
+	Adopt(source Resolver, opts ...AdoptOption) error
+}
+
+var _ Adopter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Adopt(source Resolver, opts ...AdoptOption) error {
+	if source == nil {
+		return errors.New("param 'source' is null")
+	}
+	for _, resolver := range append([]Resolver{c}, c.Ancestors()...) {
+		if resolver == source {
+			return errors.New("source is current container or it's ancestor")
+		}
+	}
+	for _, ancestor := range ancestorsOf(source) {
+		if ancestor == Resolver(c) {
+			return errors.New("cycle reference: parent chain of source contains current container")
+		}
+	}
+	a := &adoption{source: source}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(a); err != nil {
+			return err
+		}
+	}
+	c.locker.Lock()
+	c.adopted = append(c.adopted, a)
+	c.locker.Unlock()
+	c.AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+		if a.hasPackages && !underPackage(pkgPathOf(serviceType), a.packages) {
+			return reflect.Value{}, false
+		}
+		val := a.resolve(serviceType)
+		return val, val.IsValid()
+	})
+	return nil
+}
+
+// resolve service from source without panic in strict mode.
+func (a *adoption) resolve(serviceType reflect.Type) reflect.Value {
+	switch source := a.source.(type) {
+	case *defaultContainer:
+		return source.resolveLenient(serviceType, nil)
+	case Container:
+		val, _ := SafeResolve(source, serviceType)
+		return val
+	default:
+		return source.Resolve(serviceType)
+	}
+}
+
+// resolveAdoptedByName to resolve service by name from adopted sources in order, name should start with namespace if set.
+func (c *defaultContainer) resolveAdoptedByName(typeName string) (reflect.Value, bool) {
+	c.locker.Lock()
+	adopted := c.adopted
+	c.locker.Unlock()
+	for _, a := range adopted {
+		source, ok := a.source.(Container)
+		if !ok {
+			continue
+		}
+		name := typeName
+		if a.namespace != "" {
+			if name = strings.TrimPrefix(typeName, a.namespace+":"); name == typeName {
+				continue
+			}
+		}
+		if val, ok := source.(NameResolver).ResolveByName(name); ok {
+			return val, true
+		}
+	}
+	return reflect.Value{}, false
+}
@@ -0,0 +1,105 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestAdopt(t *testing.T) {
+	t.Run("services of source should be resolved as fallback", func(t *testing.T) {
+		globalContainer = New()
+		plugin := New()
+		pluginSvc := &serviceInstance1{name: "plugin"}
+		AddSingletonToC[service1](plugin, pluginSvc)
+		AddSingletonToC[service2](plugin, &serviceInstance2{name: "plugin"})
+		parent := New()
+		AddSingletonToC[service2](parent, &serviceInstance2{name: "parent"})
+		globalContainer.SetParent(parent)
+		if err := globalContainer.(Adopter).Adopt(plugin); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]() != pluginSvc {
+			t.Error("service of source should be resolved")
+			return
+		}
+		if GetService[service2]().GetName() != "plugin" {
+			t.Error("source should be checked before parent")
+			return
+		}
+		AddSingleton[service1](&serviceInstance1{name: "host"})
+		if GetService[service1]().GetName() != "host" {
+			t.Error("service of current container should be preferred")
+			return
+		}
+	})
+
+	t.Run("services of source should be resolved by name with namespace", func(t *testing.T) {
+		globalContainer = New()
+		plugin := New()
+		pluginSvc := &serviceInstance1{name: "plugin"}
+		AddSingletonToC[*serviceInstance1](plugin, pluginSvc)
+		if err := globalContainer.(Adopter).Adopt(plugin, AdoptNamespace("billing")); err != nil {
+			t.Error(err)
+			return
+		}
+		if val, ok := globalContainer.(NameResolver).ResolveByName("billing:*ioc.serviceInstance1"); !ok || val.Interface() != pluginSvc {
+			t.Error("service of source should be resolved by name with namespace")
+			return
+		}
+		if _, ok := globalContainer.(NameResolver).ResolveByName("*ioc.serviceInstance1"); ok {
+			t.Error("service of source should not be resolved by name without namespace")
+			return
+		}
+	})
+
+	t.Run("only types under packages should be resolved from source", func(t *testing.T) {
+		globalContainer = New()
+		plugin := New()
+		AddSingletonToC[service1](plugin, &serviceInstance1{name: "plugin"})
+		if err := globalContainer.(Adopter).Adopt(plugin, AdoptPackages("example.com/plugin")); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("types out of packages should not be resolved from source")
+			return
+		}
+	})
+
+	t.Run("adopting current container or ancestor should fail", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		globalContainer.SetParent(parent)
+		child := New()
+		child.SetParent(globalContainer)
+		if globalContainer.(Adopter).Adopt(child) == nil {
+			t.Error("source with current container as ancestor should fail")
+			return
+		}
+		if globalContainer.(Adopter).Adopt(globalContainer) == nil || globalContainer.(Adopter).Adopt(parent) == nil || globalContainer.(Adopter).Adopt(nil) == nil {
+			t.Error("adopting current container or ancestor should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,89 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// AfterInit to add callback which runs once after singleton 'TService' in global container is initialized, see 'AfterIniter.AfterInit'.
+//
+//	ioc.AfterInit[*Server](func(s *Server, r ioc.Resolver) {
+//	    s.Use(middleware)
+//	})
+func AfterInit[TService any](fn func(instance TService, r Resolver)) {
+	AfterInitToC[TService](globalContainer, fn)
+}
+
+// AfterInitToC to add callback which runs once after singleton 'TService' in container is initialized, see 'AfterIniter.AfterInit'.
+func AfterInitToC[TService any](container Container, fn func(instance TService, r Resolver)) {
+	if fn == nil {
+		return
+	}
+	container.(AfterIniter).AfterInit(TypeOf[TService](), func(instance any, r Resolver) {
+		typed, _ := instance.(TService)
+		fn(typed, r)
+	})
+}
+
+// AfterIniter is implemented by container to add callbacks after singletons initialized.
+type AfterIniter interface {
+	// AfterInit to add callback which runs once after singleton of service in current container is initialized,
+	// with the instance and current container. Callbacks run in adding order after initializer,
+	// and callbacks added after initialized run only if it's initialized again, such as after 'Invalidate'.
+	// It does nothing if 'serviceType' or 'fn' is null.
+	//
+	//  container.(ioc.AfterIniter).AfterInit(reflect.TypeOf((*Server)(nil)), func(instance any, r ioc.Resolver) {
+	//      instance.(*Server).Use(middleware)
+	//  })
+	AfterInit(serviceType reflect.Type, fn func(instance any, r Resolver))
+}
+
+var _ AfterIniter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AfterInit(serviceType reflect.Type, fn func(instance any, r Resolver)) {
+	if serviceType == nil || fn == nil {
+		return
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	var callbacks []func(instance any, r Resolver)
+	if val, ok := c.afterInits.Load(serviceType); ok {
+		callbacks = val.([]func(instance any, r Resolver))
+	}
+	// copy on write, so callbacks can be read without lock
+	newCallbacks := make([]func(instance any, r Resolver), 0, len(callbacks)+1)
+	newCallbacks = append(newCallbacks, callbacks...)
+	newCallbacks = append(newCallbacks, fn)
+	c.afterInits.Store(serviceType, newCallbacks)
+}
+
+// runAfterInits to run callbacks of service type in adding order after it's singleton is initialized.
+func (c *defaultContainer) runAfterInits(serviceType reflect.Type, instance any) {
+	val, ok := c.afterInits.Load(serviceType)
+	if !ok {
+		return
+	}
+	for _, fn := range val.([]func(instance any, r Resolver)) {
+		fn(instance, c)
+	}
+}
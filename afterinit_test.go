@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type afterInitService struct {
+	initialized bool
+	seen        []string
+}
+
+func (s *afterInitService) Initialize() {
+	s.initialized = true
+}
+
+func TestAfterInit(t *testing.T) {
+	t.Run("callbacks should run once in adding order after initialized", func(t *testing.T) {
+		globalContainer = New()
+		instance := &afterInitService{}
+		AddSingleton[*afterInitService](instance)
+		var resolvers []Resolver
+		AfterInit[*afterInitService](func(s *afterInitService, r Resolver) {
+			if s.initialized {
+				s.seen = append(s.seen, "first")
+			}
+			resolvers = append(resolvers, r)
+		})
+		globalContainer.(AfterIniter).AfterInit(TypeOf[*afterInitService](), func(instance any, r Resolver) {
+			s := instance.(*afterInitService)
+			s.seen = append(s.seen, "second")
+		})
+		globalContainer.(AfterIniter).AfterInit(TypeOf[*afterInitService](), nil)
+
+		GetService[*afterInitService]()
+		GetService[*afterInitService]()
+		if !reflect.DeepEqual(instance.seen, []string{"first", "second"}) {
+			t.Errorf("callbacks should observe initialized instance once in order, but %v", instance.seen)
+			return
+		}
+		if len(resolvers) != 1 || resolvers[0] != globalContainer {
+			t.Error("callback should receive the resolver which the resolving started from")
+			return
+		}
+	})
+
+	t.Run("callbacks should run again after invalidated", func(t *testing.T) {
+		globalContainer = New()
+		count := 0
+		AddSingleton[*afterInitService](&afterInitService{})
+		AfterInit[*afterInitService](func(s *afterInitService, r Resolver) {
+			count++
+		})
+		GetService[*afterInitService]()
+		Invalidate[*afterInitService]()
+		GetService[*afterInitService]()
+		if count != 2 {
+			t.Errorf("callback should run for each initializing, but %d", count)
+			return
+		}
+	})
+
+	t.Run("callbacks of other type should not run", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{})
+		AfterInit[*afterInitService](func(s *afterInitService, r Resolver) {
+			t.Error("callback of other type should not run")
+		})
+		GetService[service1]()
+	})
+}
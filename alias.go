@@ -0,0 +1,106 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Alias to make resolving 'TAlias' delegate to 'TTarget' in global container.
+//
+// It will panic if 'TTarget' is not assignable to 'TAlias'.
+func Alias[TAlias any, TTarget any]() {
+	AliasToC[TAlias, TTarget](globalContainer)
+}
+
+// AliasToC to make resolving 'TAlias' delegate to 'TTarget' in container.
+//
+// It will panic if 'TTarget' is not assignable to 'TAlias'.
+func AliasToC[TAlias any, TTarget any](container Container) {
+	if err := container.(Aliaser).Alias(TypeOf[TAlias](), TypeOf[TTarget]()); err != nil {
+		panic(err)
+	}
+}
+
+// AddAlias to make resolving 'TAlias' return exactly what resolving 'TTarget' returns in global container,
+// the same singleton instance or a new transient one for each resolving, matching the lifetime of 'TTarget'.
+// It's the same as 'Alias'.
+//
+// It will panic if 'TTarget' is not assignable to 'TAlias'.
+func AddAlias[TAlias any, TTarget any]() {
+	Alias[TAlias, TTarget]()
+}
+
+// AddAliasToC to make resolving 'TAlias' return exactly what resolving 'TTarget' returns in container.
+// It's the same as 'AliasToC'.
+//
+// It will panic if 'TTarget' is not assignable to 'TAlias'.
+func AddAliasToC[TAlias any, TTarget any](container Container) {
+	AliasToC[TAlias, TTarget](container)
+}
+
+// Aliaser is implemented by container to resolve service type as another one.
+type Aliaser interface {
+	// Alias to make resolving 'aliasType' delegate to 'targetType' in current container, such as migrating to new package path.
+	// Target is resolved for each resolving of alias, so alias is unresolvable if target is removed,
+	// and 'targetType' should be assignable to 'aliasType'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.Aliaser).Alias(reflect.TypeOf((*oldpkg.Service)(nil)).Elem(), reflect.TypeOf((*newpkg.Service)(nil)).Elem())
+	Alias(aliasType reflect.Type, targetType reflect.Type) error
+}
+
+var _ Aliaser = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Alias(aliasType reflect.Type, targetType reflect.Type) error {
+	if aliasType == nil {
+		return errors.New("param 'aliasType' is null")
+	}
+	if targetType == nil {
+		return errors.New("param 'targetType' is null")
+	}
+	if !targetType.AssignableTo(aliasType) {
+		return fmt.Errorf("target '%v' should be assignable to alias '%v'", targetType, aliasType)
+	}
+	for t := targetType; t != nil; {
+		if t == aliasType {
+			return fmt.Errorf("cycle reference: alias '%v' is target of itself", aliasType)
+		}
+		binding := c.getBinding(t)
+		if binding == nil {
+			break
+		}
+		t = binding.AliasOf
+	}
+	return c.addBinding(&serviceBinding{ServiceType: aliasType, AliasOf: targetType})
+}
+
+// resolveAlias to resolve target of alias, and returns invalid value if it's not assignable to alias.
+func (c *defaultContainer) resolveAlias(b *serviceBinding, origin *defaultContainer, path *resolvePath) reflect.Value {
+	instance := c.resolve(b.AliasOf, origin, path)
+	if !instance.IsValid() || !instance.Type().AssignableTo(b.ServiceType) {
+		return reflect.Value{}
+	}
+	return instance
+}
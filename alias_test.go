@@ -0,0 +1,134 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestAlias(t *testing.T) {
+	t.Run("resolving alias should delegate to target", func(t *testing.T) {
+		globalContainer = New()
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddSingleton[service2](svc2)
+		Alias[service1, service2]()
+		if GetService[service1]() != svc2 {
+			t.Error("alias should be resolved as target")
+			return
+		}
+		if len(ResolveAll[service1]()) != 1 {
+			t.Error("target should be resolved once by 'ResolveAll' of alias")
+			return
+		}
+
+		replaced := &serviceInstance2{name: "replaced"}
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateReplace)
+		AddSingleton[service2](replaced)
+		if GetService[service1]() != replaced {
+			t.Error("alias should be resolved as target replaced")
+			return
+		}
+	})
+
+	t.Run("alias should be unresolvable if target is removed", func(t *testing.T) {
+		globalContainer = New()
+		Alias[service1, service2]()
+		WithSingleton[service2](&serviceInstance2{name: "temporary"}, func() {
+			if svc := GetService[service1](); svc == nil || svc.GetName() != "temporary" {
+				t.Error("alias should be resolved as target added later")
+			}
+		})
+		if GetService[service1]() != nil {
+			t.Error("alias should not be resolved if target is removed")
+			return
+		}
+	})
+
+	t.Run("target not assignable to alias should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Aliaser).Alias(TypeOf[service2](), TypeOf[service1]()); err == nil {
+			t.Error("target not assignable to alias should fail")
+			return
+		}
+		if err := globalContainer.(Aliaser).Alias(TypeOf[service1](), TypeOf[service1]()); err == nil {
+			t.Error("alias of itself should fail")
+			return
+		}
+		if err := globalContainer.(Aliaser).Alias(nil, TypeOf[service1]()); err == nil {
+			t.Error("null alias should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("generic alias not assignable should panic")
+			}
+		}()
+		Alias[*serviceInstance1, *serviceInstance2]()
+	})
+}
+
+func TestAddAlias(t *testing.T) {
+	t.Run("singleton alias should resolve the identical instance", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		AddAlias[service1, service2]()
+		if GetService[service1]() != GetService[service2]() {
+			t.Error("singleton alias should resolve the identical instance")
+			return
+		}
+	})
+
+	t.Run("transient alias should resolve independent instances", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance2](func() *serviceInstance2 { return &serviceInstance2{name: "instance2"} })
+		AddAlias[service1, *serviceInstance2]()
+		first, second := GetService[service1](), GetService[service1]()
+		if first == nil || second == nil || first == second {
+			t.Error("transient alias should resolve independent instances")
+			return
+		}
+	})
+
+	t.Run("target in parent should be allowed", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddSingletonToC[service2](parent, svc2)
+		globalContainer.SetParent(parent)
+		AddAlias[service1, service2]()
+		if GetService[service1]() != svc2 {
+			t.Error("alias should resolve target in parent")
+			return
+		}
+	})
+
+	t.Run("target registered after alias should be resolved", func(t *testing.T) {
+		globalContainer = New()
+		AddAlias[service1, service2]()
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddSingleton[service2](svc2)
+		if GetService[service1]() != svc2 {
+			t.Error("alias should resolve target registered later")
+			return
+		}
+	})
+}
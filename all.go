@@ -0,0 +1,252 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// ResolveAll to get instances of all registrations of service 'TService' from global container, sorted by 'ioc.Order'.
+func ResolveAll[TService any](opts ...CollectOption) []TService {
+	return ResolveAllFromC[TService](globalContainer, opts...)
+}
+
+// ResolveAllFromC to get instances of all registrations of service 'TService' from container, sorted by 'ioc.Order'.
+func ResolveAllFromC[TService any](container Container, opts ...CollectOption) []TService {
+	instanceVals := container.(AllResolver).ResolveAll(TypeOf[TService](), opts...)
+	instances := make([]TService, 0, len(instanceVals))
+	for _, instanceVal := range instanceVals {
+		if instance, ok := instanceVal.Interface().(TService); ok {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+// GetServiceAt to get the 'index'-th instance of service 'TService' in the order of 'ResolveAll' from global container,
+// returns zero value if out of range.
+func GetServiceAt[TService any](index int) TService {
+	return GetServiceAtFromC[TService](globalContainer, index)
+}
+
+// GetServiceAtFromC to get the 'index'-th instance of service 'TService' in the order of 'ResolveAll' from container,
+// returns zero value if out of range.
+func GetServiceAtFromC[TService any](container Container, index int) TService {
+	var instance TService
+	instanceVal := container.(AllResolver).ResolveAt(TypeOf[TService](), index)
+	if !instanceVal.IsValid() {
+		return instance
+	}
+	if val, ok := instanceVal.Interface().(TService); ok {
+		instance = val
+	}
+	return instance
+}
+
+// orderedInstance is instance to be resolved by 'ResolveAll' with it's order.
+type orderedInstance struct {
+	Resolve func() reflect.Value
+	Order   int
+}
+
+// AllResolver is implemented by container to resolve instances of all registrations of service.
+type AllResolver interface {
+	// ResolveAll to get instances of all registrations of the service, includes keyed ones, in current container and parent chain,
+	// sorted by 'ioc.Order', and then ancestors' before current's, and then registration order.
+	// For interface, registrations whose implementation is assignable to it are included too, such as '*HandlerImpl' for 'Handler',
+	// and registrations of the same instance are included once. Instance resolved by multiple registrations, such as
+	// in both parent and current or returned by multiple factories, is included once with 'ioc.Distinct'.
+	//
+	//  var container ioc.Container
+	//  middlewares := container.(ioc.AllResolver).ResolveAll(TypeOf[Middleware](), ioc.Distinct())
+	ResolveAll(serviceType reflect.Type, opts ...CollectOption) []reflect.Value
+
+	// ResolveAt to get the 'index'-th instance in the order of 'ResolveAll', only resolves instances before it.
+	// It returns invalid value if 'index' is negative or out of range.
+	//
+	//  var container ioc.Container
+	//  fallback := container.(ioc.AllResolver).ResolveAt(TypeOf[Handler](), 1)
+	ResolveAt(serviceType reflect.Type, index int) reflect.Value
+}
+
+var _ AllResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveAll(serviceType reflect.Type, opts ...CollectOption) []reflect.Value {
+	if serviceType == nil {
+		return nil
+	}
+	return collectOptionsOf(opts).apply(c.resolveAllIn(serviceType, nil))
+}
+
+// resolveAllIn to resolve all instances of service in the call of 'path', and skip invalid ones.
+func (c *defaultContainer) resolveAllIn(serviceType reflect.Type, path *resolvePath) []reflect.Value {
+	ordered := c.orderedInstances(serviceType, path)
+	instances := make([]reflect.Value, 0, len(ordered))
+	for _, instance := range ordered {
+		if instanceVal := instance.Resolve(); instanceVal.IsValid() {
+			instances = append(instances, instanceVal)
+		}
+	}
+	return instances
+}
+
+func (c *defaultContainer) ResolveAt(serviceType reflect.Type, index int) reflect.Value {
+	if serviceType == nil || index < 0 {
+		return reflect.Value{}
+	}
+	// only resolve instances until the 'index'-th, and skip invalid ones as 'ResolveAll' does
+	for _, instance := range c.orderedInstances(serviceType, nil) {
+		if instanceVal := instance.Resolve(); instanceVal.IsValid() {
+			if index == 0 {
+				return instanceVal
+			}
+			index--
+		}
+	}
+	return reflect.Value{}
+}
+
+// orderedInstances to get instances to be resolved in the call of 'path' sorted by order.
+func (c *defaultContainer) orderedInstances(serviceType reflect.Type, path *resolvePath) []orderedInstance {
+	ordered := c.resolveAll(serviceType, c, path)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Order < ordered[j].Order
+	})
+	return ordered
+}
+
+// resolveAll to get instances of ancestors first, and then current's in registration order.
+func (c *defaultContainer) resolveAll(serviceType reflect.Type, origin *defaultContainer, path *resolvePath) []orderedInstance {
+	var ordered []orderedInstance
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		ordered = parent.resolveAll(serviceType, origin, path)
+	case AllResolver:
+		for _, instance := range parent.ResolveAll(serviceType) {
+			instance := instance
+			ordered = append(ordered, orderedInstance{Resolve: func() reflect.Value { return instance }})
+		}
+	}
+
+	for _, binding := range c.implementersOf(serviceType) {
+		if binding.NotInherited && origin != c {
+			continue
+		}
+		binding := binding
+		ordered = append(ordered, orderedInstance{
+			Resolve: func() reflect.Value { return binding.resolve(c, origin, path) },
+			Order:   binding.Order,
+		})
+	}
+	return ordered
+}
+
+// implementers is the cached bindings of service in container, it's stale if version of bindings changed.
+type implementers struct {
+	Version  uint64
+	Bindings []*serviceBinding
+}
+
+// implementersOf to get bindings in current container in registration order, includes keyed ones, which are registered
+// as the service, or whose implementation is assignable to the service if it's interface. Bindings of the same instance
+// or sharing initializing are included once.
+func (c *defaultContainer) implementersOf(serviceType reflect.Type) []*serviceBinding {
+	version := atomic.LoadUint64(&c.bindingsVersion)
+	if cached, ok := c.implementers.Load(serviceType); ok && cached.(*implementers).Version == version {
+		return cached.(*implementers).Bindings
+	}
+
+	var bindings []*serviceBinding
+	collect := func(key, val any) bool {
+		// keyed primary is also stored without key
+		binding := val.(*serviceBinding)
+		if binding.Feature != nil && !binding.Feature.isEnabled() {
+			return true
+		}
+		if binding.matches(serviceType) && !(binding.Primary && key != binding.ServiceType) {
+			bindings = append(bindings, binding)
+		}
+		return true
+	}
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		return collect(serviceType, binding)
+	})
+	c.keyedBindings.Range(collect)
+	c.elements.Range(func(key, val any) bool {
+		for _, binding := range val.([]*serviceBinding) {
+			collect(nil, binding)
+		}
+		return true
+	})
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Seq < bindings[j].Seq
+	})
+	seen := make(map[any]bool)
+	deduplicated := bindings[:0]
+	for _, binding := range bindings {
+		var identity any = binding.implementation()
+		if instance := binding.implementation().Instance; instance.IsValid() && instance.Type().Comparable() {
+			identity = instance.Interface()
+		}
+		if !seen[identity] {
+			seen[identity] = true
+			deduplicated = append(deduplicated, binding)
+		}
+	}
+	c.implementers.Store(serviceType, &implementers{Version: version, Bindings: deduplicated})
+	return deduplicated
+}
+
+// matches to check whether binding is registered as the service, or it's implementation is assignable to the interface.
+func (b *serviceBinding) matches(serviceType reflect.Type) bool {
+	if b.AliasOf != nil {
+		// the target of alias is matched by itself
+		return false
+	}
+	if b.ServiceType == serviceType {
+		return true
+	}
+	if serviceType.Kind() != reflect.Interface || b.ServiceType == resolverType {
+		return false
+	}
+	if instance := b.implementation().Instance; instance.IsValid() {
+		return instance.Type().AssignableTo(serviceType)
+	}
+	return b.ServiceType.AssignableTo(serviceType)
+}
+
+// bindingsChanged to make cached implementers stale.
+func (c *defaultContainer) bindingsChanged() {
+	atomic.AddUint64(&c.bindingsVersion, 1)
+}
+
+// sortByOrder to sort bindings by order, and keep registration order for ties.
+func sortByOrder(bindings []*serviceBinding) []*serviceBinding {
+	sorted := make([]*serviceBinding, len(bindings))
+	copy(sorted, bindings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Order < sorted[j].Order
+	})
+	return sorted
+}
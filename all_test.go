@@ -0,0 +1,182 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestResolveAll(t *testing.T) {
+	t.Run("resolve all should be sorted by order", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		_ = parent.(Registerer).Register(&serviceInstance1{name: "parent"}, As(serviceType))
+		_ = parent.(Registerer).Register(&serviceInstance1{name: "parent-first"}, As(serviceType), Keyed("first"), Order(-1))
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "last"}, As(serviceType), Keyed("last"), Order(10))
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "default"}, As(serviceType))
+		_ = globalContainer.(Registerer).Register(nil, As(serviceType), Keyed("transient"), Transient(func() any {
+			return &serviceInstance1{name: "transient"}
+		}))
+		SetParent(parent)
+
+		var names []string
+		for _, svc := range ResolveAll[service1]() {
+			names = append(names, svc.GetName())
+		}
+		if strings.Join(names, ",") != "parent-first,parent,default,transient,last" {
+			t.Errorf("instances should be sorted by order, then ancestors' first, then registration order, but %v", names)
+			return
+		}
+		if instances := ResolveAll[service2](); len(instances) != 0 {
+			t.Error("service not registered should be empty")
+			return
+		}
+	})
+}
+
+func TestResolveAt(t *testing.T) {
+	t.Run("resolve at index should be in order of resolve all", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		created := 0
+		_ = globalContainer.(Registerer).Register(nil, As(serviceType), Keyed("transient"), Transient(func() any {
+			created++
+			return &serviceInstance1{name: "transient"}
+		}))
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "default"}, As(serviceType))
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "first"}, As(serviceType), Keyed("first"), Order(-1))
+
+		if svc := GetServiceAt[service1](0); svc == nil || svc.GetName() != "first" {
+			t.Error("index 0 should be the first one sorted by order")
+			return
+		}
+		if svc := GetServiceAt[service1](2); svc == nil || svc.GetName() != "default" {
+			t.Error("index 2 should be the last one in registration order")
+			return
+		}
+		if created != 1 {
+			t.Errorf("only instances until the index should be resolved, but transient created %d times", created)
+			return
+		}
+		if GetServiceAt[service1](3) != nil || GetServiceAt[service1](-1) != nil {
+			t.Error("out of range or negative index should be nil")
+			return
+		}
+		if globalContainer.(AllResolver).ResolveAt(serviceType, -1).IsValid() || globalContainer.(AllResolver).ResolveAt(nil, 0).IsValid() {
+			t.Error("negative index or nil service type should be invalid")
+			return
+		}
+	})
+}
+
+func TestResolveGroupOrder(t *testing.T) {
+	t.Run("resolve group should be sorted by order", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		Register(&serviceInstance1{name: "default1"}, As(serviceType), InGroup("group1"))
+		Register(&serviceInstance1{name: "second"}, As(serviceType), InGroup("group1"), Order(2))
+		AddToGroup[service1]("group1", &serviceInstance1{name: "default2"})
+		Register(&serviceInstance1{name: "first"}, As(serviceType), InGroup("group1"), Order(-1))
+
+		var names []string
+		for _, svc := range ResolveGroup[service1]("group1") {
+			names = append(names, svc.GetName())
+		}
+		if strings.Join(names, ",") != "first,default1,default2,second" {
+			t.Errorf("instances should be sorted by order, then registration order, but %v", names)
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("register in group should not register service")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(nil, As(serviceType), InGroup("group1"), Transient(func() any { return &serviceInstance1{} })); err == nil {
+			t.Error("transient in group should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, InGroup("")); err == nil {
+			t.Error("empty group name should fail")
+			return
+		}
+	})
+}
+
+func TestResolveAllCovariance(t *testing.T) {
+	t.Run("resolve all interface should include assignable registrations", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		shared := &serviceInstance1{name: "shared"}
+		AddSingleton[*serviceInstance1](&serviceInstance1{name: "concrete"})
+		AddTransient[*serviceInstance7](func() *serviceInstance7 { return &serviceInstance7{name: "transient"} })
+		AddSingleton[service1](shared)
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton("shared", reflect.TypeOf((*serviceInstance1)(nil)), shared)
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+
+		var names []string
+		for _, svc := range ResolveAll[service1]() {
+			names = append(names, svc.GetName())
+		}
+		if strings.Join(names, ",") != "concrete,transient,shared" {
+			t.Errorf("assignable registrations should be included once in registration order, but %v", names)
+			return
+		}
+		if instances := globalContainer.(AllResolver).ResolveAll(reflect.TypeOf((*serviceInstance1)(nil))); len(instances) != 2 {
+			t.Error("*struct should only include registrations of itself")
+			return
+		}
+
+		AddSingleton[service2](&serviceInstance2{name: "added"})
+		if instances := globalContainer.(AllResolver).ResolveAll(serviceType); len(instances) != 4 {
+			t.Error("cached implementers should be refreshed after registering")
+			return
+		}
+	})
+
+	t.Run("slice field without group should be injected with assignable registrations", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance1](&serviceInstance1{name: "instance1"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		client := &sliceClient{}
+		Inject(client)
+		if len(client.All) != 2 || client.All[0].GetName() != "instance1" || client.All[1].GetName() != "instance2" {
+			t.Errorf("slice field should be injected with all assignable registrations, but %v", client.All)
+			return
+		}
+		if len(client.Renamers) != 1 {
+			t.Error("slice field should only include assignable registrations")
+			return
+		}
+		AddSingleton[*sliceClient](&sliceClient{})
+		if err := VerifyContainer(globalContainer); err != nil {
+			t.Errorf("slice field should be injectable, but %v", err)
+			return
+		}
+	})
+}
+
+type sliceClient struct {
+	All      []service1 `ioc-inject:"true"`
+	Renamers []service2 `ioc-inject:"true"`
+}
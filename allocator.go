@@ -0,0 +1,117 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AllocatorMethodName is the method name of allocator convention, such as 'func (*T) New() *T',
+// which is invoked on nil *T, or zero T if the receiver is T, to create the base instance before injecting.
+const AllocatorMethodName = "New"
+
+// AddAllocator to add allocator of *struct 'T' to global container.
+//
+// It will panic if 'allocator' is null.
+func AddAllocator[T any](allocator func() *T) {
+	AddAllocatorToC[T](globalContainer, allocator)
+}
+
+// AddAllocatorToC to add allocator of *struct 'T' to container.
+//
+// It will panic if 'T' is not struct or 'allocator' is null.
+func AddAllocatorToC[T any](container Container, allocator func() *T) {
+	if allocator == nil {
+		panic("param 'allocator' is null")
+	}
+	err := container.(AllocatorAdder).AddAllocator(TypeOf[*T](), func() any {
+		return allocator()
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// AllocatorAdder is implemented by container to add allocators of base instances of '*struct'.
+type AllocatorAdder interface {
+	// AddAllocator to add allocator of *struct 'ptrType', which creates the base instance before injecting by 'ioc.Build',
+	// instead of zero value. It's resolved from parent if not found in current.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.AllocatorAdder).AddAllocator(reflect.TypeOf((*Registry)(nil)), func() any {
+	//      return &Registry{entries: make(map[string]Entry)}
+	//  })
+	AddAllocator(ptrType reflect.Type, allocator func() any) error
+}
+
+var _ AllocatorAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddAllocator(ptrType reflect.Type, allocator func() any) error {
+	if ptrType == nil {
+		return errors.New("param 'ptrType' is null")
+	}
+	if ptrType.Kind() != reflect.Pointer || ptrType.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("type '%v' should be *struct", ptrType)
+	}
+	if allocator == nil {
+		return errors.New("param 'allocator' is null")
+	}
+	c.allocators.Store(ptrType, allocator)
+	return nil
+}
+
+// getAllocator to get allocator of *struct from current and then parent chain.
+func (c *defaultContainer) getAllocator(ptrType reflect.Type) func() any {
+	for container := c; container != nil; container, _ = container.parent.(*defaultContainer) {
+		if allocator, ok := container.allocators.Load(ptrType); ok {
+			return allocator.(func() any)
+		}
+	}
+	return nil
+}
+
+// allocate to create base instance of *struct 'ptrType', with precedence:
+// allocator added to container > allocator method convention > zero value.
+func allocate(c Container, ptrType reflect.Type) (reflect.Value, error) {
+	if dc, ok := c.(*defaultContainer); ok {
+		if allocator := dc.getAllocator(ptrType); allocator != nil {
+			ptr := reflect.ValueOf(allocator())
+			if !ptr.IsValid() || ptr.Type() != ptrType || ptr.IsNil() {
+				return reflect.Value{}, fmt.Errorf("allocator of '%v' should return non-nil '%v'", ptrType, ptrType)
+			}
+			return ptr, nil
+		}
+	}
+	for _, receiver := range []reflect.Type{ptrType.Elem(), ptrType} {
+		method, ok := receiver.MethodByName(AllocatorMethodName)
+		if !ok || method.Type.NumIn() != 1 || method.Type.NumOut() != 1 || method.Type.Out(0) != ptrType {
+			continue
+		}
+		if ptr := reflect.Zero(receiver).Method(method.Index).Call(nil)[0]; !ptr.IsNil() {
+			return ptr, nil
+		}
+		return reflect.Value{}, fmt.Errorf("method '%s' of '%v' should return non-nil '%v'", AllocatorMethodName, receiver, ptrType)
+	}
+	return reflect.New(ptrType.Elem()), nil
+}
@@ -0,0 +1,119 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type allocatedTarget struct {
+	S1      service1 `ioc-inject:"true"`
+	entries map[string]int
+	source  string
+}
+
+type conventionTarget struct {
+	S1      service1 `ioc-inject:"true"`
+	entries map[string]int
+}
+
+func (*conventionTarget) New() *conventionTarget {
+	return &conventionTarget{entries: map[string]int{"convention": 1}}
+}
+
+type valueConventionTarget struct {
+	entries map[string]int
+}
+
+func (valueConventionTarget) New() *valueConventionTarget {
+	return &valueConventionTarget{entries: map[string]int{"value": 1}}
+}
+
+func TestAllocator(t *testing.T) {
+	t.Run("build should start from allocated instance", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddAllocator[allocatedTarget](func() *allocatedTarget {
+			return &allocatedTarget{entries: make(map[string]int), source: "allocator"}
+		})
+
+		instance := Build[*allocatedTarget](globalContainer)
+		if instance.entries == nil || instance.source != "allocator" {
+			t.Error("instance should be created by allocator")
+			return
+		}
+		if instance.S1 == nil {
+			t.Error("fields should be injected after allocated")
+			return
+		}
+		if value := Build[allocatedTarget](globalContainer); value.entries == nil || value.S1 == nil {
+			t.Error("struct should be created by allocator too")
+			return
+		}
+	})
+
+	t.Run("build should use allocator method convention", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		if instance := Build[*conventionTarget](globalContainer); instance.entries["convention"] != 1 || instance.S1 == nil {
+			t.Error("instance should be created by method 'New' and then injected")
+			return
+		}
+		if instance := Build[*valueConventionTarget](globalContainer); instance.entries["value"] != 1 {
+			t.Error("instance should be created by method 'New' with value receiver")
+			return
+		}
+	})
+
+	t.Run("allocator added should take precedence over convention, and be found from parent", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddAllocatorToC[conventionTarget](parent, func() *conventionTarget {
+			return &conventionTarget{entries: map[string]int{"allocator": 1}}
+		})
+		globalContainer.SetParent(parent)
+		if instance := Build[*conventionTarget](globalContainer); instance.entries["allocator"] != 1 {
+			t.Error("allocator added should take precedence")
+			return
+		}
+	})
+
+	t.Run("invalid allocator should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(AllocatorAdder).AddAllocator(reflect.TypeOf(allocatedTarget{}), func() any { return nil }); err == nil {
+			t.Error("non-pointer type should fail")
+			return
+		}
+		if err := globalContainer.(AllocatorAdder).AddAllocator(reflect.TypeOf((*allocatedTarget)(nil)), nil); err == nil {
+			t.Error("nil allocator should fail")
+			return
+		}
+		_ = globalContainer.(AllocatorAdder).AddAllocator(reflect.TypeOf((*allocatedTarget)(nil)), func() any { return nil })
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("allocator returns nil should panic")
+			}
+		}()
+		Build[*allocatedTarget](globalContainer)
+	})
+}
@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+)
+
+// GetServiceAs to get service 'TService' from global container, only if the instance is 'TImpl', otherwise returns zero value.
+//
+//	// resolve 'Service1' only if it's implemented by '*ServiceImplementation1'
+//	service1 := ioc.GetServiceAs[*ServiceImplementation1, Service1]()
+func GetServiceAs[TImpl any, TService any]() TService {
+	return GetServiceAsFromC[TImpl, TService](globalContainer)
+}
+
+// GetServiceAsFromC to get service 'TService' from container, only if the instance is 'TImpl', otherwise returns zero value.
+func GetServiceAsFromC[TImpl any, TService any](container Container) TService {
+	service, _ := GetServiceAsEFromC[TImpl, TService](container)
+	return service
+}
+
+// GetServiceAsE to get service 'TService' from global container, and returns error if not found or the instance is not 'TImpl'.
+func GetServiceAsE[TImpl any, TService any]() (TService, error) {
+	return GetServiceAsEFromC[TImpl, TService](globalContainer)
+}
+
+// GetServiceAsEFromC to get service 'TService' from container, and returns error if not found or the instance is not 'TImpl'.
+func GetServiceAsEFromC[TImpl any, TService any](container Container) (TService, error) {
+	var service TService
+	instanceVal := container.Resolve(TypeOf[TService]())
+	if !instanceVal.IsValid() || instanceVal.IsZero() {
+		return service, fmt.Errorf("service '%v' not found", TypeOf[TService]())
+	}
+	instance := instanceVal.Interface()
+	if _, ok := instance.(TImpl); !ok {
+		return service, fmt.Errorf("instance of service '%v' is '%T', not '%v'", TypeOf[TService](), instance, TypeOf[TImpl]())
+	}
+	service, _ = instance.(TService)
+	return service, nil
+}
@@ -0,0 +1,61 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestGetServiceAs(t *testing.T) {
+	t.Run("get service as matching implementation should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+
+		if GetServiceAs[*serviceInstance1, service1]() != svc1 {
+			t.Error("service should be returned if implementation matches")
+			return
+		}
+		if service, err := GetServiceAsE[*serviceInstance1, service1](); err != nil || service != svc1 {
+			t.Errorf("service should be returned without error, but %v", err)
+			return
+		}
+	})
+
+	t.Run("get service as mismatching implementation should be zero", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		if GetServiceAs[*serviceInstance2, service1]() != nil {
+			t.Error("zero value should be returned if implementation mismatches")
+			return
+		}
+		if _, err := GetServiceAsE[*serviceInstance2, service1](); err == nil {
+			t.Error("error should be returned if implementation mismatches")
+			return
+		}
+		if _, err := GetServiceAsE[*serviceInstance2, service2](); err == nil {
+			t.Error("error should be returned if not found")
+			return
+		}
+	})
+}
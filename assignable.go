@@ -0,0 +1,144 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync/atomic"
+)
+
+// AssignablePolicy controls what 'AssignableResolver.ResolveAssignable' does when multiple registrations are assignable to the service.
+type AssignablePolicy int32
+
+const (
+	// AssignableFirst to choose the first registered one, it's the default policy.
+	AssignableFirst AssignablePolicy = iota
+	// AssignablePrimary to choose the one marked by 'ioc.AsPrimary', and fail if there is none.
+	AssignablePrimary
+	// AssignableError to fail with error listing candidates.
+	AssignableError
+)
+
+// ResolveAssignable to resolve the registration assignable to 'TService' from global container, see 'AssignableResolver.ResolveAssignable'.
+func ResolveAssignable[TService any]() (TService, error) {
+	return ResolveAssignableFromC[TService](globalContainer)
+}
+
+// ResolveAssignableFromC to resolve the registration assignable to 'TService' from container, see 'AssignableResolver.ResolveAssignable'.
+func ResolveAssignableFromC[TService any](container Container) (TService, error) {
+	var instance TService
+	instanceVal, err := container.(AssignableResolver).ResolveAssignable(TypeOf[TService]())
+	if err != nil || !instanceVal.IsValid() {
+		return instance, err
+	}
+	instance, _ = instanceVal.Interface().(TService)
+	return instance, nil
+}
+
+// AssignableResolver is implemented by container to resolve registration assignable to service.
+type AssignableResolver interface {
+	// SetAssignablePolicy to set how 'ResolveAssignable' chooses when multiple registrations are assignable to the service,
+	// default is 'ioc.AssignableFirst'.
+	SetAssignablePolicy(policy AssignablePolicy)
+
+	// ResolveAssignable to resolve the registration as the service or assignable to it if it's interface, from the nearest
+	// container in parent chain which has any. If multiple ones exist, it's chosen by the policy set by 'SetAssignablePolicy'.
+	//
+	//  container.(ioc.AssignableResolver).SetAssignablePolicy(ioc.AssignableError)
+	//  val, err := container.(ioc.AssignableResolver).ResolveAssignable(reflect.TypeOf((*io.Writer)(nil)).Elem())
+	ResolveAssignable(serviceType reflect.Type) (reflect.Value, error)
+}
+
+var _ AssignableResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetAssignablePolicy(policy AssignablePolicy) {
+	atomic.StoreInt32(&c.assignable, int32(policy))
+}
+
+func (c *defaultContainer) ResolveAssignable(serviceType reflect.Type) (reflect.Value, error) {
+	if serviceType == nil {
+		return reflect.Value{}, errors.New("param 'serviceType' is null")
+	}
+	policy := AssignablePolicy(atomic.LoadInt32(&c.assignable))
+	for current := c; current != nil; {
+		var candidates []*serviceBinding
+		for _, binding := range current.implementersOf(serviceType) {
+			if !binding.NotInherited || current == c {
+				candidates = append(candidates, binding)
+			}
+		}
+		if len(candidates) > 0 {
+			binding, err := chooseAssignable(serviceType, candidates, policy)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			return binding.resolve(current, c, nil), nil
+		}
+		switch parent := current.parent.(type) {
+		case *defaultContainer:
+			current = parent
+		case nil:
+			current = nil
+		default:
+			if val := parent.Resolve(serviceType); val.IsValid() {
+				return val, nil
+			}
+			current = nil
+		}
+	}
+	return reflect.Value{}, fmt.Errorf("service '%v' not found", serviceType)
+}
+
+// chooseAssignable to choose one of candidates in registration order by policy.
+func chooseAssignable(serviceType reflect.Type, candidates []*serviceBinding, policy AssignablePolicy) (*serviceBinding, error) {
+	if len(candidates) == 1 {
+		return candidates[0], nil
+	}
+	switch policy {
+	case AssignablePrimary:
+		for _, binding := range candidates {
+			if binding.Primary {
+				return binding, nil
+			}
+		}
+		return nil, fmt.Errorf("no primary of service '%v' in candidates: %s", serviceType, describeCandidates(candidates))
+	case AssignableError:
+		return nil, fmt.Errorf("service '%v' is ambiguous in candidates: %s", serviceType, describeCandidates(candidates))
+	}
+	return candidates[0], nil
+}
+
+// describeCandidates to list implementation types of candidates with their registration sites.
+func describeCandidates(candidates []*serviceBinding) string {
+	names := make([]string, 0, len(candidates))
+	for _, binding := range candidates {
+		implementationType := binding.ServiceType
+		if instance := binding.implementation().Instance; instance.IsValid() {
+			implementationType = instance.Type()
+		}
+		names = append(names, fmt.Sprintf("'%v'%s", implementationType, binding.registeredAtSuffix()))
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,106 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveAssignable(t *testing.T) {
+	register := func() (*serviceInstance1, *serviceInstance2) {
+		globalContainer = New()
+		svc1, svc2 := &serviceInstance1{name: "instance1"}, &serviceInstance2{name: "instance2"}
+		AddSingleton[*serviceInstance1](svc1)
+		Register(svc2, AsPrimary())
+		return svc1, svc2
+	}
+
+	t.Run("first policy should choose the first registered", func(t *testing.T) {
+		svc1, _ := register()
+		svc, err := ResolveAssignable[service1]()
+		if err != nil || svc != svc1 {
+			t.Errorf("first policy should choose the first registered, but got '%v', '%v'", svc, err)
+			return
+		}
+	})
+
+	t.Run("primary policy should choose the primary", func(t *testing.T) {
+		_, svc2 := register()
+		globalContainer.(AssignableResolver).SetAssignablePolicy(AssignablePrimary)
+		svc, err := ResolveAssignable[service1]()
+		if err != nil || svc != svc2 {
+			t.Errorf("primary policy should choose the primary, but got '%v', '%v'", svc, err)
+			return
+		}
+
+		globalContainer = New()
+		globalContainer.(AssignableResolver).SetAssignablePolicy(AssignablePrimary)
+		AddSingleton[*serviceInstance1](&serviceInstance1{name: "instance1"})
+		AddSingleton[*serviceInstance2](&serviceInstance2{name: "instance2"})
+		if _, err = ResolveAssignable[service1](); err == nil {
+			t.Error("primary policy without primary should fail")
+			return
+		}
+	})
+
+	t.Run("error policy should list candidates", func(t *testing.T) {
+		register()
+		globalContainer.(AssignableResolver).SetAssignablePolicy(AssignableError)
+		_, err := ResolveAssignable[service1]()
+		if err == nil || !strings.Contains(err.Error(), "'*ioc.serviceInstance1'") || !strings.Contains(err.Error(), "'*ioc.serviceInstance2'") {
+			t.Errorf("error policy should list candidates, but got '%v'", err)
+			return
+		}
+		svc, err := ResolveAssignable[service2]()
+		if err != nil || svc == nil || svc.GetName() != "instance2" {
+			t.Error("single candidate should be resolved by error policy")
+			return
+		}
+	})
+
+	t.Run("nearest container with candidates should be chosen", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[*serviceInstance1](parent, &serviceInstance1{name: "parent"})
+		globalContainer.SetParent(parent)
+		globalContainer.(AssignableResolver).SetAssignablePolicy(AssignableError)
+		svc, err := ResolveAssignable[service1]()
+		if err != nil || svc == nil || svc.GetName() != "parent" {
+			t.Error("candidate of parent should be resolved")
+			return
+		}
+		AddSingleton[*serviceInstance2](&serviceInstance2{name: "child"})
+		if svc, err = ResolveAssignable[service1](); err != nil || svc.GetName() != "child" {
+			t.Error("candidate of child should shadow parent's")
+			return
+		}
+		if _, err = ResolveAssignable[service4](); err != nil {
+			t.Error(err)
+			return
+		}
+		if _, err = globalContainer.(AssignableResolver).ResolveAssignable(TypeOf[*serviceInstance3]()); err == nil {
+			t.Error("service not found should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,129 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// asyncSingleton is the construction of singleton in background, see 'AsyncAdder.AddSingletonAsync'.
+type asyncSingleton struct {
+	done    chan struct{}   // closed after construction completes
+	binding *serviceBinding // singleton binding of instance constructed, nil if failed
+	err     error           // error of construction
+}
+
+// AddSingletonAsync to add singleton service to global container, whose instance is constructed in background.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddSingletonAsync[TService any](instanceFactory func() TService) {
+	AddSingletonAsyncToC[TService](globalContainer, instanceFactory)
+}
+
+// AddSingletonAsyncToC to add singleton service to container, whose instance is constructed in background.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddSingletonAsyncToC[TService any](container Container, instanceFactory func() TService) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	err := container.(AsyncAdder).AddSingletonAsync(TypeOf[TService](), func() any {
+		return instanceFactory()
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// AsyncAdder is implemented by container to add singleton constructed in background.
+type AsyncAdder interface {
+	// AddSingletonAsync to add singleton service, whose instance is constructed by factory in a background goroutine
+	// started at adding, to overlap expensive construction with startup. Resolving blocks until the construction completes,
+	// use 'ResolveWithContext' to time out waiting. The instance is then injected and initialized as singleton when resolving.
+	// If construction fails, such as factory panics or returns null, the error is cached and panics on each resolving,
+	// which can be got by 'ResolveE', or logged and resolved as not found if 'SetRecoverFactoryPanics' is enabled.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.AsyncAdder).AddSingletonAsync(reflect.TypeOf((*Index)(nil)).Elem(), func() any { return loadIndex() })
+	AddSingletonAsync(serviceType reflect.Type, instanceFactory func() any) error
+}
+
+var _ AsyncAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSingletonAsync(serviceType reflect.Type, instanceFactory func() any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	async := &asyncSingleton{done: make(chan struct{})}
+	stored, err := c.tryAddBinding(&serviceBinding{ServiceType: serviceType, Async: async})
+	if stored {
+		// not construct the one ignored by duplicate policy
+		go async.construct(serviceType, instanceFactory)
+	}
+	return err
+}
+
+// construct instance by factory, and cache the singleton binding of it or the error.
+func (a *asyncSingleton) construct(serviceType reflect.Type, instanceFactory func() any) {
+	defer close(a.done)
+	defer func() {
+		if r := recover(); r != nil {
+			a.err = panicError(fmt.Sprintf("async factory of service '%v' panic", serviceType), r)
+		}
+	}()
+	instance := instanceFactory()
+	if err := checkInstance(instance); err != nil {
+		a.err = fmt.Errorf("async factory of service '%v' fail: %w", serviceType, err)
+		return
+	}
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err == nil {
+		err = validateBinding(binding)
+	}
+	if err != nil {
+		a.err = fmt.Errorf("async factory of service '%v' fail: %w", serviceType, err)
+		return
+	}
+	a.binding = binding
+}
+
+// resolveAsync to wait for construction in background, and resolve the instance as singleton in the call of 'path'.
+func (c *defaultContainer) resolveAsync(b *serviceBinding, path *resolvePath) reflect.Value {
+	<-b.Async.done
+	if b.Async.err != nil {
+		if atomic.LoadInt32(&c.recoverFactory) == 1 {
+			c.logf("%v", b.Async.err)
+			if path != nil {
+				path.call.recovered(append(path.types(), b.ServiceType), b.Async.err)
+			}
+			return reflect.Value{}
+		}
+		panic(b.Async.err)
+	}
+	return b.Async.binding.resolve(c, c, path)
+}
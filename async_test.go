@@ -0,0 +1,142 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestAddSingletonAsync(t *testing.T) {
+	t.Run("resolve before construction completes should block", func(t *testing.T) {
+		globalContainer = New()
+		release := make(chan struct{})
+		AddSingletonAsync[service1](func() service1 {
+			<-release
+			return &serviceInstance1{name: "async"}
+		})
+		resolved := make(chan service1)
+		go func() { resolved <- GetService[service1]() }()
+		select {
+		case <-resolved:
+			t.Error("resolve before construction completes should block")
+			return
+		case <-time.After(20 * time.Millisecond):
+		}
+		close(release)
+		select {
+		case svc := <-resolved:
+			if svc == nil || svc.GetName() != "async" {
+				t.Error("resolve should get instance after construction completes")
+				return
+			}
+		case <-time.After(time.Second):
+			t.Error("resolve should return after construction completes")
+			return
+		}
+	})
+
+	t.Run("resolve after construction completes should be instant and singleton", func(t *testing.T) {
+		globalContainer = New()
+		AddSingletonAsync[service1](func() service1 { return &serviceInstance1{name: "async"} })
+		first := GetService[service1]()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		val, err := globalContainer.(ContextResolver).ResolveWithContext(ctx, TypeOf[service1]())
+		if err != nil || val.Interface().(service1) != first {
+			t.Error("resolve after construction completes should get the same instance")
+			return
+		}
+	})
+
+	t.Run("resolve with context should time out waiting for construction", func(t *testing.T) {
+		globalContainer = New()
+		release := make(chan struct{})
+		defer close(release)
+		AddSingletonAsync[service1](func() service1 {
+			<-release
+			return &serviceInstance1{name: "async"}
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		if _, err := globalContainer.(ContextResolver).ResolveWithContext(ctx, TypeOf[service1]()); !errors.Is(err, context.DeadlineExceeded) {
+			t.Error("resolve with context should time out waiting for construction")
+			return
+		}
+	})
+
+	t.Run("failed construction should be cached and surfaced on resolve", func(t *testing.T) {
+		globalContainer = New()
+		calls := 0
+		AddSingletonAsync[service1](func() service1 {
+			calls++
+			panic("construct fail")
+		})
+		for i := 0; i < 2; i++ {
+			if _, err := GetServiceE[service1](); err == nil {
+				t.Error("failed construction should be surfaced on resolve")
+				return
+			}
+		}
+		if calls != 1 {
+			t.Errorf("failed construction should be cached, but factory called %d times", calls)
+			return
+		}
+	})
+
+	t.Run("null instance should be surfaced on resolve", func(t *testing.T) {
+		globalContainer = New()
+		AddSingletonAsync[service1](func() service1 { return nil })
+		if _, err := GetServiceE[service1](); err == nil {
+			t.Error("null instance should be surfaced on resolve")
+			return
+		}
+	})
+
+	t.Run("failed construction should resolve as not found when recovering", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		AddSingletonAsync[service1](func() service1 { panic("construct fail") })
+		if svc := GetService[service1](); svc != nil {
+			t.Error("failed construction should resolve as not found when recovering")
+			return
+		}
+	})
+	t.Run("async singleton ignored by duplicate policy should not be constructed", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		var constructed int32
+		AddSingletonAsync[service1](func() service1 {
+			atomic.AddInt32(&constructed, 1)
+			return &serviceInstance1{}
+		})
+		time.Sleep(10 * time.Millisecond)
+		if GetService[service1]() != svc1 || atomic.LoadInt32(&constructed) != 0 {
+			t.Error("async singleton ignored should not be constructed")
+			return
+		}
+	})
+}
@@ -0,0 +1,114 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// autoInjectUsed is 1 if any container has set package to inject without tag, so injecting doesn't walk containers if unused.
+var autoInjectUsed int32
+
+// AutoInjector is implemented by container to inject fields without tag by package path.
+type AutoInjector interface {
+	// SetAutoInjectPackage to inject all exported fields resolvable without tag 'ioc-inject' to structs whose package path is
+	// 'pkgPath' or under it, when injecting with current container or it's child. Fields with tag are injected as the tag says,
+	// and tag 'ioc-inject:"false"' excludes the field. Fields can't be resolved are skipped even in strict mode.
+	//
+	//  container.(ioc.AutoInjector).SetAutoInjectPackage("example.com/app/internal")
+	SetAutoInjectPackage(pkgPath string)
+}
+
+var _ AutoInjector = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetAutoInjectPackage(pkgPath string) {
+	atomic.StoreInt32(&autoInjectUsed, 1)
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	pkgPaths, _ := c.autoInjectPkgs.Load().([]string)
+	for _, existing := range pkgPaths {
+		if existing == pkgPath {
+			return
+		}
+	}
+	// copy on write, so package paths can be read without lock
+	newPkgPaths := make([]string, 0, len(pkgPaths)+1)
+	newPkgPaths = append(newPkgPaths, pkgPaths...)
+	newPkgPaths = append(newPkgPaths, pkgPath)
+	c.autoInjectPkgs.Store(newPkgPaths)
+}
+
+// autoInjects to check whether struct type is under any package set by 'SetAutoInjectPackage' of current or ancestors.
+func (c *defaultContainer) autoInjects(structType reflect.Type) bool {
+	if atomic.LoadInt32(&autoInjectUsed) == 0 {
+		return false
+	}
+	pkgPath := structType.PkgPath()
+	for current := c; current != nil; current, _ = current.Parent().(*defaultContainer) {
+		pkgPaths, _ := current.autoInjectPkgs.Load().([]string)
+		for _, prefix := range pkgPaths {
+			if underPackage(pkgPath, prefix) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// injectUntagged to inject fields without tag which can be resolved.
+func (c *defaultContainer) injectUntagged(targetVal reflect.Value, structType reflect.Type, path *resolvePath) {
+	for _, field := range getUntaggedFields(structType) {
+		if !c.canResolve(field.FieldType) {
+			continue
+		}
+		if val := c.resolveLenient(field.FieldType, path); val.IsValid() {
+			targetVal.Elem().Field(field.FieldIndex).Set(val)
+		}
+	}
+}
+
+var structTypeToUntaggedFieldsCache sync.Map
+
+// getUntaggedFields to get exported fields without tag 'ioc-inject' or 'ioc-config', which are not injected by 'getFieldsToInject'.
+func getUntaggedFields(structType reflect.Type) []structField {
+	if val, ok := structTypeToUntaggedFieldsCache.Load(structType); ok {
+		return val.([]structField)
+	}
+	var fields []structField
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() || field.Anonymous || field.Type == resolverType || field.Type == injectionContextType {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("ioc-inject"); ok {
+			continue
+		}
+		if _, ok := field.Tag.Lookup("ioc-config"); ok {
+			continue
+		}
+		fields = append(fields, structField{FieldIndex: i, FieldName: field.Name, FieldType: field.Type})
+	}
+	structTypeToUntaggedFieldsCache.Store(structType, fields)
+	return fields
+}
@@ -0,0 +1,86 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+type untaggedClient struct {
+	Svc1     service1
+	Svc2     service2 `ioc-inject:"false"`
+	Svc3     service3
+	Keyed    service1 `ioc-inject:"key=keyed"`
+	Name     string
+	internal service1
+}
+
+func TestSetAutoInjectPackage(t *testing.T) {
+	t.Run("untagged fields should be injected only for configured package", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		keyed := &serviceInstance1{name: "keyed"}
+		AddSingleton[service1](svc1)
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		AddKeyed[service1]("keyed", keyed)
+
+		globalContainer.(AutoInjector).SetAutoInjectPackage("example.com/other")
+		client := &untaggedClient{}
+		Inject(client)
+		if client.Svc1 != nil || client.Keyed != keyed {
+			t.Error("untagged fields of struct from other package should not be injected")
+			return
+		}
+
+		globalContainer.(AutoInjector).SetAutoInjectPackage("gopkg.berkaroad.top/ioc")
+		client = &untaggedClient{}
+		Inject(client)
+		if client.Svc1 != svc1 || client.Keyed != keyed {
+			t.Error("untagged fields of struct from configured package should be injected")
+			return
+		}
+		if client.Svc2 != nil || client.internal != nil {
+			t.Error("fields excluded by tag or unexported should not be injected")
+			return
+		}
+		if client.Svc3 != nil || client.Name != "" {
+			t.Error("fields can't be resolved should be skipped")
+			return
+		}
+	})
+
+	t.Run("child container should inherit packages and skip missing in strict mode", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(AutoInjector).SetAutoInjectPackage("gopkg.berkaroad.top")
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		child := New()
+		child.SetParent(globalContainer)
+		child.(StrictResolver).SetStrictResolve(true)
+		client := &untaggedClient{}
+		InjectFromC(child, client)
+		if client.Svc1 != svc1 || client.Svc3 != nil {
+			t.Error("child container should inherit packages and skip missing in strict mode")
+			return
+		}
+	})
+}
@@ -0,0 +1,122 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AutoRegister to add each singleton instance to global container as it's *struct type and interfaces it implements,
+// see 'AutoRegisterer.AutoRegister'.
+//
+// It will panic if any instance is invalid.
+func AutoRegister(instances ...any) {
+	AutoRegisterToC(globalContainer, instances...)
+}
+
+// AutoRegisterToC to add each singleton instance to container as it's *struct type and interfaces it implements,
+// see 'AutoRegisterer.AutoRegister'.
+//
+// It will panic if any instance is invalid.
+func AutoRegisterToC(container Container, instances ...any) {
+	if err := container.(AutoRegisterer).AutoRegister(instances...); err != nil {
+		panic(err)
+	}
+}
+
+// AutoRegisterer is implemented by container to add instances as interfaces they implement.
+type AutoRegisterer interface {
+	// SetAutoRegisterInterfaces to set interfaces which 'AutoRegister' registers instances as, it replaces the previous ones.
+	// It returns error if any type is not interface.
+	//
+	//  err := container.(ioc.AutoRegisterer).SetAutoRegisterInterfaces(reflect.TypeOf((*Reader)(nil)).Elem(), reflect.TypeOf((*Writer)(nil)).Elem())
+	SetAutoRegisterInterfaces(interfaceTypes ...reflect.Type) error
+
+	// AutoRegister to add each singleton instance as it's *struct type, and also as each interface it implements
+	// in the ones set by 'SetAutoRegisterInterfaces'. The interfaces share the instance with the *struct type,
+	// so it's initialized once whichever is resolved first.
+	// Types already registered are handled by the duplicate policy, and interfaces share the one registered for the *struct type.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.AutoRegisterer).AutoRegister(&FileStore{}, &HttpClient{})
+	AutoRegister(instances ...any) error
+}
+
+var _ AutoRegisterer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetAutoRegisterInterfaces(interfaceTypes ...reflect.Type) error {
+	for _, interfaceType := range interfaceTypes {
+		if interfaceType == nil || interfaceType.Kind() != reflect.Interface {
+			return fmt.Errorf("type '%v' should be an interface", interfaceType)
+		}
+	}
+	c.locker.Lock()
+	c.autoInterfaces = append([]reflect.Type(nil), interfaceTypes...)
+	c.locker.Unlock()
+	return nil
+}
+
+func (c *defaultContainer) AutoRegister(instances ...any) error {
+	c.locker.Lock()
+	interfaceTypes := c.autoInterfaces
+	c.locker.Unlock()
+
+	var errs []error
+	for _, instance := range instances {
+		if err := c.autoRegister(instance, interfaceTypes); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// implementation to get the binding which is actually resolved.
+func (b *serviceBinding) implementation() *serviceBinding {
+	if b.Target != nil {
+		return b.Target
+	}
+	return b
+}
+
+// autoRegister to add instance as it's type, and add interfaces resolved as the binding of it's type.
+func (c *defaultContainer) autoRegister(instance any, interfaceTypes []reflect.Type) error {
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	instanceType := reflect.TypeOf(instance)
+	if err := c.AddSingleton(instanceType, instance); err != nil {
+		return err
+	}
+	// it's the existing one if ignored by duplicate policy
+	target := c.getBinding(instanceType)
+	var errs []error
+	for _, interfaceType := range interfaceTypes {
+		if !instanceType.Implements(interfaceType) {
+			continue
+		}
+		if err := c.addBinding(&serviceBinding{ServiceType: interfaceType, Target: target}); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return aggregateErrors(errs)
+}
@@ -0,0 +1,113 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync/atomic"
+	"testing"
+)
+
+type multiRoleService struct {
+	name             string
+	initializedTimes int32
+}
+
+func (s *multiRoleService) GetName() string {
+	return s.name
+}
+
+func (s *multiRoleService) Rename(name string) {
+	s.name = name
+}
+
+func (s *multiRoleService) Initialize(resolver Resolver) {
+	atomic.AddInt32(&s.initializedTimes, 1)
+}
+
+func TestAutoRegister(t *testing.T) {
+	service1Type := reflect.TypeOf((*service1)(nil)).Elem()
+	service2Type := reflect.TypeOf((*service2)(nil)).Elem()
+
+	t.Run("instance should be registered as it's type and interfaces in allow-list", func(t *testing.T) {
+		globalContainer = New()
+		_ = globalContainer.(AutoRegisterer).SetAutoRegisterInterfaces(service1Type, service2Type, reflect.TypeOf((*Stoppable)(nil)).Elem())
+		svc := &multiRoleService{name: "multi"}
+		AutoRegister(svc)
+
+		if GetService[service1]() != svc || GetService[service2]() != svc || GetService[*multiRoleService]() != svc {
+			t.Error("instance should be resolved as it's type and both interfaces")
+			return
+		}
+		if GetService[Stoppable]() != nil {
+			t.Error("interface not implemented should not be registered")
+			return
+		}
+		if atomic.LoadInt32(&svc.initializedTimes) != 1 {
+			t.Errorf("instance should be initialized once, but %d times", svc.initializedTimes)
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(service1Type); lifetime != LifetimeSingleton {
+			t.Error("interface should be singleton")
+			return
+		}
+	})
+
+	t.Run("interface already bound should follow duplicate policy", func(t *testing.T) {
+		globalContainer = New()
+		_ = globalContainer.(AutoRegisterer).SetAutoRegisterInterfaces(service1Type, service2Type)
+		existing := &serviceInstance1{name: "existing"}
+		AddSingleton[service1](existing)
+		svc := &multiRoleService{name: "multi"}
+		AutoRegister(svc)
+		if GetService[service1]() != existing || GetService[service2]() != svc {
+			t.Error("existing one should be kept by default")
+			return
+		}
+
+		globalContainer = New()
+		_ = globalContainer.(AutoRegisterer).SetAutoRegisterInterfaces(service1Type, service2Type)
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateError)
+		AddSingleton[service1](existing)
+		if err := globalContainer.(AutoRegisterer).AutoRegister(&multiRoleService{name: "multi"}); err == nil {
+			t.Error("duplicate should fail by policy")
+			return
+		}
+		if GetService[service2]() == nil {
+			t.Error("interfaces not conflicted should still be registered")
+			return
+		}
+	})
+
+	t.Run("invalid params should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(AutoRegisterer).SetAutoRegisterInterfaces(reflect.TypeOf((*serviceInstance1)(nil))); err == nil {
+			t.Error("non-interface should fail")
+			return
+		}
+		var svc *multiRoleService
+		if err := globalContainer.(AutoRegisterer).AutoRegister(svc); err == nil {
+			t.Error("nil instance should fail")
+			return
+		}
+	})
+}
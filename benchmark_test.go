@@ -23,6 +23,7 @@ package ioc
 
 import (
 	"context"
+	"reflect"
 	"testing"
 )
 
@@ -39,6 +40,64 @@ func BenchmarkGetSingletonService(b *testing.B) {
 	}
 }
 
+func BenchmarkGetSingletonServiceParallel(b *testing.B) {
+	globalContainer = New()
+	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
+	AddSingleton[ProductCategoryRepository2](&ProductCategoryRepositoryImpl{})
+	AddSingleton[*ProductCategoryApplicationServiceImpl](&ProductCategoryApplicationServiceImpl{})
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			svc := GetService[*ProductCategoryApplicationServiceImpl]()
+			svc.Get(context.TODO(), "123")
+		}
+	})
+}
+
+func BenchmarkGetSingletonServiceByProvider(b *testing.B) {
+	globalContainer = New()
+	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
+	AddSingleton[ProductCategoryRepository2](&ProductCategoryRepositoryImpl{})
+	AddSingleton[*ProductCategoryApplicationServiceImpl](&ProductCategoryApplicationServiceImpl{})
+	provide := ProviderFor[*ProductCategoryApplicationServiceImpl](globalContainer)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		svc := provide()
+		svc.Get(context.TODO(), "123")
+	}
+}
+
+func BenchmarkGetSingletonServiceBySealed(b *testing.B) {
+	globalContainer = New()
+	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
+	AddSingleton[ProductCategoryRepository2](&ProductCategoryRepositoryImpl{})
+	AddSingleton[*ProductCategoryApplicationServiceImpl](&ProductCategoryApplicationServiceImpl{})
+	sealed := globalContainer.(Sealer).Seal()
+	serviceType := TypeOf[*ProductCategoryApplicationServiceImpl]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		svc := sealed.Resolve(serviceType).Interface().(*ProductCategoryApplicationServiceImpl)
+		svc.Get(context.TODO(), "123")
+	}
+}
+
+func BenchmarkGetSingletonServiceByUnsealed(b *testing.B) {
+	globalContainer = New()
+	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
+	AddSingleton[ProductCategoryRepository2](&ProductCategoryRepositoryImpl{})
+	AddSingleton[*ProductCategoryApplicationServiceImpl](&ProductCategoryApplicationServiceImpl{})
+	serviceType := TypeOf[*ProductCategoryApplicationServiceImpl]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		svc := globalContainer.Resolve(serviceType).Interface().(*ProductCategoryApplicationServiceImpl)
+		svc.Get(context.TODO(), "123")
+	}
+}
+
 func BenchmarkGetTransientService(b *testing.B) {
 	globalContainer = New()
 	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
@@ -110,6 +169,19 @@ func BenchmarkInjectToStruct(b *testing.B) {
 	}
 }
 
+func BenchmarkInjectToSelfInjector(b *testing.B) {
+	globalContainer = New()
+	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
+	AddSingleton[ProductCategoryRepository2](&ProductCategoryRepositoryImpl{})
+	svc := &ProductCategorySelfInjectorImpl{}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Inject(svc)
+		svc.Get(context.TODO(), "123")
+	}
+}
+
 func BenchmarkInjectToStructNative(b *testing.B) {
 	globalContainer = New()
 	AddSingleton[ProductCategoryRepository](&ProductCategoryRepositoryImpl{})
@@ -147,6 +219,21 @@ func (svc *ProductCategoryApplicationServiceImpl) Get(ctx context.Context, id st
 	return svc.Repo.Get(id)
 }
 
+var _ SelfInjector = (*ProductCategorySelfInjectorImpl)(nil)
+
+type ProductCategorySelfInjectorImpl struct {
+	ProductCategoryApplicationServiceImpl
+}
+
+var productCategoryRepositoryType = reflect.TypeOf((*ProductCategoryRepository)(nil)).Elem()
+var productCategoryRepository2Type = reflect.TypeOf((*ProductCategoryRepository2)(nil)).Elem()
+
+func (svc *ProductCategorySelfInjectorImpl) InjectDeps(resolver Resolver) {
+	svc.Resolver = resolver
+	svc.Repo, _ = resolver.Resolve(productCategoryRepositoryType).Interface().(ProductCategoryRepository)
+	svc.Repo2, _ = resolver.Resolve(productCategoryRepository2Type).Interface().(ProductCategoryRepository2)
+}
+
 type ProductCategoryRepository interface {
 	Get(id string) ProductCategory
 }
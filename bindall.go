@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+)
+
+// BindAll to add singleton instance to global container as it's type and interfaces already known, see 'InterfaceBinder.BindAll'.
+//
+// It will panic if 'instance' is invalid.
+func BindAll[TImpl any](instance TImpl) {
+	BindAllToC[TImpl](globalContainer, instance)
+}
+
+// BindAllToC to add singleton instance to container as it's type and interfaces already known, see 'InterfaceBinder.BindAll'.
+//
+// It will panic if 'instance' is invalid.
+func BindAllToC[TImpl any](container Container, instance TImpl) {
+	if err := container.(InterfaceBinder).BindAll(instance); err != nil {
+		panic(err)
+	}
+}
+
+// InterfaceBinder is implemented by container to add instance as interfaces it implements in the ones already known.
+type InterfaceBinder interface {
+	// BindAll to add singleton instance as it's type, and also as each interface it implements in the ones already known
+	// to current container and it's parent chain, which are registered as services or depended on by singletons.
+	// Interfaces already registered in current container are skipped, and the others share the instance as 'AutoRegister'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.InterfaceBinder).BindAll(&FileStore{}) // as 'Reader' and 'Writer' depended on by other services
+	BindAll(instance any) error
+}
+
+var _ InterfaceBinder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) BindAll(instance any) error {
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	instanceType := reflect.TypeOf(instance)
+	var interfaceTypes []reflect.Type
+	for _, interfaceType := range c.contractInterfaces() {
+		if instanceType.Implements(interfaceType) && c.getBinding(interfaceType) == nil {
+			interfaceTypes = append(interfaceTypes, interfaceType)
+		}
+	}
+	return c.autoRegister(instance, interfaceTypes)
+}
+
+// contractInterfaces to get interfaces registered as services or depended on by singletons in current container and
+// it's parent chain, sorted by name.
+func (c *defaultContainer) contractInterfaces() []reflect.Type {
+	known := make(map[reflect.Type]struct{})
+	for _, interfaceType := range c.knownInterfaces() {
+		known[interfaceType] = struct{}{}
+	}
+	for current := c; current != nil; {
+		for _, binding := range current.singletonBindings() {
+			for _, depType := range binding.dependencies() {
+				if depType.Kind() == reflect.Interface && depType != resolverType {
+					known[depType] = struct{}{}
+				}
+			}
+		}
+		current, _ = current.Parent().(*defaultContainer)
+	}
+	interfaces := make([]reflect.Type, 0, len(known))
+	for interfaceType := range known {
+		interfaces = append(interfaces, interfaceType)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].String() < interfaces[j].String()
+	})
+	return interfaces
+}
@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+type bindAllConsumer struct {
+	Svc4 service4 `ioc-inject:"true"`
+}
+
+func TestBindAll(t *testing.T) {
+	t.Run("instance should be bound to all known interfaces it implements", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service1](parent, &serviceInstance1{name: "parent1"})
+		AddSingletonToC[service3](parent, &serviceInstance2{name: "parent3"})
+		globalContainer.SetParent(parent)
+		AddSingleton[*bindAllConsumer](&bindAllConsumer{})
+
+		svc := &serviceInstance2{name: "bound"}
+		BindAll(svc)
+		if GetService[service1]() != svc || GetService[service3]() != svc || GetService[service4]() != svc ||
+			GetService[*serviceInstance2]() != svc {
+			t.Error("instance should be bound to all known interfaces it implements")
+			return
+		}
+		if GetService[*bindAllConsumer]().Svc4 != svc {
+			t.Error("interface depended on by singleton should be bound")
+			return
+		}
+		if GetService[service2]() != nil || GetService[service6]() != nil {
+			t.Error("unknown interfaces should not be bound")
+			return
+		}
+	})
+
+	t.Run("interfaces already bound should be skipped", func(t *testing.T) {
+		globalContainer = New()
+		existing := &serviceInstance1{name: "existing"}
+		AddSingleton[service1](existing)
+		AddSingleton[service5](&serviceInstance1{name: "existing5"})
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateError)
+
+		svc := &serviceInstance2{name: "bound"}
+		if err := globalContainer.(InterfaceBinder).BindAll(svc); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]() != existing || GetService[service5]().GetName() != "existing5" {
+			t.Error("interfaces already bound should be skipped")
+			return
+		}
+		if err := globalContainer.(InterfaceBinder).BindAll(nil); err == nil {
+			t.Error("null instance should fail")
+			return
+		}
+	})
+}
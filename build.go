@@ -0,0 +1,76 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Build to create a new instance of 'T' which is *struct or struct, inject to it's fields and invoke it's initialize method
+// with services from container, without registering it. It's for one-off objects such as request handlers.
+// The instance is created by allocator added by 'AllocatorAdder.AddAllocator', or method 'New() *T', or zero value.
+//
+// It will panic if 'T' is not *struct or struct, such as interface which has nothing to allocate.
+//
+//	handler := ioc.Build[*OrderHandler](container)
+func Build[T any](c Container) T {
+	if c == nil {
+		panic("param 'c' is null")
+	}
+	instance, err := build(c, TypeOf[T]())
+	if err != nil {
+		panic(err)
+	}
+	return instance.Interface().(T)
+}
+
+// build to create a new instance of 'targetType' which is *struct or struct, and inject to it with services from container.
+func build(c Container, targetType reflect.Type) (reflect.Value, error) {
+	var ptrType reflect.Type
+	switch {
+	case targetType.Kind() == reflect.Pointer && targetType.Elem().Kind() == reflect.Struct:
+		ptrType = targetType
+	case targetType.Kind() == reflect.Struct:
+		ptrType = reflect.PtrTo(targetType)
+	default:
+		return reflect.Value{}, fmt.Errorf("type '%v' should be *struct or struct", targetType)
+	}
+	ptr, err := allocate(c, ptrType)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	// detect initialize method as singleton
+	binding, err := newSingletonBinding(ptr.Type(), ptr.Interface())
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	InjectFromC(c, ptr)
+	if binding.InstanceInitializer.IsValid() {
+		InjectFromC(c, binding.InstanceInitializer)
+	}
+	if targetType.Kind() == reflect.Struct {
+		return ptr.Elem(), nil
+	}
+	return ptr, nil
+}
@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestBuild(t *testing.T) {
+	t.Run("build *struct should success", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		instance := Build[*buildTarget](globalContainer)
+		if instance == nil || instance.S1 == nil || instance.S7Name != "instance7" {
+			t.Error("fields should be injected and initialize method should be invoked")
+			return
+		}
+		if Build[*buildTarget](globalContainer) == instance {
+			t.Error("new instance should be created for each building")
+			return
+		}
+		if GetService[*buildTarget]() != nil {
+			t.Error("built instance should not be registered")
+			return
+		}
+	})
+
+	t.Run("build struct should success", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		instance := Build[buildTarget](globalContainer)
+		if instance.S1 == nil || instance.S7Name != "instance7" {
+			t.Error("fields should be injected and initialize method should be invoked")
+			return
+		}
+	})
+
+	t.Run("build interface should fail", func(t *testing.T) {
+		globalContainer = New()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("build interface should panic")
+			}
+		}()
+		Build[service1](globalContainer)
+	})
+}
+
+type buildTarget struct {
+	S1     service1 `ioc-inject:"true"`
+	S7Name string
+}
+
+func (t *buildTarget) Initialize(s7 *serviceInstance7) {
+	t.S7Name = s7.name
+}
@@ -0,0 +1,142 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// GetServiceChecked to get service from global container, and verify that the instance can serve every method of 'TService'.
+// It's meant for debugging dynamic or proxy-heavy setups, not for hot paths, as it walks method set by reflection for each call.
+//
+//	svc, err := ioc.GetServiceChecked[Service1]()
+//	// err: method 'GetName' of service 'Service1' is promoted from nil embedded field 'Service1' of '*Proxy'
+func GetServiceChecked[TService any]() (TService, error) {
+	return GetServiceCheckedFromC[TService](globalContainer)
+}
+
+// GetServiceCheckedFromC to get service from container, and verify that the instance can serve every method of 'TService'.
+// It returns error if service not found, instance is typed nil, or any method is missing or promoted from nil embedded field.
+func GetServiceCheckedFromC[TService any](container Container) (TService, error) {
+	var instance TService
+	serviceType := TypeOf[TService]()
+	instanceVal, err := container.(ErrorResolver).ResolveE(serviceType)
+	if err != nil {
+		return instance, err
+	}
+	if !instanceVal.IsValid() {
+		return instance, fmt.Errorf("service '%v' not found", serviceType)
+	}
+	if err = checkMethodSet(serviceType, instanceVal); err != nil {
+		return instance, err
+	}
+	instance, _ = instanceVal.Interface().(TService)
+	return instance, nil
+}
+
+// GetServiceNonNil to get service from global container, and fail instead of returning a non-nil interface wrapping nil.
+//
+//	svc, err := ioc.GetServiceNonNil[Service1]()
+//	// err: service 'Service1' resolved to typed-nil of type '*ServiceImplementation1'
+func GetServiceNonNil[TService any]() (TService, error) {
+	return GetServiceNonNilFromC[TService](globalContainer)
+}
+
+// GetServiceNonNilFromC to get service from container, and fail instead of returning a non-nil interface wrapping nil,
+// such as the typed nil returned by factory. It returns error if service not found or resolved to nil.
+func GetServiceNonNilFromC[TService any](container Container) (TService, error) {
+	var instance TService
+	serviceType := TypeOf[TService]()
+	instanceVal, err := container.(ErrorResolver).ResolveE(serviceType)
+	if err != nil {
+		return instance, err
+	}
+	if !instanceVal.IsValid() {
+		return instance, fmt.Errorf("service '%v' not found", serviceType)
+	}
+	for instanceVal.Kind() == reflect.Interface && !instanceVal.IsNil() {
+		instanceVal = instanceVal.Elem()
+	}
+	switch instanceVal.Kind() {
+	case reflect.Interface:
+		return instance, fmt.Errorf("service '%v' resolved to nil", serviceType)
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if instanceVal.IsNil() {
+			return instance, fmt.Errorf("service '%v' resolved to typed-nil of type '%v'", serviceType, instanceVal.Type())
+		}
+	}
+	instance, _ = instanceVal.Interface().(TService)
+	return instance, nil
+}
+
+// checkMethodSet to check whether methods of service can be called on instance without nil dereference.
+func checkMethodSet(serviceType reflect.Type, instance reflect.Value) error {
+	for instance.Kind() == reflect.Interface {
+		if instance.IsNil() {
+			return fmt.Errorf("instance of service '%v' is nil", serviceType)
+		}
+		instance = instance.Elem()
+	}
+	switch instance.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		if instance.IsNil() {
+			return fmt.Errorf("instance of service '%v' is a nil '%v'", serviceType, instance.Type())
+		}
+	}
+	if serviceType.Kind() != reflect.Interface {
+		return nil
+	}
+	for i := 0; i < serviceType.NumMethod(); i++ {
+		methodName := serviceType.Method(i).Name
+		if !instance.MethodByName(methodName).IsValid() {
+			return fmt.Errorf("method '%s' of service '%v' is missing in '%v'", methodName, serviceType, instance.Type())
+		}
+		if fieldName := nilEmbeddedOf(instance, methodName); fieldName != "" {
+			return fmt.Errorf("method '%s' of service '%v' is promoted from nil embedded field '%s' of '%v'",
+				methodName, serviceType, fieldName, instance.Type())
+		}
+	}
+	return nil
+}
+
+// nilEmbeddedOf to get name of nil embedded interface or pointer field which has the method, returns empty if not found.
+func nilEmbeddedOf(instance reflect.Value, methodName string) string {
+	structVal := instance
+	if structVal.Kind() == reflect.Pointer {
+		structVal = structVal.Elem()
+	}
+	if structVal.Kind() != reflect.Struct {
+		return ""
+	}
+	structType := structVal.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.Anonymous || field.Type.Kind() != reflect.Interface && field.Type.Kind() != reflect.Pointer {
+			continue
+		}
+		if _, ok := field.Type.MethodByName(methodName); ok && structVal.Field(i).IsNil() {
+			return field.Name
+		}
+	}
+	return ""
+}
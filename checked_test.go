@@ -0,0 +1,113 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+// brokenProxy implements service1 by embedding, but the embedded one is nil.
+type brokenProxy struct {
+	service1
+}
+
+func TestGetServiceChecked(t *testing.T) {
+	t.Run("valid implementation should pass", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		svc, err := GetServiceChecked[service2]()
+		if err != nil || svc == nil || svc.GetName() != "instance2" {
+			t.Errorf("valid implementation should be returned, but %v", err)
+			return
+		}
+		AddSingleton[service1](&brokenProxy{service1: &serviceInstance1{name: "inner"}})
+		if proxy, err := GetServiceChecked[service1](); err != nil || proxy.GetName() != "inner" {
+			t.Errorf("proxy with embedded instance should pass, but %v", err)
+			return
+		}
+	})
+
+	t.Run("broken implementation should fail", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&brokenProxy{})
+		_, err := GetServiceChecked[service1]()
+		if err == nil || !strings.Contains(err.Error(), "method 'GetName'") || !strings.Contains(err.Error(), "nil embedded field 'service1'") {
+			t.Errorf("method promoted from nil embedded field should fail, but %v", err)
+			return
+		}
+
+		var nilInstance *serviceInstance2
+		AddTransient[service2](func() service2 { return nilInstance })
+		_, err = GetServiceChecked[service2]()
+		if err == nil || !strings.Contains(err.Error(), "nil '*ioc.serviceInstance2'") {
+			t.Errorf("typed nil should fail, but %v", err)
+			return
+		}
+
+		if _, err = GetServiceChecked[*serviceInstance1](); err == nil {
+			t.Error("service not found should fail")
+			return
+		}
+	})
+}
+
+func TestGetServiceNonNil(t *testing.T) {
+	t.Run("typed nil returned by factory should fail", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 {
+			var svc *serviceInstance1
+			return svc
+		})
+		if svc := GetService[service1](); svc == nil {
+			t.Error("typed nil should be a non-nil interface by 'GetService'")
+			return
+		}
+		_, err := GetServiceNonNil[service1]()
+		if err == nil || err.Error() != "service 'ioc.service1' resolved to typed-nil of type '*ioc.serviceInstance1'" {
+			t.Errorf("typed nil returned by factory should fail, but got '%v'", err)
+			return
+		}
+	})
+
+	t.Run("non-nil instance should be got", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { return &serviceInstance1{name: "instance1"} })
+		if svc, err := GetServiceNonNil[service1](); err != nil || svc.GetName() != "instance1" {
+			t.Error("non-nil instance should be got")
+			return
+		}
+	})
+
+	t.Run("nil or not found should fail", func(t *testing.T) {
+		globalContainer = New()
+		if _, err := GetServiceNonNil[service1](); err == nil {
+			t.Error("service not found should fail")
+			return
+		}
+		AddTransient[service1](func() service1 { return nil })
+		if _, err := GetServiceNonNil[service1](); err == nil {
+			t.Error("nil should fail")
+			return
+		}
+	})
+}
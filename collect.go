@@ -0,0 +1,79 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// CollectOption is option of resolving collection, such as 'AllResolver.ResolveAll' and 'Grouper.ResolveGroup'.
+type CollectOption func(opts *collectOptions)
+
+// collectOptions is the options of resolving collection.
+type collectOptions struct {
+	Distinct bool
+}
+
+// Distinct to include instance once by pointer identity, such as one implementing multiple interfaces collected,
+// so it's not invoked twice in pipeline of middlewares or handlers. The first one in order is kept.
+//
+//	handlers := ioc.ResolveGroup[Handler]("handlers", ioc.Distinct())
+func Distinct() CollectOption {
+	return func(opts *collectOptions) {
+		opts.Distinct = true
+	}
+}
+
+// collectOptionsOf to apply options in order, nil ones are skipped.
+func collectOptionsOf(opts []CollectOption) collectOptions {
+	var options collectOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+	return options
+}
+
+// apply to filter instances collected by options.
+func (opts collectOptions) apply(instances []reflect.Value) []reflect.Value {
+	if !opts.Distinct || len(instances) < 2 {
+		return instances
+	}
+	seen := make(map[any]bool, len(instances))
+	distinct := make([]reflect.Value, 0, len(instances))
+	for _, instance := range instances {
+		concrete := instance
+		for concrete.Kind() == reflect.Interface && !concrete.IsNil() {
+			concrete = concrete.Elem()
+		}
+		if concrete.Kind() == reflect.Pointer && !concrete.IsNil() {
+			identity := concrete.Interface()
+			if seen[identity] {
+				continue
+			}
+			seen[identity] = true
+		}
+		distinct = append(distinct, instance)
+	}
+	return distinct
+}
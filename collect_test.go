@@ -0,0 +1,67 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestDistinct(t *testing.T) {
+	t.Run("instance in group by multiple types should be included once", func(t *testing.T) {
+		globalContainer = New()
+		shared := &serviceInstance2{name: "shared"}
+		AddToGroup[service1]("handlers", shared)
+		AddToGroup[service2]("handlers", shared)
+		AddToGroup[service1]("handlers", &serviceInstance1{name: "other"})
+		if handlers := ResolveGroup[service1]("handlers"); len(handlers) != 3 {
+			t.Errorf("instance should be included for each type without distinct, but %d", len(handlers))
+			return
+		}
+		handlers := ResolveGroup[service1]("handlers", Distinct())
+		if len(handlers) != 2 || handlers[0] != service1(shared) || handlers[1].GetName() != "other" {
+			t.Errorf("instance should be included once with distinct, but %v", handlers)
+			return
+		}
+	})
+
+	t.Run("instance resolved by multiple registrations should be included once", func(t *testing.T) {
+		globalContainer = New()
+		shared := &serviceInstance2{name: "shared"}
+		AddSingleton[service1](shared)
+		parent := globalContainer
+		globalContainer = New()
+		globalContainer.SetParent(parent)
+		AddTransient[service2](func() service2 { return shared })
+		if all := ResolveAll[service1](); len(all) != 2 {
+			t.Errorf("instance should be included for each registration without distinct, but %d", len(all))
+			return
+		}
+		if all := ResolveAll[service1](Distinct()); len(all) != 1 || all[0] != service1(shared) {
+			t.Errorf("instance should be included once with distinct, but %v", all)
+			return
+		}
+		if all := globalContainer.(AllResolver).ResolveAll(TypeOf[service1](), nil, Distinct()); len(all) != 1 {
+			t.Error("nil option should be skipped")
+			return
+		}
+	})
+}
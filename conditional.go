@@ -0,0 +1,132 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddSingletonIf to add singleton instance to global container only if 'cond' returns true.
+//
+// It will panic if 'cond', 'TService' or 'instance' is invalid.
+func AddSingletonIf[TService any](cond func() bool, instance TService) {
+	AddSingletonIfToC[TService](globalContainer, cond, instance)
+}
+
+// AddSingletonIfToC to add singleton instance to container only if 'cond' returns true.
+//
+// It will panic if 'cond', 'TService' or 'instance' is invalid.
+func AddSingletonIfToC[TService any](container Container, cond func() bool, instance TService) {
+	if cond == nil {
+		panic("param 'cond' is null")
+	}
+	if !cond() {
+		return
+	}
+	AddSingletonToC[TService](container, instance)
+}
+
+// AddTransientIf to add transient service instance factory to global container only if 'cond' returns true.
+//
+// It will panic if 'cond', 'TService' or 'instanceFactory' is invalid.
+func AddTransientIf[TService any](cond func() bool, instanceFactory func() TService) {
+	AddTransientIfToC[TService](globalContainer, cond, instanceFactory)
+}
+
+// AddTransientIfToC to add transient service instance factory to container only if 'cond' returns true.
+//
+// It will panic if 'cond', 'TService' or 'instanceFactory' is invalid.
+func AddTransientIfToC[TService any](container Container, cond func() bool, instanceFactory func() TService) {
+	if cond == nil {
+		panic("param 'cond' is null")
+	}
+	if !cond() {
+		return
+	}
+	AddTransientToC[TService](container, instanceFactory)
+}
+
+// ConditionalAdder is implemented by container to add services and inject fields by conditions.
+type ConditionalAdder interface {
+	// AddSingletonIf to add singleton instance only if 'cond' returns true, which is evaluated once when adding.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ConditionalAdder).AddSingletonIf(isDev, reflect.TypeOf((*Cache)(nil)).Elem(), &MemoryCache{})
+	//  err = container.(ioc.ConditionalAdder).AddSingletonIf(isProd, reflect.TypeOf((*Cache)(nil)).Elem(), &RedisCache{})
+	AddSingletonIf(cond func() bool, serviceType reflect.Type, instance any) error
+
+	// AddTransientIf to add transient service instance factory only if 'cond' returns true, which is evaluated once when adding.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ConditionalAdder).AddTransientIf(isDev, reflect.TypeOf((*Cache)(nil)).Elem(), func() any {
+	//      return &MemoryCache{}
+	//  })
+	AddTransientIf(cond func() bool, serviceType reflect.Type, instanceFactory func() any) error
+
+	// SetCondition to set condition by name for field tagged with 'ioc-inject:"when=name"', which is injected only if
+	// the condition returns true when injecting, otherwise it's left as is. Field with condition not set in current container
+	// or it's parent chain is not injected. Null 'cond' removes the condition.
+	//
+	//  container.(ioc.ConditionalAdder).SetCondition("prod", func() bool { return os.Getenv("ENV") == "prod" })
+	SetCondition(name string, cond func() bool)
+}
+
+var _ ConditionalAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSingletonIf(cond func() bool, serviceType reflect.Type, instance any) error {
+	if cond == nil {
+		return errors.New("param 'cond' is null")
+	}
+	if !cond() {
+		return nil
+	}
+	return c.AddSingleton(serviceType, instance)
+}
+
+func (c *defaultContainer) AddTransientIf(cond func() bool, serviceType reflect.Type, instanceFactory func() any) error {
+	if cond == nil {
+		return errors.New("param 'cond' is null")
+	}
+	if !cond() {
+		return nil
+	}
+	return c.AddTransient(serviceType, instanceFactory)
+}
+
+func (c *defaultContainer) SetCondition(name string, cond func() bool) {
+	if cond == nil {
+		c.conditions.Delete(name)
+		return
+	}
+	c.conditions.Store(name, cond)
+}
+
+// conditionHolds to evaluate condition by name from container and it's parent chain, false if it's not set.
+func conditionHolds(container Container, name string) bool {
+	for c, ok := container.(*defaultContainer); ok; c, ok = c.Parent().(*defaultContainer) {
+		if cond, found := c.conditions.Load(name); found {
+			return cond.(func() bool)()
+		}
+	}
+	return false
+}
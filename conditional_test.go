@@ -0,0 +1,147 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddSingletonIf(t *testing.T) {
+	isDev := func() bool { return true }
+	isProd := func() bool { return false }
+
+	t.Run("add singleton if condition is true", func(t *testing.T) {
+		globalContainer = New()
+		AddSingletonIf[service1](isProd, &serviceInstance1{name: "prod"})
+		AddSingletonIf[service1](isDev, &serviceInstance1{name: "dev"})
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "dev" {
+			t.Error("only singleton with true condition should be added")
+			return
+		}
+	})
+
+	t.Run("add transient if condition is true", func(t *testing.T) {
+		globalContainer = New()
+		AddTransientIf[service1](isProd, func() service1 { return &serviceInstance1{name: "prod"} })
+		if GetService[service1]() != nil {
+			t.Error("transient with false condition should not be added")
+			return
+		}
+		AddTransientIf[service1](isDev, func() service1 { return &serviceInstance1{name: "dev"} })
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "dev" {
+			t.Error("transient with true condition should be added")
+			return
+		}
+	})
+
+	t.Run("false condition should not conflict with duplicate policy", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateError)
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(ConditionalAdder).AddSingletonIf(isDev, serviceType, &serviceInstance1{name: "dev"}); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := globalContainer.(ConditionalAdder).AddSingletonIf(isProd, serviceType, &serviceInstance1{name: "prod"}); err != nil {
+			t.Error("singleton with false condition should not be checked for duplicate")
+			return
+		}
+		if err := globalContainer.(ConditionalAdder).AddTransientIf(isDev, serviceType, func() any { return &serviceInstance1{} }); err == nil {
+			t.Error("duplicate with true condition should fail")
+			return
+		}
+	})
+
+	t.Run("nil condition should fail", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(ConditionalAdder).AddSingletonIf(nil, serviceType, &serviceInstance1{}); err == nil {
+			t.Error("nil condition should fail")
+			return
+		}
+		if err := globalContainer.(ConditionalAdder).AddTransientIf(nil, serviceType, func() any { return &serviceInstance1{} }); err == nil {
+			t.Error("nil condition should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("nil condition should panic")
+			}
+		}()
+		AddSingletonIf[service1](nil, &serviceInstance1{})
+	})
+}
+
+type conditionalClient struct {
+	Svc1  service1 `ioc-inject:"when=prod"`
+	Svc2  service2 `ioc-inject:"true"`
+	Other service1 `ioc-inject:"when=unknown"`
+}
+
+func TestSetCondition(t *testing.T) {
+	t.Run("field should be injected only if condition is true", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		prod := false
+		globalContainer.(ConditionalAdder).SetCondition("prod", func() bool { return prod })
+
+		client := &conditionalClient{}
+		Inject(client)
+		if client.Svc1 != nil || client.Svc2 == nil {
+			t.Error("field should not be injected if condition is false")
+			return
+		}
+		prod = true
+		Inject(client)
+		if client.Svc1 == nil {
+			t.Error("field should be injected if condition is true")
+			return
+		}
+		if client.Other != nil {
+			t.Error("field with unknown condition should not be injected")
+			return
+		}
+	})
+
+	t.Run("condition should be inherited and removable", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		parent.(ConditionalAdder).SetCondition("prod", func() bool { return true })
+		globalContainer.SetParent(parent)
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		client := &conditionalClient{}
+		Inject(client)
+		if client.Svc1 == nil {
+			t.Error("condition of parent should be used")
+			return
+		}
+		parent.(ConditionalAdder).SetCondition("prod", nil)
+		client = &conditionalClient{}
+		Inject(client)
+		if client.Svc1 != nil {
+			t.Error("removed condition should not inject")
+			return
+		}
+	})
+}
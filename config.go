@@ -0,0 +1,309 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType reflect.Type = reflect.TypeOf(time.Duration(0))
+
+// BindConfig to allocate '*T', fill it from 'source', and add it to container as singleton service '*T'.
+//
+// The key of field in 'source' is the value of struct tag 'ioc-bind', or the field name if not tagged,
+// which matches case-insensitively if no key equals to it, and fails if several keys match.
+// Field with tag 'ioc-bind:"-"' will be skipped.
+// Nested struct or *struct field is filled from a 'map[string]any' value, slice field from a '[]any' value,
+// and basic types are coerced, such as string "8080" to int, float64 8080 to int, string "5s" or number of nanoseconds to time.Duration.
+//
+//	type DBConfig struct {
+//	    Host string `ioc-bind:"host"`
+//	    Port int    `ioc-bind:"port"`
+//	}
+//	type Config struct {
+//	    DB      DBConfig      `ioc-bind:"db"`
+//	    Timeout time.Duration `ioc-bind:"timeout"`
+//	}
+//
+//	err := ioc.BindConfig[Config](container, map[string]any{
+//	    "db":      map[string]any{"host": "localhost", "port": "3306"},
+//	    "timeout": "5s",
+//	})
+//	// inject to field with type '*Config'
+//	type Service struct {
+//	    Config *Config `ioc-inject:"true"`
+//	}
+func BindConfig[T any](c Container, source map[string]any) error {
+	if c == nil {
+		return errors.New("param 'c' is null")
+	}
+	configType := TypeOf[T]()
+	if configType.Kind() != reflect.Struct {
+		return fmt.Errorf("type of config '%v' should be a struct", configType)
+	}
+	config := reflect.New(configType)
+	if err := bindStruct(config.Elem(), source, configType.Name()); err != nil {
+		return err
+	}
+	return c.AddSingleton(config.Type(), config.Interface())
+}
+
+func bindStruct(structVal reflect.Value, source map[string]any, path string) error {
+	structType := structVal.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, ok := field.Tag.Lookup("ioc-bind")
+		if key == "-" {
+			continue
+		}
+		var val any
+		var found bool
+		if ok && key != "" {
+			val, found = source[key]
+		} else {
+			key = field.Name
+			var err error
+			if val, found, err = lookupIgnoreCase(source, key, path); err != nil {
+				return err
+			}
+		}
+		if !found {
+			continue
+		}
+		if err := bindValue(structVal.Field(i), val, path+"."+key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lookupIgnoreCase to find value by key, or by the only key equals to it case-insensitively.
+func lookupIgnoreCase(source map[string]any, key string, path string) (any, bool, error) {
+	if val, ok := source[key]; ok {
+		return val, true, nil
+	}
+	var matched []string
+	for k := range source {
+		if strings.EqualFold(k, key) {
+			matched = append(matched, k)
+		}
+	}
+	switch len(matched) {
+	case 0:
+		return nil, false, nil
+	case 1:
+		return source[matched[0]], true, nil
+	default:
+		sort.Strings(matched)
+		return nil, false, fmt.Errorf("bind config '%s.%s' fail: ambiguous keys '%s'", path, key, strings.Join(matched, "', '"))
+	}
+}
+
+func bindValue(target reflect.Value, val any, path string) error {
+	if val == nil {
+		return nil
+	}
+	source := reflect.ValueOf(val)
+	if source.Type().AssignableTo(target.Type()) {
+		target.Set(source)
+		return nil
+	}
+
+	targetType := target.Type()
+	switch {
+	case targetType == durationType:
+		switch v := val.(type) {
+		case string:
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("bind config '%s' fail: %w", path, err)
+			}
+			target.SetInt(int64(d))
+			return nil
+		}
+		if isNumberKind(source.Kind()) {
+			// number of nanoseconds
+			return bindNumber(target, source, path)
+		}
+	case targetType.Kind() == reflect.Pointer:
+		elem := reflect.New(targetType.Elem())
+		if err := bindValue(elem.Elem(), val, path); err != nil {
+			return err
+		}
+		target.Set(elem)
+		return nil
+	case targetType.Kind() == reflect.Struct:
+		if m, ok := val.(map[string]any); ok {
+			return bindStruct(target, m, path)
+		}
+	case targetType.Kind() == reflect.Slice:
+		if source.Kind() == reflect.Slice || source.Kind() == reflect.Array {
+			slice := reflect.MakeSlice(targetType, source.Len(), source.Len())
+			for i := 0; i < source.Len(); i++ {
+				if err := bindValue(slice.Index(i), source.Index(i).Interface(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+			target.Set(slice)
+			return nil
+		}
+	case targetType.Kind() == reflect.Map:
+		if source.Kind() == reflect.Map {
+			m := reflect.MakeMapWithSize(targetType, source.Len())
+			iter := source.MapRange()
+			for iter.Next() {
+				k := reflect.New(targetType.Key()).Elem()
+				if err := bindValue(k, iter.Key().Interface(), path); err != nil {
+					return err
+				}
+				v := reflect.New(targetType.Elem()).Elem()
+				if err := bindValue(v, iter.Value().Interface(), fmt.Sprintf("%s[%v]", path, iter.Key())); err != nil {
+					return err
+				}
+				m.SetMapIndex(k, v)
+			}
+			target.Set(m)
+			return nil
+		}
+	default:
+		if str, ok := val.(string); ok {
+			return bindString(target, str, path)
+		}
+		if isNumberKind(source.Kind()) && isNumberKind(targetType.Kind()) {
+			return bindNumber(target, source, path)
+		}
+		if source.CanConvert(targetType) && isBasicKind(source.Kind()) && isBasicKind(targetType.Kind()) &&
+			(source.Kind() == reflect.String) == (targetType.Kind() == reflect.String) {
+			target.Set(source.Convert(targetType))
+			return nil
+		}
+	}
+	return fmt.Errorf("bind config '%s' fail: can't convert '%v' to '%v'", path, source.Type(), targetType)
+}
+
+// bindNumber to convert number to another kind of number, and fail if it overflows or has fraction instead of truncating.
+func bindNumber(target reflect.Value, source reflect.Value, path string) error {
+	overflow := false
+	switch target.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			v = source.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			overflow = source.Uint() > math.MaxInt64
+			v = int64(source.Uint())
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("bind config '%s' fail: value '%v' is not an integer of '%v'", path, f, target.Type())
+			}
+			// float64(math.MaxInt64) is rounded up to 2^63, which is out of range
+			overflow = f < math.MinInt64 || f >= math.MaxInt64
+			v = int64(f)
+		}
+		if overflow = overflow || target.OverflowInt(v); !overflow {
+			target.SetInt(v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var v uint64
+		switch source.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			overflow = source.Int() < 0
+			v = uint64(source.Int())
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			v = source.Uint()
+		default:
+			f := source.Float()
+			if f != math.Trunc(f) {
+				return fmt.Errorf("bind config '%s' fail: value '%v' is not an integer of '%v'", path, f, target.Type())
+			}
+			overflow = f < 0 || f >= math.MaxUint64
+			v = uint64(f)
+		}
+		if overflow = overflow || target.OverflowUint(v); !overflow {
+			target.SetUint(v)
+		}
+	default:
+		v := source.Convert(reflect.TypeOf(float64(0))).Float()
+		if overflow = target.OverflowFloat(v); !overflow {
+			target.SetFloat(v)
+		}
+	}
+	if overflow {
+		return fmt.Errorf("bind config '%s' fail: value '%v' overflows '%v'", path, source.Interface(), target.Type())
+	}
+	return nil
+}
+
+func isNumberKind(kind reflect.Kind) bool {
+	return kind >= reflect.Int && kind <= reflect.Float64
+}
+
+func bindString(target reflect.Value, str string, path string) error {
+	var err error
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(str)
+	case reflect.Bool:
+		var v bool
+		if v, err = strconv.ParseBool(str); err == nil {
+			target.SetBool(v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if v, err = strconv.ParseInt(str, 10, target.Type().Bits()); err == nil {
+			target.SetInt(v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if v, err = strconv.ParseUint(str, 10, target.Type().Bits()); err == nil {
+			target.SetUint(v)
+		}
+	case reflect.Float32, reflect.Float64:
+		var v float64
+		if v, err = strconv.ParseFloat(str, target.Type().Bits()); err == nil {
+			target.SetFloat(v)
+		}
+	default:
+		return fmt.Errorf("bind config '%s' fail: can't convert 'string' to '%v'", path, target.Type())
+	}
+	if err != nil {
+		return fmt.Errorf("bind config '%s' fail: %w", path, err)
+	}
+	return nil
+}
+
+func isBasicKind(kind reflect.Kind) bool {
+	return kind == reflect.Bool || kind == reflect.String ||
+		(kind >= reflect.Int && kind <= reflect.Float64)
+}
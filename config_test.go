@@ -0,0 +1,196 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBindConfig(t *testing.T) {
+	t.Run("bind config and inject should success", func(t *testing.T) {
+		globalContainer = New()
+		err := BindConfig[appConfig](globalContainer, map[string]any{
+			"name":    "app",
+			"debug":   "true",
+			"timeout": "5s",
+			"db":      map[string]any{"host": "localhost", "port": "3306"},
+			"cache":   map[string]any{"Size": float64(128)},
+			"tags":    []any{"a", "b"},
+			"limits":  map[string]any{"read": 10, "write": "20"},
+			"Ignored": "ignored",
+		})
+		if err != nil {
+			t.Errorf("bind config fail: %v", err)
+			return
+		}
+		var c configClient
+		Inject(&c)
+		config := c.Config
+		if config == nil || config != GetService[*appConfig]() {
+			t.Error("config should be injected as singleton")
+			return
+		}
+		if config.Name != "app" || !config.Debug || config.Timeout != 5*time.Second {
+			t.Error("basic fields should be bound")
+			return
+		}
+		if config.DB.Host != "localhost" || config.DB.Port != 3306 {
+			t.Error("nested struct should be bound")
+			return
+		}
+		if config.Cache == nil || config.Cache.Size != 128 {
+			t.Error("nested *struct should be bound")
+			return
+		}
+		if len(config.Tags) != 2 || config.Tags[1] != "b" {
+			t.Error("slice should be bound")
+			return
+		}
+		if config.Limits["read"] != 10 || config.Limits["write"] != 20 {
+			t.Error("map should be bound")
+			return
+		}
+		if config.Ignored != "" {
+			t.Error("field with tag 'ioc-bind:\"-\"' should be skipped")
+			return
+		}
+	})
+
+	t.Run("invalid value should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := BindConfig[appConfig](globalContainer, map[string]any{"db": map[string]any{"port": "abc"}}); err == nil {
+			t.Error("bind 'abc' to int should fail")
+			return
+		}
+		if err := BindConfig[appConfig](globalContainer, map[string]any{"debug": 1}); err == nil {
+			t.Error("bind int to bool should fail")
+			return
+		}
+		if GetService[*appConfig]() != nil {
+			t.Error("config should not be registered if bind fail")
+			return
+		}
+	})
+
+	t.Run("number out of range should fail instead of truncating", func(t *testing.T) {
+		globalContainer = New()
+		type limitConfig struct {
+			Small int8
+			Count uint
+			Ratio float32
+		}
+		for _, source := range []map[string]any{
+			{"small": 300},
+			{"count": -1},
+			{"count": 1e30},
+			{"ratio": 1e300},
+		} {
+			if err := BindConfig[limitConfig](globalContainer, source); err == nil || !strings.Contains(err.Error(), "overflows") {
+				t.Errorf("bind %v should fail for overflow, but '%v'", source, err)
+				return
+			}
+		}
+		if err := BindConfig[limitConfig](globalContainer, map[string]any{"small": 1.5}); err == nil {
+			t.Error("bind 1.5 to int should fail")
+			return
+		}
+		if err := BindConfig[limitConfig](globalContainer, map[string]any{"small": 127.0, "count": int64(8), "ratio": 0.5}); err != nil {
+			t.Error(err)
+			return
+		}
+		if config := GetService[*limitConfig](); config.Small != 127 || config.Count != 8 || config.Ratio != 0.5 {
+			t.Error("number in range should be converted")
+			return
+		}
+	})
+
+	t.Run("exact key should be preferred and ambiguous keys should fail", func(t *testing.T) {
+		globalContainer = New()
+		type portConfig struct {
+			Port int
+		}
+		if err := BindConfig[portConfig](globalContainer, map[string]any{"Port": 1, "port": 2, "PORT": 3}); err != nil {
+			t.Error(err)
+			return
+		}
+		if config := GetService[*portConfig](); config.Port != 1 {
+			t.Errorf("key equals to field name should be preferred, but got %d", config.Port)
+			return
+		}
+		globalContainer = New()
+		if err := BindConfig[portConfig](globalContainer, map[string]any{"port": 2, "PORT": 3}); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+			t.Errorf("keys matched case-insensitively should be ambiguous, but '%v'", err)
+			return
+		}
+	})
+
+	t.Run("number of nanoseconds should be bound to duration", func(t *testing.T) {
+		for _, timeout := range []any{int(time.Second), int64(time.Second), uint32(time.Second), float64(time.Second)} {
+			globalContainer = New()
+			if err := BindConfig[appConfig](globalContainer, map[string]any{"timeout": timeout}); err != nil {
+				t.Error(err)
+				return
+			}
+			if config := GetService[*appConfig](); config.Timeout != time.Second {
+				t.Errorf("bind %T to duration fail, got %v", timeout, config.Timeout)
+				return
+			}
+		}
+		globalContainer = New()
+		if err := BindConfig[appConfig](globalContainer, map[string]any{"timeout": 1.5}); err == nil {
+			t.Error("bind fraction of nanoseconds to duration should fail")
+			return
+		}
+	})
+
+	t.Run("non-struct config should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := BindConfig[string](globalContainer, nil); err == nil {
+			t.Error("config should be a struct")
+			return
+		}
+	})
+}
+
+type appConfig struct {
+	Name    string        `ioc-bind:"name"`
+	Debug   bool          `ioc-bind:"debug"`
+	Timeout time.Duration `ioc-bind:"timeout"`
+	DB      struct {
+		Host string `ioc-bind:"host"`
+		Port int    `ioc-bind:"port"`
+	} `ioc-bind:"db"`
+	Cache   *cacheConfig   `ioc-bind:"cache"`
+	Tags    []string       `ioc-bind:"tags"`
+	Limits  map[string]int `ioc-bind:"limits"`
+	Ignored string         `ioc-bind:"-"`
+}
+
+type cacheConfig struct {
+	Size int
+}
+
+type configClient struct {
+	Config *appConfig `ioc-inject:"true"`
+}
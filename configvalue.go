@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// GetConfig to get config value by key from global container, see 'ioc.GetConfigFromC'.
+func GetConfig[T any](key string) (T, bool) {
+	return GetConfigFromC[T](globalContainer, key)
+}
+
+// GetConfigFromC to get config value by key from container, and returns false if not found or can't be converted to 'T'.
+//
+// Value is returned as it is if it's assignable to 'T', otherwise it's converted with the same rule as 'ioc.BindConfig',
+// such as string "8080" to int, float64 8080 to int, string "5s" to time.Duration.
+func GetConfigFromC[T any](container Container, key string) (T, bool) {
+	var value T
+	val := configValueOf(container, key, TypeOf[T]())
+	if !val.IsValid() {
+		return value, false
+	}
+	value, ok := val.Interface().(T)
+	return value, ok
+}
+
+// ConfigValueStore is implemented by container to store config values by key.
+type ConfigValueStore interface {
+	// AddConfigValue to add config value by key, such as feature flags and timeouts, which don't need their own service type.
+	// Config values are stored apart from services, and can be got by 'ioc.GetConfig' or injected to field tagged with 'ioc-config'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ConfigValueStore).AddConfigValue("timeout", "5s")
+	//  // inject to field
+	//  type Client struct {
+	//      Timeout time.Duration `ioc-config:"timeout"`
+	//  }
+	AddConfigValue(key string, value any) error
+
+	// ConfigValue to get config value by key as it's added. It will get from parent if not found in current.
+	ConfigValue(key string) (any, bool)
+}
+
+var _ ConfigValueStore = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddConfigValue(key string, value any) error {
+	if key == "" {
+		return errors.New("param 'key' is empty")
+	}
+	if value == nil {
+		return errors.New("param 'value' is null")
+	}
+	c.configValues.Store(key, value)
+	return nil
+}
+
+func (c *defaultContainer) ConfigValue(key string) (any, bool) {
+	if value, ok := c.configValues.Load(key); ok {
+		return value, true
+	}
+	if parent, ok := c.parent.(ConfigValueStore); ok {
+		return parent.ConfigValue(key)
+	}
+	return nil, false
+}
+
+// configValueOf to get config value by key converted to 'valueType', returns invalid value if not found or can't be converted.
+func configValueOf(container Container, key string, valueType reflect.Type) reflect.Value {
+	value, ok := container.(ConfigValueStore).ConfigValue(key)
+	if !ok {
+		return reflect.Value{}
+	}
+	target := reflect.New(valueType).Elem()
+	if err := bindValue(target, value, key); err != nil {
+		return reflect.Value{}
+	}
+	return target
+}
@@ -0,0 +1,124 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+	"time"
+)
+
+type configValueClient struct {
+	Timeout time.Duration `ioc-config:"timeout"`
+	Port    int           `ioc-config:"port"`
+	Debug   bool          `ioc-config:"debug"`
+	Name    string        `ioc-config:"missing"`
+	S1      service1      `ioc-inject:"true"`
+}
+
+func TestConfigValue(t *testing.T) {
+	t.Run("get config should assert or convert value", func(t *testing.T) {
+		globalContainer = New()
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("timeout", "5s")
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("port", "8080")
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("ratio", 0.5)
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("tags", []any{"a", "b"})
+
+		if timeout, ok := GetConfig[time.Duration]("timeout"); !ok || timeout != 5*time.Second {
+			t.Error("string should be converted to duration")
+			return
+		}
+		if port, ok := GetConfig[int]("port"); !ok || port != 8080 {
+			t.Error("string should be converted to int")
+			return
+		}
+		if port, ok := GetConfig[string]("port"); !ok || port != "8080" {
+			t.Error("value should be got as it is")
+			return
+		}
+		if ratio, ok := GetConfig[float32]("ratio"); !ok || ratio != 0.5 {
+			t.Error("float64 should be converted to float32")
+			return
+		}
+		if tags, ok := GetConfig[[]string]("tags"); !ok || len(tags) != 2 || tags[1] != "b" {
+			t.Error("slice should be converted")
+			return
+		}
+		if _, ok := GetConfig[int]("timeout"); ok {
+			t.Error("value can't be converted should be false")
+			return
+		}
+		if _, ok := GetConfig[int]("missing"); ok {
+			t.Error("missing key should be false")
+			return
+		}
+		if GetService[*string]() != nil {
+			t.Error("config value should not be registered as service")
+			return
+		}
+	})
+
+	t.Run("config value should be got from parent", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		_ = parent.(ConfigValueStore).AddConfigValue("debug", true)
+		SetParent(parent)
+		if debug, ok := GetConfig[bool]("debug"); !ok || !debug {
+			t.Error("config value should be got from parent")
+			return
+		}
+	})
+
+	t.Run("field tagged with 'ioc-config' should be injected", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("timeout", "5s")
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("port", 8080)
+		_ = globalContainer.(ConfigValueStore).AddConfigValue("debug", "true")
+		client := &configValueClient{Name: "default"}
+		Inject(client)
+		if client.Timeout != 5*time.Second || client.Port != 8080 || !client.Debug || client.S1 == nil {
+			t.Errorf("config values and services should be injected, but %+v", client)
+			return
+		}
+		if client.Name != "default" {
+			t.Error("field of missing key should be left as it is")
+			return
+		}
+		AddSingleton[*configValueClient](&configValueClient{})
+		if err := VerifyContainer(globalContainer); err != nil {
+			t.Errorf("config field should not be reported as dependency, but %v", err)
+			return
+		}
+	})
+
+	t.Run("invalid config value should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(ConfigValueStore).AddConfigValue("", 1); err == nil {
+			t.Error("empty key should fail")
+			return
+		}
+		if err := globalContainer.(ConfigValueStore).AddConfigValue("key", nil); err == nil {
+			t.Error("nil value should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResolveOrConstruct to get service 'T' from global container, or construct it if it's an unregistered *struct,
+// see 'ConstructResolver.ResolveOrConstruct'. It returns zero value if 'T' can't be resolved or constructed.
+//
+//	handler := ioc.ResolveOrConstruct[*OrderHandler]()
+func ResolveOrConstruct[T any]() T {
+	return ResolveOrConstructFromC[T](globalContainer)
+}
+
+// ResolveOrConstructFromC to get service 'T' from container, or construct it if it's an unregistered *struct,
+// see 'ConstructResolver.ResolveOrConstruct'. It returns zero value if 'T' can't be resolved or constructed.
+func ResolveOrConstructFromC[T any](container Container) T {
+	var instance T
+	if val := container.(ConstructResolver).ResolveOrConstruct(TypeOf[T]()); val.IsValid() {
+		instance, _ = val.Interface().(T)
+	}
+	return instance
+}
+
+// ConstructResolver is implemented by container to construct unregistered '*struct' when resolving.
+type ConstructResolver interface {
+	// ResolveOrConstruct to resolve service, or construct it if it's an unregistered '*struct' without registering it.
+	// The instance constructed is injected and initialized, and it's fields of unregistered '*struct' are constructed too.
+	// Interface still requires registration, so it's invalid value if not found.
+	//
+	// It will panic if constructing makes a cycle, such as '*A' has field of '*B' and '*B' has field of '*A'.
+	//
+	//  var container ioc.Container
+	//  handler := container.(ioc.ConstructResolver).ResolveOrConstruct(reflect.TypeOf((*OrderHandler)(nil))).Interface().(*OrderHandler)
+	ResolveOrConstruct(serviceType reflect.Type) reflect.Value
+}
+
+var _ ConstructResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveOrConstruct(serviceType reflect.Type) reflect.Value {
+	if serviceType == nil {
+		return reflect.Value{}
+	}
+	return c.resolveOrConstruct(serviceType, nil)
+}
+
+// resolveOrConstruct to resolve service, or construct it if it's an unregistered *struct.
+// 'path' is the types being constructed, to detect cycle of constructing.
+func (c *defaultContainer) resolveOrConstruct(serviceType reflect.Type, path []reflect.Type) reflect.Value {
+	if serviceType.Kind() != reflect.Pointer || serviceType.Elem().Kind() != reflect.Struct {
+		// only *struct can be constructed, and not found is the same as resolving
+		return c.Resolve(serviceType)
+	}
+	if val := c.resolveLenient(serviceType, nil); val.IsValid() {
+		return val
+	}
+	for i, constructing := range path {
+		if constructing == serviceType {
+			names := make([]string, 0, len(path)-i+1)
+			for _, t := range path[i:] {
+				names = append(names, t.String())
+			}
+			names = append(names, serviceType.String())
+			panic(fmt.Errorf("cycle reference: constructing '%s'", strings.Join(names, " -> ")))
+		}
+	}
+	path = append(path, serviceType)
+
+	ptr, err := allocate(c, serviceType)
+	if err != nil {
+		panic(err)
+	}
+	// detect initialize method as singleton
+	binding, err := newSingletonBinding(serviceType, ptr.Interface())
+	if err != nil {
+		panic(err)
+	}
+	if _, ok := ptr.Interface().(SelfInjector); ok {
+		InjectFromC(c, ptr)
+	} else {
+		c.injectConstructing(ptr, path)
+	}
+	if binding.InstanceInitializer.IsValid() {
+		InjectFromC(c, binding.InstanceInitializer)
+	}
+	return ptr
+}
+
+// injectConstructing to inject to fields of instance being constructed, fields of unregistered *struct are constructed too.
+func (c *defaultContainer) injectConstructing(ptr reflect.Value, path []reflect.Type) {
+	structType := ptr.Type().Elem()
+	fields := c.fieldsToInject(structType)
+	injectContext(c, ptr, nil)
+	for _, field := range fields {
+		if field.When != "" && !conditionHolds(c, field.When) {
+			continue
+		}
+		var val reflect.Value
+		if isConstructible(field) && !c.canResolve(field.FieldType) {
+			val = c.resolveOrConstruct(field.FieldType, path)
+		} else {
+			val = resolveField(c, field, nil)
+		}
+		if val.IsValid() {
+			ptr.Elem().Field(field.FieldIndex).Set(val)
+		}
+	}
+	if c.autoInjects(structType) {
+		c.injectUntagged(ptr, structType, nil)
+	}
+}
+
+// isConstructible to check whether field can be constructed if it's not registered, which is plain *struct field.
+func isConstructible(field structField) bool {
+	return !field.HasKey && field.Group == "" && !field.HasConfig && !field.FromParent &&
+		field.OptionalOf == nil && field.ProviderOf == nil &&
+		field.FieldType.Kind() == reflect.Pointer && field.FieldType.Elem().Kind() == reflect.Struct
+}
@@ -0,0 +1,134 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+type constructedRepo struct {
+	Dep service1 `ioc-inject:"true"`
+}
+
+type constructedHandler struct {
+	Dep         service1         `ioc-inject:"true"`
+	Repo        *constructedRepo `ioc-inject:"true"`
+	initialized int
+}
+
+func (h *constructedHandler) Initialize(dep service2) {
+	if dep != nil {
+		h.initialized++
+	}
+}
+
+type constructedCycleA struct {
+	B *constructedCycleB `ioc-inject:"true"`
+}
+
+type constructedCycleB struct {
+	A *constructedCycleA `ioc-inject:"true"`
+}
+
+func TestResolveOrConstruct(t *testing.T) {
+	t.Run("unregistered struct should be constructed with registered services", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "dep"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		handler := ResolveOrConstruct[*constructedHandler]()
+		if handler == nil || handler.Dep == nil || handler.Dep.GetName() != "dep" || handler.initialized != 1 {
+			t.Error("constructed instance should be injected and initialized")
+			return
+		}
+		if handler.Repo == nil || handler.Repo.Dep == nil || handler.Repo.Dep.GetName() != "dep" {
+			t.Error("field of unregistered struct should be constructed too")
+			return
+		}
+		if ResolveOrConstruct[*constructedHandler]() == handler {
+			t.Error("constructed instance should not be cached")
+			return
+		}
+		if globalContainer.Resolve(TypeOf[*constructedHandler]()).IsValid() {
+			t.Error("constructed instance should not be registered")
+			return
+		}
+	})
+
+	t.Run("registered service should be resolved", func(t *testing.T) {
+		globalContainer = New()
+		registered := &constructedRepo{}
+		AddSingleton[*constructedRepo](registered)
+		handler := ResolveOrConstruct[*constructedHandler]()
+		if ResolveOrConstruct[*constructedRepo]() != registered || handler.Repo != registered {
+			t.Error("registered service should be resolved instead of constructed")
+			return
+		}
+	})
+
+	t.Run("interface should be resolved once", func(t *testing.T) {
+		globalContainer = New()
+		created := 0
+		AddTransient[service1](func() service1 {
+			created++
+			return &serviceInstance1{name: "instance1"}
+		})
+		if svc := ResolveOrConstruct[service1](); svc == nil || created != 1 {
+			t.Errorf("interface should be resolved once, but created %d times", created)
+			return
+		}
+	})
+
+	t.Run("interface should require registration", func(t *testing.T) {
+		globalContainer = New()
+		if ResolveOrConstruct[service1]() != nil {
+			t.Error("unregistered interface should be zero value")
+			return
+		}
+		if globalContainer.(ConstructResolver).ResolveOrConstruct(nil).IsValid() {
+			t.Error("null type should be invalid")
+			return
+		}
+	})
+
+	t.Run("cycle of constructing should panic", func(t *testing.T) {
+		globalContainer = New()
+		defer func() {
+			err, _ := recover().(error)
+			if err == nil || !strings.Contains(err.Error(), "cycle reference") {
+				t.Errorf("should panic for cycle reference, but '%v'", err)
+			}
+		}()
+		ResolveOrConstruct[*constructedCycleA]()
+	})
+
+	t.Run("strict resolve should not panic for constructible fields", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		AddSingleton[service1](&serviceInstance1{name: "dep"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		if handler := ResolveOrConstruct[*constructedHandler](); handler == nil || handler.Repo == nil {
+			t.Error("field of unregistered struct should be constructed in strict mode")
+			return
+		}
+	})
+}
@@ -0,0 +1,69 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+)
+
+// ContextResolver is implemented by container to resolve service with context.
+type ContextResolver interface {
+	// ResolveWithContext to resolve service in a goroutine, and return the context's error if resolving doesn't finish
+	// before 'ctx' is done. Panic during resolving will be converted into error.
+	//
+	// Timed-out resolving can't be killed and may still be running, the instance constructed by it will be discarded,
+	// so factories should dispose resources by themselves if needed.
+	//
+	//  ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	//  defer cancel()
+	//  val, err := container.(ioc.ContextResolver).ResolveWithContext(ctx, reflect.TypeOf((*Service1)(nil)).Elem())
+	ResolveWithContext(ctx context.Context, serviceType reflect.Type) (reflect.Value, error)
+}
+
+var _ ContextResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveWithContext(ctx context.Context, serviceType reflect.Type) (reflect.Value, error) {
+	if ctx == nil {
+		return reflect.Value{}, errors.New("param 'ctx' is null")
+	}
+	if err := ctx.Err(); err != nil {
+		return reflect.Value{}, err
+	}
+	type result struct {
+		val reflect.Value
+		err error
+	}
+	// buffered, so the goroutine won't leak after timed out
+	resultChan := make(chan result, 1)
+	go func() {
+		val, err := SafeResolve(c, serviceType)
+		resultChan <- result{val: val, err: err}
+	}()
+	select {
+	case r := <-resultChan:
+		return r.val, r.err
+	case <-ctx.Done():
+		return reflect.Value{}, ctx.Err()
+	}
+}
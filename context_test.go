@@ -0,0 +1,80 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResolveWithContext(t *testing.T) {
+	t.Run("resolve in time should success", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { return &serviceInstance1{name: "instance1"} })
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		val, err := globalContainer.(ContextResolver).ResolveWithContext(ctx, reflect.TypeOf((*serviceInstance1)(nil)))
+		if err != nil || !val.IsValid() || val.Interface().(*serviceInstance1).name != "instance1" {
+			t.Error("resolve in time should success")
+			return
+		}
+	})
+
+	t.Run("slow factory should time out", func(t *testing.T) {
+		globalContainer = New()
+		release := make(chan struct{})
+		defer close(release)
+		AddTransient[*serviceInstance1](func() *serviceInstance1 {
+			<-release
+			return &serviceInstance1{name: "instance1"}
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		val, err := globalContainer.(ContextResolver).ResolveWithContext(ctx, reflect.TypeOf((*serviceInstance1)(nil)))
+		if !errors.Is(err, context.DeadlineExceeded) || val.IsValid() {
+			t.Error("slow factory should time out")
+			return
+		}
+	})
+
+	t.Run("done context should fail", func(t *testing.T) {
+		globalContainer = New()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := globalContainer.(ContextResolver).ResolveWithContext(ctx, reflect.TypeOf((*serviceInstance1)(nil))); !errors.Is(err, context.Canceled) {
+			t.Error("done context should fail")
+			return
+		}
+	})
+
+	t.Run("panic during resolving should convert into error", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { panic("factory fail") })
+		if _, err := globalContainer.(ContextResolver).ResolveWithContext(context.Background(), reflect.TypeOf((*serviceInstance1)(nil))); err == nil {
+			t.Error("panic should convert into error")
+			return
+		}
+	})
+}
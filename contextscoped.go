@@ -0,0 +1,150 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// contextScoped is the instance of service cached until the context is done.
+type contextScoped struct {
+	ctx      context.Context
+	factory  func() any
+	dispose  func(instance any)
+	locker   sync.Mutex
+	created  reflect.Value // created by factory, which is disposed
+	instance reflect.Value // decorated and resolved
+	done     bool          // context is done, and the instance has been disposed
+}
+
+// AddContextScoped to add service to global container, whose instance is cached until 'ctx' is done, see 'ContextScopeAdder.AddContextScoped'.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid, or 'ctx' is done.
+//
+//	ctx, cancel := context.WithCancel(context.Background())
+//	defer cancel()
+//	ioc.AddContextScoped[*Session](ctx, newSession, func(s *Session) { s.Close() })
+func AddContextScoped[TService any](ctx context.Context, instanceFactory func() TService, dispose func(TService)) {
+	AddContextScopedToC[TService](globalContainer, ctx, instanceFactory, dispose)
+}
+
+// AddContextScopedToC to add service to container, whose instance is cached until 'ctx' is done, see 'ContextScopeAdder.AddContextScoped'.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid, or 'ctx' is done.
+func AddContextScopedToC[TService any](container Container, ctx context.Context, instanceFactory func() TService, dispose func(TService)) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	var disposeAny func(any)
+	if dispose != nil {
+		disposeAny = func(instance any) {
+			dispose(instance.(TService))
+		}
+	}
+	if err := container.(ContextScopeAdder).AddContextScoped(ctx, TypeOf[TService](), func() any { return instanceFactory() }, disposeAny); err != nil {
+		panic(err)
+	}
+}
+
+// ContextScopeAdder is implemented by container to add service cached until context is done.
+type ContextScopeAdder interface {
+	// AddContextScoped to add service whose instance is created by factory on first resolving, and cached until 'ctx' is done,
+	// such as client of a long-running operation with it's own context. After 'ctx' is done, the instance is disposed
+	// by 'dispose', or by it's 'Dispose' if 'dispose' is null and it implements 'ioc.Disposable', and the service is not found.
+	// The context is watched by a goroutine started after the instance created, so nothing is left if it's never resolved.
+	// The instance is resolved without injecting or initializer, and the factory should not resolve the service itself.
+	//
+	//  ctx, cancel := context.WithCancel(context.Background())
+	//  defer cancel()
+	//  err := container.(ioc.ContextScopeAdder).AddContextScoped(ctx, reflect.TypeOf((*Session)(nil)), func() any { return newSession() }, nil)
+	AddContextScoped(ctx context.Context, serviceType reflect.Type, instanceFactory func() any, dispose func(instance any)) error
+}
+
+var _ ContextScopeAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddContextScoped(ctx context.Context, serviceType reflect.Type, instanceFactory func() any, dispose func(instance any)) error {
+	if ctx == nil {
+		return errors.New("param 'ctx' is null")
+	}
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("context of service '%v' is done: %w", serviceType, err)
+	}
+	scoped := &contextScoped{ctx: ctx, factory: instanceFactory, dispose: dispose}
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, ContextScoped: scoped})
+}
+
+// get to get instance cached, and create it by factory in the call of 'path' if not exists. It's not found after the context
+// is done. Instance created is not cached if the call is dry, see 'VerifyContainer'.
+func (s *contextScoped) get(b *serviceBinding, owner *defaultContainer, path *resolvePath) reflect.Value {
+	defer s.locker.Unlock()
+	s.locker.Lock()
+	if s.done || s.ctx.Err() != nil {
+		return reflect.Value{}
+	}
+	if s.instance.IsValid() {
+		return s.instance
+	}
+	created, ok := owner.invokeFactory(b.ServiceType, s.factory, path)
+	if !ok {
+		// recovered panic of factory
+		return reflect.Value{}
+	}
+	if !created.IsValid() {
+		// not cached, and retry on next resolving
+		return reflect.Zero(b.ServiceType)
+	}
+	if path.isDry() {
+		return owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, created, owner))
+	}
+	s.created = created
+	s.instance = owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, created, owner))
+	// watch only after created, so nothing is left if it's never resolved
+	go s.watch(owner)
+	return s.instance
+}
+
+// watch to dispose instance created after the context is done.
+func (s *contextScoped) watch(owner *defaultContainer) {
+	<-s.ctx.Done()
+	s.locker.Lock()
+	created := s.created
+	s.created, s.instance, s.done = reflect.Value{}, reflect.Value{}, true
+	s.locker.Unlock()
+
+	if s.dispose != nil {
+		s.dispose(created.Interface())
+	} else if disposable, ok := created.Interface().(Disposable); ok {
+		if err := disposable.Dispose(); err != nil {
+			owner.logf("dispose service '%v' fail: %v", created.Type(), err)
+		}
+	}
+}
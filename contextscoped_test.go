@@ -0,0 +1,126 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type contextSession struct {
+	disposed int32
+}
+
+func (s *contextSession) GetName() string {
+	return "session"
+}
+
+func (s *contextSession) Dispose() error {
+	atomic.AddInt32(&s.disposed, 1)
+	return nil
+}
+
+// waitFor to wait until 'cond' is true or timeout.
+func waitFor(cond func() bool) bool {
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if cond() {
+			return true
+		}
+	}
+	return cond()
+}
+
+func TestAddContextScoped(t *testing.T) {
+	t.Run("instance should be disposed after context cancelled", func(t *testing.T) {
+		globalContainer = New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var created, disposed int32
+		AddContextScoped[service1](ctx, func() service1 {
+			atomic.AddInt32(&created, 1)
+			return &serviceInstance1{name: "session"}
+		}, func(instance service1) {
+			if instance.GetName() == "session" {
+				atomic.AddInt32(&disposed, 1)
+			}
+		})
+		first, second := GetService[service1](), GetService[service1]()
+		if first == nil || first != second || atomic.LoadInt32(&created) != 1 {
+			t.Error("instance should be cached before context done")
+			return
+		}
+		cancel()
+		if !waitFor(func() bool { return atomic.LoadInt32(&disposed) == 1 }) {
+			t.Error("instance should be disposed after context cancelled")
+			return
+		}
+		if GetService[service1]() != nil || atomic.LoadInt32(&created) != 1 {
+			t.Error("service should not be found after context cancelled")
+			return
+		}
+	})
+
+	t.Run("disposable should be disposed if dispose is null", func(t *testing.T) {
+		globalContainer = New()
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		session := &contextSession{}
+		AddContextScoped[service1](ctx, func() service1 { return session }, nil)
+		if GetService[service1]() != service1(session) {
+			t.Error("instance should be resolved")
+			return
+		}
+		cancel()
+		if !waitFor(func() bool { return atomic.LoadInt32(&session.disposed) == 1 }) {
+			t.Error("disposable should be disposed after context cancelled")
+			return
+		}
+	})
+
+	t.Run("instance never resolved should not be created or disposed", func(t *testing.T) {
+		globalContainer = New()
+		ctx, cancel := context.WithCancel(context.Background())
+		var calls int32
+		AddContextScoped[service1](ctx, func() service1 {
+			atomic.AddInt32(&calls, 1)
+			return &serviceInstance1{}
+		}, func(service1) { atomic.AddInt32(&calls, 1) })
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		if atomic.LoadInt32(&calls) != 0 || GetService[service1]() != nil || atomic.LoadInt32(&calls) != 0 {
+			t.Error("factory and dispose should not be called if never resolved before context done")
+			return
+		}
+	})
+
+	t.Run("done context should be rejected", func(t *testing.T) {
+		globalContainer = New()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if err := globalContainer.(ContextScopeAdder).AddContextScoped(ctx, TypeOf[service1](), func() any { return &serviceInstance1{} }, nil); err == nil {
+			t.Error("done context should be rejected")
+			return
+		}
+	})
+}
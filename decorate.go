@@ -0,0 +1,147 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+)
+
+// Decorate to add a decorator of service 'TService'.
+//
+// It will panic if 'TService' or 'decorator' is invalid.
+//
+//	ioc.Decorate[Repository](func(inner Repository) Repository {
+//	    return &LoggingRepository{inner: inner}
+//	})
+func Decorate[TService any](decorator func(inner TService) TService) {
+	DecorateToC[TService](globalContainer, decorator)
+}
+
+// DecorateToC to add a decorator of service 'TService' to container.
+//
+// It will panic if 'TService' or 'decorator' is invalid.
+func DecorateToC[TService any](container Container, decorator func(inner TService) TService) {
+	if decorator == nil {
+		panic("param 'decorator' is null")
+	}
+	DecorateWithResolverToC[TService](container, func(inner TService, resolver Resolver) TService {
+		return decorator(inner)
+	})
+}
+
+// DecorateWithResolver to add a decorator of service 'TService', which can resolve other services by the resolver.
+//
+// It will panic if 'TService' or 'decorator' is invalid.
+//
+//	ioc.DecorateWithResolver[Repository](func(inner Repository, resolver ioc.Resolver) Repository {
+//	    cache := resolver.Resolve(TypeOf[Cache]()).Interface().(Cache)
+//	    return &CachedRepository{inner: inner, cache: cache}
+//	})
+func DecorateWithResolver[TService any](decorator func(inner TService, resolver Resolver) TService) {
+	DecorateWithResolverToC[TService](globalContainer, decorator)
+}
+
+// DecorateWithResolverToC to add a decorator of service 'TService' to container, which can resolve other services by the resolver.
+//
+// It will panic if 'TService' or 'decorator' is invalid.
+func DecorateWithResolverToC[TService any](container Container, decorator func(inner TService, resolver Resolver) TService) {
+	if decorator == nil {
+		panic("param 'decorator' is null")
+	}
+	err := container.(Decorator).Decorate(TypeOf[TService](), func(inner reflect.Value, resolver Resolver) reflect.Value {
+		var innerInstance TService
+		if val, ok := inner.Interface().(TService); ok {
+			innerInstance = val
+		}
+		return reflect.ValueOf(decorator(innerInstance, resolver))
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Decorator is implemented by container to wrap instances of service after constructing.
+type Decorator interface {
+	// Decorate to add a decorator of service, which wraps the instance after constructing,
+	// before the singleton instance is cached or the transient instance is returned.
+	//
+	// Decorators compose in adding order, and receive the resolver which the resolving started from for transient,
+	// so decorators can resolve other services with overrides in child container. Instance cached by current container,
+	// such as singleton, is decorated with current container instead, so it never keeps services of a child.
+	// The returns of decorator will be skipped if it is invalid or can't be assigned to the service type.
+	//
+	//  err := container.(ioc.Decorator).Decorate(TypeOf[Repository](), func(inner reflect.Value, resolver ioc.Resolver) reflect.Value {
+	//      cache := resolver.Resolve(TypeOf[Cache]()).Interface().(Cache)
+	//      return reflect.ValueOf(&CachedRepository{inner: inner.Interface().(Repository), cache: cache})
+	//  })
+	Decorate(serviceType reflect.Type, decorator func(inner reflect.Value, resolver Resolver) reflect.Value) error
+}
+
+var _ Decorator = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Decorate(serviceType reflect.Type, decorator func(inner reflect.Value, resolver Resolver) reflect.Value) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if decorator == nil {
+		return errors.New("param 'decorator' is null")
+	}
+	if err := validateBinding(&serviceBinding{ServiceType: serviceType}); err != nil {
+		return err
+	}
+
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	var decorators []func(inner reflect.Value, resolver Resolver) reflect.Value
+	if val, ok := c.decorators.Load(serviceType); ok {
+		decorators = val.([]func(inner reflect.Value, resolver Resolver) reflect.Value)
+	}
+	// copy on write, so decorators can be read without lock
+	newDecorators := make([]func(inner reflect.Value, resolver Resolver) reflect.Value, 0, len(decorators)+1)
+	newDecorators = append(newDecorators, decorators...)
+	newDecorators = append(newDecorators, decorator)
+	c.decorators.Store(serviceType, newDecorators)
+	atomic.StoreInt32(&c.decorated, 1)
+	return nil
+}
+
+// decorateInstance to apply decorators of service type to instance in order,
+// skip decorator whose returns is invalid or can't be assigned to service type.
+func (c *defaultContainer) decorateInstance(serviceType reflect.Type, instance reflect.Value, origin *defaultContainer) reflect.Value {
+	if !instance.IsValid() || atomic.LoadInt32(&c.decorated) == 0 {
+		return instance
+	}
+	val, ok := c.decorators.Load(serviceType)
+	if !ok {
+		return instance
+	}
+	for _, decorator := range val.([]func(inner reflect.Value, resolver Resolver) reflect.Value) {
+		decorated := decorator(instance, origin)
+		if !decorated.IsValid() || !decorated.Type().AssignableTo(serviceType) {
+			continue
+		}
+		instance = decorated
+	}
+	return instance
+}
@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDecorate(t *testing.T) {
+	t.Run("decorators should compose in order", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddTransient[service2](func() service2 { return &serviceInstance2{name: "instance2"} })
+		Decorate[service1](func(inner service1) service1 {
+			return &serviceProxy{inner: inner, prefix: "a-"}
+		})
+		Decorate[service1](func(inner service1) service1 {
+			return &serviceProxy{inner: inner, prefix: "b-"}
+		})
+		Decorate[service2](func(inner service2) service2 {
+			inner.Rename("decorated-" + inner.GetName())
+			return inner
+		})
+
+		svc1 := GetService[service1]()
+		if svc1 == nil || svc1.GetName() != "b-a-instance1" || GetService[service1]() != svc1 {
+			t.Error("decorated singleton should be cached")
+			return
+		}
+		svc2 := GetService[service2]()
+		if svc2 == nil || svc2.GetName() != "decorated-instance2" || GetService[service2]() == svc2 {
+			t.Error("transient should be decorated every time")
+			return
+		}
+	})
+
+	t.Run("decorator with resolver should resolve sibling service", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddTransientToC[service2](parent, func() service2 { return &serviceInstance2{name: "instance2"} })
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "parent-prefix-"})
+		DecorateWithResolverToC[service2](parent, func(inner service2, resolver Resolver) service2 {
+			prefix := resolver.Resolve(reflect.TypeOf((*serviceInstance7)(nil))).Interface().(*serviceInstance7)
+			inner.Rename(prefix.GetName() + inner.GetName())
+			return inner
+		})
+		SetParent(parent)
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "child-prefix-"})
+
+		if svc := GetServiceFromC[service2](parent); svc == nil || svc.GetName() != "parent-prefix-instance2" {
+			t.Error("decorator should resolve service from parent")
+			return
+		}
+		if svc := GetService[service2](); svc == nil || svc.GetName() != "child-prefix-instance2" {
+			t.Error("decorator should resolve service with overrides in child")
+			return
+		}
+	})
+
+	t.Run("invalid decorator should fail", func(t *testing.T) {
+		globalContainer = New()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("null decorator should fail")
+				}
+			}()
+			Decorate[service1](nil)
+		}()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("type of service 'serviceInstance1' should be interface or *struct")
+				}
+			}()
+			Decorate[serviceInstance1](func(inner serviceInstance1) serviceInstance1 { return inner })
+		}()
+	})
+}
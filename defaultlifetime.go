@@ -0,0 +1,135 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Add to add instance or factory 'func() TService' to global container with lifetime inferred from package of service type.
+//
+// It will panic if 'instanceOrFactory' is invalid.
+//
+//	ioc.Add(func() *handlers.UserHandler { return &handlers.UserHandler{} })
+func Add(instanceOrFactory any) {
+	if err := globalContainer.(DefaultLifetimeAdder).Add(instanceOrFactory); err != nil {
+		panic(err)
+	}
+}
+
+// DefaultLifetimeAdder is implemented by container to add services with lifetime by package path.
+type DefaultLifetimeAdder interface {
+	// SetDefaultLifetimeFor to set default lifetime of services whose package path is 'pkgPath' or under it, used by 'Add'.
+	// The longest 'pkgPath' wins if multiple ones match, and 'ioc.LifetimeUnknown' removes the default of 'pkgPath'.
+	//
+	//  var container ioc.Container
+	//  container.(ioc.DefaultLifetimeAdder).SetDefaultLifetimeFor("example.com/app/internal/handlers", ioc.LifetimeTransient)
+	SetDefaultLifetimeFor(pkgPath string, lifetime Lifetime)
+
+	// Add to add instance or factory 'func() TService' with lifetime inferred from package of service type, see 'SetDefaultLifetimeFor'.
+	// Service type is type of instance or return type of factory, and instance can only be singleton.
+	// If there is no default lifetime of the package, instance is singleton and factory is transient.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.DefaultLifetimeAdder).Add(func() *handlers.UserHandler { return &handlers.UserHandler{} })
+	Add(instanceOrFactory any) error
+}
+
+var _ DefaultLifetimeAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetDefaultLifetimeFor(pkgPath string, lifetime Lifetime) {
+	pkgPath = strings.TrimSuffix(pkgPath, "/")
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if lifetime != LifetimeSingleton && lifetime != LifetimeTransient && lifetime != LifetimeScoped {
+		delete(c.defaultLifetimes, pkgPath)
+		return
+	}
+	if c.defaultLifetimes == nil {
+		c.defaultLifetimes = make(map[string]Lifetime)
+	}
+	c.defaultLifetimes[pkgPath] = lifetime
+}
+
+// defaultLifetimeOf to get default lifetime of service type by the longest package path matched.
+func (c *defaultContainer) defaultLifetimeOf(serviceType reflect.Type) Lifetime {
+	pkgPath := pkgPathOf(serviceType)
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	lifetime, matched := LifetimeUnknown, -1
+	for prefix, l := range c.defaultLifetimes {
+		if len(prefix) <= matched {
+			continue
+		}
+		if underPackage(pkgPath, prefix) {
+			lifetime, matched = l, len(prefix)
+		}
+	}
+	return lifetime
+}
+
+// underPackage to check whether package path is 'prefix' or under it, and any one is under empty prefix.
+func underPackage(pkgPath string, prefix string) bool {
+	return pkgPath == prefix || prefix == "" || strings.HasPrefix(pkgPath, prefix+"/")
+}
+
+func (c *defaultContainer) Add(instanceOrFactory any) error {
+	if instanceOrFactory == nil {
+		return errors.New("param 'instanceOrFactory' is null")
+	}
+	val := reflect.ValueOf(instanceOrFactory)
+	if val.Kind() != reflect.Func {
+		if err := checkInstance(instanceOrFactory); err != nil {
+			return err
+		}
+		serviceType := val.Type()
+		if lifetime := c.defaultLifetimeOf(serviceType); lifetime != LifetimeUnknown && lifetime != LifetimeSingleton {
+			return fmt.Errorf("service '%v' is '%v' by default, add factory instead of instance", serviceType, lifetime)
+		}
+		return c.AddSingleton(serviceType, instanceOrFactory)
+	}
+
+	if val.IsNil() {
+		return errors.New("param 'instanceOrFactory' is null")
+	}
+	factoryType := val.Type()
+	if factoryType.NumIn() != 0 || factoryType.NumOut() != 1 {
+		return fmt.Errorf("factory '%v' should be 'func() TService'", factoryType)
+	}
+	serviceType := factoryType.Out(0)
+	instanceFactory := func() any {
+		return val.Call(nil)[0].Interface()
+	}
+	switch c.defaultLifetimeOf(serviceType) {
+	case LifetimeSingleton:
+		binding := &serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory, Lazy: true}
+		binding.Memo.Store(newLazyMemo())
+		return c.addBinding(binding)
+	case LifetimeScoped:
+		return c.AddScoped(serviceType, instanceFactory)
+	default:
+		return c.AddTransient(serviceType, instanceFactory)
+	}
+}
@@ -0,0 +1,107 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	t.Run("lifetime should be inferred from package of service type", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("bytes", LifetimeTransient)
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("strings", LifetimeSingleton)
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("gopkg.berkaroad.top", LifetimeTransient)
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("gopkg.berkaroad.top/ioc", LifetimeScoped)
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("gopkg.berkaroad.top/io", LifetimeSingleton)
+
+		Add(func() *bytes.Buffer { return &bytes.Buffer{} })
+		Add(func() *strings.Builder { return &strings.Builder{} })
+		Add(func() service1 { return &serviceInstance1{} })
+		Add(func() *sync.WaitGroup { return &sync.WaitGroup{} })
+		Add(&sync.Mutex{})
+
+		expected := map[reflect.Type]Lifetime{
+			TypeOf[*bytes.Buffer]():    LifetimeTransient,
+			TypeOf[*strings.Builder](): LifetimeSingleton,
+			TypeOf[service1]():         LifetimeScoped, // the longest matched wins, but not "gopkg.berkaroad.top/io"
+			TypeOf[*sync.WaitGroup]():  LifetimeTransient,
+			TypeOf[*sync.Mutex]():      LifetimeSingleton,
+		}
+		for serviceType, lifetime := range expected {
+			if _, actual, found := globalContainer.(LifetimeResolver).ResolveWithInfo(serviceType); !found || actual != lifetime {
+				t.Errorf("lifetime of '%v' should be '%v', but '%v'", serviceType, lifetime, actual)
+				return
+			}
+		}
+		if GetService[*strings.Builder]() != GetService[*strings.Builder]() {
+			t.Error("factory of singleton should be invoked once")
+			return
+		}
+		if GetService[*bytes.Buffer]() == GetService[*bytes.Buffer]() {
+			t.Error("factory of transient should be invoked for each resolving")
+			return
+		}
+	})
+
+	t.Run("default lifetime should be removed by unknown lifetime", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("bytes/", LifetimeSingleton)
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("bytes", LifetimeUnknown)
+		Add(func() *bytes.Buffer { return &bytes.Buffer{} })
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(TypeOf[*bytes.Buffer]()); lifetime != LifetimeTransient {
+			t.Errorf("factory should be transient without default lifetime, but '%v'", lifetime)
+			return
+		}
+	})
+
+	t.Run("invalid instance or factory should fail", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DefaultLifetimeAdder).SetDefaultLifetimeFor("bytes", LifetimeTransient)
+		if err := globalContainer.(DefaultLifetimeAdder).Add(nil); err == nil {
+			t.Error("null should fail")
+			return
+		}
+		if err := globalContainer.(DefaultLifetimeAdder).Add(&bytes.Buffer{}); err == nil {
+			t.Error("instance of transient should fail")
+			return
+		}
+		if err := globalContainer.(DefaultLifetimeAdder).Add(func(s string) *bytes.Buffer { return nil }); err == nil {
+			t.Error("factory with params should fail")
+			return
+		}
+		if err := globalContainer.(DefaultLifetimeAdder).Add(func() string { return "" }); err == nil {
+			t.Error("factory of invalid service type should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("null should panic")
+			}
+		}()
+		Add(nil)
+	})
+}
@@ -0,0 +1,110 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddDeferred to add singleton service to global container, whose registration is deferred until first resolving.
+//
+// It will panic if 'TService' or 'register' is invalid.
+func AddDeferred[TService any](register func(c Container) TService) {
+	AddDeferredToC[TService](globalContainer, register)
+}
+
+// AddDeferredToC to add singleton service to container, whose registration is deferred until first resolving.
+//
+// It will panic if 'TService' or 'register' is invalid.
+func AddDeferredToC[TService any](container Container, register func(c Container) TService) {
+	if register == nil {
+		panic("param 'register' is null")
+	}
+	err := container.(DeferredAdder).AddDeferred(TypeOf[TService](), func(c Container) any {
+		return register(c)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// DeferredAdder is implemented by container to add service registered on first resolving.
+type DeferredAdder interface {
+	// AddDeferred to add singleton service whose registration is deferred until first resolving.
+	// 'register' runs once with current container, it can add other services, and returns the instance,
+	// which is then injected and initialized as singleton. It's run again on next resolving if it returns invalid instance.
+	// Resolving the same service in 'register' will panic for cycle reference.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.DeferredAdder).AddDeferred(reflect.TypeOf((*Plugin)(nil)).Elem(), func(c ioc.Container) any {
+	//      ioc.AddSingletonToC[*PluginStore](c, openStore())
+	//      return &plugin{}
+	//  })
+	AddDeferred(serviceType reflect.Type, register func(c Container) any) error
+}
+
+var _ DeferredAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddDeferred(serviceType reflect.Type, register func(c Container) any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if register == nil {
+		return errors.New("param 'register' is null")
+	}
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, Deferred: register})
+}
+
+// resolveDeferred to run deferred registration once in the call of 'path', and resolve it's instance as singleton.
+// Registration resolving the service itself, directly or by it's dependencies, panics with error of cycle reference.
+func (c *defaultContainer) resolveDeferred(b *serviceBinding, path *resolvePath) reflect.Value {
+	if instance, ok := b.InitializedInstance.Load().(reflect.Value); ok && instance.IsValid() {
+		return instance
+	}
+	if path.isDry() {
+		// registration isn't run until the first resolving which is not dry, it may register services
+		return reflect.Zero(b.ServiceType)
+	}
+	return b.initOnce(path, func(path *resolvePath) reflect.Value {
+		return c.register(b, path)
+	})
+}
+
+// register to run deferred registration in the call of 'path', and resolve it's instance as singleton.
+func (c *defaultContainer) register(b *serviceBinding, path *resolvePath) reflect.Value {
+	instance := b.Deferred(resolvingContainer{defaultContainer: c, path: path})
+	if err := checkInstance(instance); err != nil {
+		c.logf("deferred registration of service '%v' fail: %v", b.ServiceType, err)
+		return reflect.Value{}
+	}
+	singleton, err := newSingletonBinding(b.ServiceType, instance)
+	if err == nil {
+		err = validateBinding(singleton)
+	}
+	if err != nil {
+		c.logf("deferred registration of service '%v' fail: %v", b.ServiceType, err)
+		return reflect.Value{}
+	}
+	singleton.DisposeOrder = b.DisposeOrder
+	return singleton.resolve(c, c, path)
+}
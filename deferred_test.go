@@ -0,0 +1,138 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type deferredPlugin struct {
+	S1   service1 `ioc-inject:"true"`
+	name string
+}
+
+func (p *deferredPlugin) GetName() string {
+	return p.name
+}
+
+type deferredDependent struct {
+	Plugin *deferredPlugin `ioc-inject:"true"`
+}
+
+func (d *deferredDependent) GetName() string {
+	return "dependent"
+}
+
+func TestAddDeferred(t *testing.T) {
+	t.Run("deferred registration should run once on first resolving", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "dep"})
+		var registered int32
+		AddDeferred[*deferredPlugin](func(c Container) *deferredPlugin {
+			atomic.AddInt32(&registered, 1)
+			// register sibling, and pull in dependency
+			AddSingletonToC[service2](c, &serviceInstance2{name: "sibling"})
+			return &deferredPlugin{name: GetServiceFromC[service1](c).GetName() + "-plugin"}
+		})
+		if atomic.LoadInt32(&registered) != 0 || GetService[service2]() != nil {
+			t.Error("registration should be deferred until first resolving")
+			return
+		}
+
+		var wg sync.WaitGroup
+		plugins := make([]*deferredPlugin, 20)
+		for i := range plugins {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				plugins[i] = GetService[*deferredPlugin]()
+			}(i)
+		}
+		wg.Wait()
+		if atomic.LoadInt32(&registered) != 1 {
+			t.Errorf("registration should run once, but %d", registered)
+			return
+		}
+		for _, plugin := range plugins {
+			if plugin != plugins[0] || plugin == nil || plugin.GetName() != "dep-plugin" || plugin.S1 == nil {
+				t.Error("instance should be injected and shared as singleton")
+				return
+			}
+		}
+		if svc := GetService[service2](); svc == nil || svc.GetName() != "sibling" {
+			t.Error("sibling registered by deferred registration should be resolved")
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(TypeOf[*deferredPlugin]()); lifetime != LifetimeSingleton {
+			t.Errorf("deferred service should be singleton, but %v", lifetime)
+			return
+		}
+	})
+
+	t.Run("invalid instance should not be cached", func(t *testing.T) {
+		globalContainer = New()
+		var registered int
+		AddDeferred[service1](func(c Container) service1 {
+			registered++
+			if registered == 1 {
+				return nil
+			}
+			return &serviceInstance1{name: "second"}
+		})
+		if GetService[service1]() != nil {
+			t.Error("invalid instance should not be resolved")
+			return
+		}
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "second" {
+			t.Error("registration should run again after invalid instance")
+			return
+		}
+	})
+
+	t.Run("resolving itself in deferred registration should fail with cycle reference", func(t *testing.T) {
+		globalContainer = New()
+		AddDeferred[service1](func(c Container) service1 {
+			return GetServiceFromC[service1](c)
+		})
+		if _, err := GetServiceE[service1](); err == nil || !strings.Contains(err.Error(), "cycle reference: ioc.service1 -> ioc.service1") {
+			t.Errorf("cycle reference should fail, but got %v", err)
+			return
+		}
+		// dependency of the instance resolves it
+		globalContainer = New()
+		AddDeferred[*deferredPlugin](func(c Container) *deferredPlugin {
+			return &deferredPlugin{}
+		})
+		AddSingleton[service1](&deferredDependent{})
+		if _, err := GetServiceE[*deferredPlugin](); err == nil || !strings.Contains(err.Error(), "cycle reference") {
+			t.Errorf("cycle reference by dependency should fail, but got %v", err)
+			return
+		}
+		if err := globalContainer.(DeferredAdder).AddDeferred(TypeOf[service2](), nil); err == nil {
+			t.Error("null register should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,85 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// DependenciesResolver is implemented by container to resolve service with what it's wired with.
+type DependenciesResolver interface {
+	// ResolveDependencies to resolve service and get what it's wired with, for inspection.
+	// Instances of injectable fields are keyed by field name, and params of initialize method by "<method name>[<index>]",
+	// such as "Initialize[0]". Dependencies not found are invalid values in the map, and reported by the error.
+	//
+	//  deps, err := container.(ioc.DependenciesResolver).ResolveDependencies(reflect.TypeOf((*Service1)(nil)).Elem())
+	ResolveDependencies(serviceType reflect.Type) (map[string]reflect.Value, error)
+}
+
+var _ DependenciesResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveDependencies(serviceType reflect.Type) (map[string]reflect.Value, error) {
+	if serviceType == nil {
+		return nil, errors.New("param 'serviceType' is null")
+	}
+	binding := c.lookupBinding(serviceType)
+	if binding == nil {
+		return nil, fmt.Errorf("service '%v' not found", serviceType)
+	}
+	// resolve to trigger initializing of singleton, and to get instance of transient
+	instance := c.resolveLenient(serviceType, nil)
+	if binding.Instance.IsValid() {
+		instance = binding.Instance
+	}
+	for instance.IsValid() && instance.Kind() == reflect.Interface {
+		instance = instance.Elem()
+	}
+	if !instance.IsValid() || instance.IsZero() {
+		return nil, fmt.Errorf("service '%v' can't be resolved", serviceType)
+	}
+
+	deps := make(map[string]reflect.Value)
+	var errs []error
+	for _, field := range getFieldsToInject(instance.Type()) {
+		val := resolveField(c, field, nil)
+		deps[field.FieldName] = val
+		if !val.IsValid() && !field.Optional {
+			errs = append(errs, fmt.Errorf("dependency '%v' of field '%s' can't be resolved", field.FieldType, field.FieldName))
+		}
+	}
+	if instance.Kind() == reflect.Pointer && instance.Type().Elem().Kind() == reflect.Struct {
+		if withInit, err := newSingletonBinding(instance.Type(), instance.Interface()); err == nil && withInit.InstanceInitializer.IsValid() {
+			methodType := withInit.InstanceInitializer.Type()
+			for i := 0; i < methodType.NumIn(); i++ {
+				val := c.resolveLenient(methodType.In(i), nil)
+				deps[fmt.Sprintf("%s[%d]", withInit.InstanceInitializerName, i)] = val
+				if !val.IsValid() {
+					errs = append(errs, fmt.Errorf("dependency '%v' of param[%d] in method '%s' can't be resolved",
+						methodType.In(i), i, withInit.InstanceInitializerName))
+				}
+			}
+		}
+	}
+	return deps, aggregateErrors(errs)
+}
@@ -0,0 +1,105 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type wiredService struct {
+	S1       service1          `ioc-inject:"true"`
+	S2       service2          `ioc-inject:"true"`
+	Optional *serviceInstance9 `ioc-inject:"optional"`
+	s7       *serviceInstance7
+}
+
+func (s *wiredService) Initialize(s7 *serviceInstance7) {
+	s.s7 = s7
+}
+
+func TestResolveDependencies(t *testing.T) {
+	t.Run("resolve dependencies should return instances of fields and initialize params", func(t *testing.T) {
+		globalContainer = New()
+		s1 := &serviceInstance1{name: "instance1"}
+		s7 := &serviceInstance7{name: "instance7"}
+		AddSingleton[service1](s1)
+		AddSingleton[*serviceInstance7](s7)
+		svc := &wiredService{}
+		AddSingleton[*wiredService](svc)
+
+		deps, err := globalContainer.(DependenciesResolver).ResolveDependencies(reflect.TypeOf(svc))
+		if err == nil || !strings.Contains(err.Error(), "field 'S2'") || strings.Contains(err.Error(), "Optional") {
+			t.Errorf("only missing required field should be reported, but %v", err)
+			return
+		}
+		if len(deps) != 4 {
+			t.Errorf("should contain fields and params, but %v", deps)
+			return
+		}
+		if !deps["S1"].IsValid() || deps["S1"].Interface() != service1(s1) {
+			t.Error("field 'S1' should be resolved")
+			return
+		}
+		if deps["S2"].IsValid() || deps["Optional"].IsValid() {
+			t.Error("missing dependencies should be invalid")
+			return
+		}
+		if !deps["Initialize[0]"].IsValid() || deps["Initialize[0]"].Interface() != s7 {
+			t.Error("param of initialize method should be resolved")
+			return
+		}
+		if svc.s7 != s7 {
+			t.Error("singleton should be initialized by resolving")
+			return
+		}
+	})
+
+	t.Run("resolve dependencies of transient should inspect it's instance", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		AddTransient[*wiredService](func() *wiredService { return &wiredService{} })
+		deps, err := globalContainer.(DependenciesResolver).ResolveDependencies(reflect.TypeOf((*wiredService)(nil)))
+		if err == nil || !strings.Contains(err.Error(), "param[0]") {
+			t.Errorf("missing param should be reported, but %v", err)
+			return
+		}
+		if !deps["S1"].IsValid() || !deps["S2"].IsValid() {
+			t.Error("fields should be resolved")
+			return
+		}
+	})
+
+	t.Run("resolve dependencies of service not found should fail", func(t *testing.T) {
+		globalContainer = New()
+		if _, err := globalContainer.(DependenciesResolver).ResolveDependencies(reflect.TypeOf((*wiredService)(nil))); err == nil {
+			t.Error("service not found should fail")
+			return
+		}
+		if _, err := globalContainer.(DependenciesResolver).ResolveDependencies(nil); err == nil {
+			t.Error("nil service type should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,126 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Dependency is a dependency of singleton to resolve, see 'DepsAdder.AddSingletonWithDeps'.
+type Dependency struct {
+	ServiceType reflect.Type
+	Key         any // key of keyed service
+	HasKey      bool
+}
+
+// DependencyOf to create a dependency on service 'TService'.
+func DependencyOf[TService any]() Dependency {
+	return Dependency{ServiceType: TypeOf[TService]()}
+}
+
+// KeyedDependencyOf to create a dependency on keyed service 'TService'.
+//
+//	ioc.KeyedDependencyOf[DB]("replica")
+func KeyedDependencyOf[TService any](key any) Dependency {
+	return Dependency{ServiceType: TypeOf[TService](), Key: key, HasKey: true}
+}
+
+// AddSingletonWithDeps to add singleton instance with explicit dependencies, which are resolved in order of 'deps' and passed to 'construct'.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+//
+//	ioc.AddSingletonWithDeps[Service1](&ServiceImplementation1{}, []ioc.Dependency{ioc.DependencyOf[Cache](), ioc.KeyedDependencyOf[DB]("replica")},
+//	    func(instance Service1, resolved []any) {
+//	        instance.(*ServiceImplementation1).db = resolved[1].(DB)
+//	    })
+func AddSingletonWithDeps[TService any](instance TService, deps []Dependency, construct func(instance TService, resolved []any)) {
+	AddSingletonWithDepsToC[TService](globalContainer, instance, deps, construct)
+}
+
+// AddSingletonWithDepsToC to add singleton instance with explicit dependencies to container.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+func AddSingletonWithDepsToC[TService any](container Container, instance TService, deps []Dependency, construct func(instance TService, resolved []any)) {
+	if construct == nil {
+		panic("param 'construct' is null")
+	}
+	err := container.(DepsAdder).AddSingletonWithDeps(TypeOf[TService](), instance, deps, func(instance any, resolved []any) {
+		construct(instance.(TService), resolved)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// DepsAdder is implemented by container to add singleton with explicit dependencies instead of tags.
+type DepsAdder interface {
+	// AddSingletonWithDeps to add singleton instance with explicit dependencies, which are resolved in order of 'deps'
+	// and passed to 'construct' once on first resolving, after injecting to fields and it's initialize method.
+	// Dependency with key is resolved as keyed service, and dependency not found is nil in 'resolved'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.DepsAdder).AddSingletonWithDeps(reflect.TypeOf((*Service1)(nil)).Elem(), &ServiceImplementation1{},
+	//      []ioc.Dependency{{ServiceType: reflect.TypeOf((*DB)(nil)).Elem(), Key: "replica", HasKey: true},
+	//          {ServiceType: reflect.TypeOf((*Cache)(nil)).Elem()}},
+	//      func(instance any, resolved []any) {
+	//          instance.(*ServiceImplementation1).db = resolved[0].(DB)
+	//          instance.(*ServiceImplementation1).cache = resolved[1].(Cache)
+	//      })
+	AddSingletonWithDeps(serviceType reflect.Type, instance any, deps []Dependency, construct func(instance any, resolved []any)) error
+}
+
+var _ DepsAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSingletonWithDeps(serviceType reflect.Type, instance any, deps []Dependency, construct func(instance any, resolved []any)) error {
+	if construct == nil {
+		return errors.New("param 'construct' is null")
+	}
+	for _, dep := range deps {
+		if dep.ServiceType == nil {
+			return errors.New("param 'deps' has null service type")
+		}
+		if dep.HasKey {
+			if err := checkKey(dep.Key); err != nil {
+				return fmt.Errorf("dependency '%v': %w", dep.ServiceType, err)
+			}
+		}
+	}
+	deps = append([]Dependency(nil), deps...)
+	return c.AddSingletonWithInit(serviceType, instance, func(instance any, resolver Resolver) {
+		resolved := make([]any, len(deps))
+		for i, dep := range deps {
+			var val reflect.Value
+			if !dep.HasKey {
+				val = resolver.Resolve(dep.ServiceType)
+			} else if container, ok := resolver.(Container); ok {
+				// resolver of init callback is the container
+				val = container.(KeyedContainer).ResolveKeyed(dep.Key, dep.ServiceType)
+			}
+			if val.IsValid() {
+				resolved[i] = val.Interface()
+			}
+		}
+		construct(instance, resolved)
+	})
+}
@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestAddSingletonWithDeps(t *testing.T) {
+	t.Run("deps should be resolved in order", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		svc7 := &serviceInstance7{name: "instance7"}
+		AddSingleton[service1](svc1)
+		AddSingleton[*serviceInstance7](svc7)
+		var got []any
+		AddSingletonWithDeps[*depsClient](&depsClient{}, []Dependency{DependencyOf[service1](), DependencyOf[*serviceInstance7](), DependencyOf[service1](), DependencyOf[service2]()},
+			func(instance *depsClient, resolved []any) {
+				instance.constructed++
+				got = resolved
+			})
+
+		client := GetService[*depsClient]()
+		_ = GetService[*depsClient]()
+		if client.constructed != 1 {
+			t.Error("construct should be invoked once")
+			return
+		}
+		if len(got) != 4 || got[0] != svc1 || got[1] != svc7 || got[2] != svc1 || got[3] != nil {
+			t.Errorf("deps should be resolved in order, and missing one should be nil, but %v", got)
+			return
+		}
+	})
+
+	t.Run("keyed deps should be resolved by key", func(t *testing.T) {
+		globalContainer = New()
+		primary := &serviceInstance1{name: "primary"}
+		replica := &serviceInstance1{name: "replica"}
+		AddKeyed[service1]("primary", primary)
+		AddKeyed[service1]("replica", replica)
+		var got []any
+		AddSingletonWithDeps[*depsClient](&depsClient{}, []Dependency{KeyedDependencyOf[service1]("replica"), KeyedDependencyOf[service1]("primary"), KeyedDependencyOf[service1]("none"), DependencyOf[service1]()},
+			func(instance *depsClient, resolved []any) {
+				got = resolved
+			})
+
+		_ = GetService[*depsClient]()
+		if len(got) != 4 || got[0] != replica || got[1] != primary || got[2] != nil || got[3] != nil {
+			t.Errorf("keyed deps should be resolved by key, but %v", got)
+			return
+		}
+	})
+
+	t.Run("invalid deps should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(DepsAdder).AddSingletonWithDeps(TypeOf[*depsClient](), &depsClient{}, []Dependency{{}}, func(instance any, resolved []any) {}); err == nil {
+			t.Error("null type in deps should fail")
+			return
+		}
+		if err := globalContainer.(DepsAdder).AddSingletonWithDeps(TypeOf[*depsClient](), &depsClient{}, []Dependency{KeyedDependencyOf[service1]([]string{})}, func(instance any, resolved []any) {}); err == nil {
+			t.Error("key not comparable in deps should fail")
+			return
+		}
+		if err := globalContainer.(DepsAdder).AddSingletonWithDeps(TypeOf[*depsClient](), &depsClient{}, nil, nil); err == nil {
+			t.Error("null construct should fail")
+			return
+		}
+	})
+}
+
+type depsClient struct {
+	constructed int
+}
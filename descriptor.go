@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// ServiceDescriptor is the read-only description of binding.
+type ServiceDescriptor struct {
+	ServiceType        reflect.Type
+	ImplementationType reflect.Type // type of singleton instance, nil if not singleton
+	Lifetime           Lifetime
+	Key                any // key of keyed binding
+	HasKey             bool
+	Order              int
+	RegisteredAt       string // registration site as "file:line"
+	Initialized        bool   // whether singleton is initialized
+}
+
+// Ranger is implemented by container to iterate bindings with read-only descriptors.
+type Ranger interface {
+	// Range to iterate bindings of current container, includes keyed ones, with read-only descriptors, and stops if 'fn' returns false.
+	// Bindings resolvable by type are the same as resolving, that is the ones in disabled features are skipped, and the ones of active profiles are included.
+	// It won't initialize singletons. Registering during iteration follows the consistency rules of 'sync.Map.Range'.
+	//
+	//  container.(ioc.Ranger).Range(func(serviceType reflect.Type, descriptor ioc.ServiceDescriptor) bool {
+	//      log.Printf("%v: %v", serviceType, descriptor.Lifetime)
+	//      return true
+	//  })
+	Range(fn func(serviceType reflect.Type, descriptor ServiceDescriptor) bool)
+}
+
+var _ Ranger = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Range(fn func(serviceType reflect.Type, descriptor ServiceDescriptor) bool) {
+	if fn == nil {
+		return
+	}
+	proceed := true
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		if binding.ServiceType == resolverType {
+			return true
+		}
+		proceed = fn(binding.ServiceType, binding.descriptor())
+		return proceed
+	})
+	if !proceed {
+		return
+	}
+	c.keyedBindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		descriptor := binding.descriptor()
+		descriptor.Key = key.(bindingKey).Key
+		descriptor.HasKey = true
+		return fn(binding.ServiceType, descriptor)
+	})
+}
+
+// descriptor of binding without key.
+func (b *serviceBinding) descriptor() ServiceDescriptor {
+	descriptor := ServiceDescriptor{
+		ServiceType:  b.ServiceType,
+		Lifetime:     b.lifetime(),
+		Order:        b.Order,
+		RegisteredAt: b.RegisteredAt,
+	}
+	if target := b.implementation(); target.Instance.IsValid() {
+		descriptor.ImplementationType = target.Instance.Type()
+		instance, ok := target.InitializedInstance.Load().(reflect.Value)
+		descriptor.Initialized = ok && instance.IsValid()
+	}
+	return descriptor
+}
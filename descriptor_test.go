@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRange(t *testing.T) {
+	t.Run("range bindings should not initialize singletons", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		svc8 := &serviceInstance8{}
+		AddSingleton[*serviceInstance8](svc8)
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton("abc", reflect.TypeOf((*service1)(nil)).Elem(), &serviceInstance1{})
+		_ = GetService[*serviceInstance7]()
+
+		descriptors := make(map[reflect.Type][]ServiceDescriptor)
+		globalContainer.(Ranger).Range(func(serviceType reflect.Type, descriptor ServiceDescriptor) bool {
+			descriptors[serviceType] = append(descriptors[serviceType], descriptor)
+			return true
+		})
+		if len(descriptors) != 3 {
+			t.Errorf("3 service types should be iterated, but %v", len(descriptors))
+			return
+		}
+		if d := descriptors[reflect.TypeOf(svc8)][0]; d.Initialized || d.Lifetime != LifetimeSingleton || d.ImplementationType != reflect.TypeOf(svc8) || d.RegisteredAt == "" {
+			t.Error("descriptor of singleton not initialized is unexpected")
+			return
+		}
+		if svc8.s7 != nil {
+			t.Error("singleton should not be initialized by range")
+			return
+		}
+		if d := descriptors[reflect.TypeOf((*serviceInstance7)(nil))][0]; !d.Initialized {
+			t.Error("singleton resolved should be initialized")
+			return
+		}
+		if ds := descriptors[reflect.TypeOf((*service1)(nil)).Elem()]; len(ds) != 2 {
+			t.Error("keyed binding should be iterated")
+			return
+		}
+
+		count := 0
+		globalContainer.(Ranger).Range(func(serviceType reflect.Type, descriptor ServiceDescriptor) bool {
+			count++
+			return false
+		})
+		if count != 1 {
+			t.Error("range should stop if returns false")
+			return
+		}
+	})
+}
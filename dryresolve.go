@@ -0,0 +1,184 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ResolvePlan is the plan of how a service would be resolved, see 'DryResolver.DryResolve'.
+// It's a tree mirroring the dependency graph, and dependencies are fields and then params of initializer.
+type ResolvePlan struct {
+	Name         string       // field name, or param index of initializer such as "#0", empty for the root
+	ServiceType  reflect.Type // type of service
+	Resolved     bool         // whether it would be resolved
+	From         Resolver     // the container in parent chain which would resolve it, nil if not resolved
+	Lifetime     Lifetime     // lifetime of the binding, unknown if not resolved or not resolved by 'ioc.Container'
+	Cycle        bool         // whether it's truncated for referring to a service in it's path
+	Dependencies []ResolvePlan
+}
+
+func (p ResolvePlan) String() string {
+	var sb strings.Builder
+	p.writeTo(&sb, 0)
+	return sb.String()
+}
+
+// writeTo to write the plan and it's dependencies, indented by depth.
+func (p ResolvePlan) writeTo(sb *strings.Builder, depth int) {
+	sb.WriteString(strings.Repeat("  ", depth))
+	if p.Name != "" {
+		sb.WriteString(p.Name + " ")
+	}
+	switch {
+	case p.Cycle:
+		sb.WriteString(fmt.Sprintf("%v: cycle\n", p.ServiceType))
+	case p.Resolved:
+		sb.WriteString(fmt.Sprintf("%v: resolved, %v\n", p.ServiceType, p.Lifetime))
+	default:
+		sb.WriteString(fmt.Sprintf("%v: not resolved\n", p.ServiceType))
+	}
+	for _, dep := range p.Dependencies {
+		dep.writeTo(sb, depth+1)
+	}
+}
+
+// DryResolver is implemented by container to get the plan of resolving without instantiating.
+type DryResolver interface {
+	// DryResolve to get the plan of how service would be resolved from current container, which container and lifetime,
+	// and which dependencies would be injected and from where, without invoking any factory or initializer.
+	// Dependencies of instance created by factory are unknown, and dependency referring to a service in it's path is
+	// truncated as cycle. It returns error if service not found, with the plan not resolved.
+	//
+	//  plan, err := container.(ioc.DryResolver).DryResolve(reflect.TypeOf((*OrderService)(nil)))
+	//  fmt.Print(plan)
+	DryResolve(serviceType reflect.Type) (ResolvePlan, error)
+}
+
+var _ DryResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) DryResolve(serviceType reflect.Type) (ResolvePlan, error) {
+	if serviceType == nil {
+		return ResolvePlan{}, errors.New("param 'serviceType' is null")
+	}
+	plan := ResolvePlan{ServiceType: serviceType}
+	from, binding := locateBinding(c, func(dc *defaultContainer) *serviceBinding { return dc.getBinding(serviceType) })
+	plan = c.plan(plan, from, binding, nil)
+	if !plan.Resolved {
+		return plan, fmt.Errorf("service '%v' not found", serviceType)
+	}
+	return plan, nil
+}
+
+// plan to fill the plan with binding found in 'from', and plan it's dependencies with services from current container.
+// 'path' is the bindings from the root to the plan, to truncate cycle.
+func (c *defaultContainer) plan(plan ResolvePlan, from Resolver, binding *serviceBinding, path []*serviceBinding) ResolvePlan {
+	if from == nil {
+		return plan
+	}
+	plan.Resolved = true
+	plan.From = from
+	if binding == nil {
+		plan.Lifetime = LifetimeUnknown
+		return plan
+	}
+	plan.Lifetime = binding.lifetime()
+	binding = binding.implementation()
+	for _, b := range path {
+		if b == binding {
+			plan.Cycle = true
+			return plan
+		}
+	}
+	if !binding.Instance.IsValid() {
+		// dependencies of instance created by factory are unknown without invoking it
+		return plan
+	}
+	path = append(path[:len(path):len(path)], binding)
+	for _, field := range getFieldsToInject(binding.Instance.Type()) {
+		if !field.HasConfig {
+			plan.Dependencies = append(plan.Dependencies, c.planField(field, path))
+		}
+	}
+	if binding.InstanceInitializer.IsValid() {
+		methodType := binding.InstanceInitializer.Type()
+		for i := 0; i < methodType.NumIn(); i++ {
+			paramType := methodType.In(i)
+			param := ResolvePlan{Name: fmt.Sprintf("#%d", i), ServiceType: paramType}
+			from, b := locateBinding(c, func(dc *defaultContainer) *serviceBinding { return dc.getBinding(paramType) })
+			plan.Dependencies = append(plan.Dependencies, c.plan(param, from, b, path))
+		}
+	}
+	return plan
+}
+
+// planField to plan the field with the same rule as 'resolveField'.
+func (c *defaultContainer) planField(field structField, path []*serviceBinding) ResolvePlan {
+	plan := ResolvePlan{Name: field.FieldName, ServiceType: field.FieldType}
+	var resolver Resolver = c
+	if field.FromParent {
+		if resolver = c.Parent(); resolver == nil {
+			return plan
+		}
+	}
+	serviceType := field.FieldType
+	switch {
+	case field.OptionalOf != nil:
+		serviceType = field.OptionalOf
+	case field.ProviderOf != nil:
+		serviceType = field.ProviderOf
+	case serviceType.Kind() == reflect.Slice, serviceType.Kind() == reflect.Map:
+		return c.plan(plan, resolver, nil, path)
+	}
+	var from Resolver
+	var binding *serviceBinding
+	if field.HasKey || c.isFieldNameAsKey() {
+		key := field.Key
+		if !field.HasKey {
+			key = field.FieldName
+		}
+		from, binding = locateBinding(resolver, func(dc *defaultContainer) *serviceBinding { return dc.getKeyedBinding(key, serviceType) })
+	}
+	if from == nil && !field.HasKey {
+		from, binding = locateBinding(resolver, func(dc *defaultContainer) *serviceBinding { return dc.getBinding(serviceType) })
+	}
+	return c.plan(plan, from, binding, path)
+}
+
+// locateBinding to find the container in parent chain which has the binding got by 'get'.
+// Resolver which is not created by 'ioc.New' is returned with nil binding if it's reached.
+func locateBinding(resolver Resolver, get func(c *defaultContainer) *serviceBinding) (Resolver, *serviceBinding) {
+	for resolver != nil {
+		c, ok := resolver.(*defaultContainer)
+		if !ok {
+			return resolver, nil
+		}
+		if binding := get(c); binding != nil {
+			return c, binding
+		}
+		resolver = c.Parent()
+	}
+	return nil, nil
+}
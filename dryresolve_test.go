@@ -0,0 +1,114 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+type plannedService struct {
+	S1          service1     `ioc-inject:"true"`
+	S3          service3     `ioc-inject:"true"`
+	Repo        *plannedRepo `ioc-inject:"true"`
+	Missing     service4     `ioc-inject:"optional"`
+	initialized int
+}
+
+func (s *plannedService) Initialize(s2 service2) {
+	s.initialized++
+}
+
+type plannedRepo struct {
+	Svc *plannedService `ioc-inject:"true"`
+}
+
+func TestDryResolve(t *testing.T) {
+	t.Run("plan should mirror dependency graph without side effects", func(t *testing.T) {
+		globalContainer = New()
+		parent := globalContainer
+		AddSingleton[service1](&serviceInstance1{name: "parent"})
+		globalContainer = New()
+		globalContainer.SetParent(parent)
+		factoryCalls := 0
+		AddTransient[service2](func() service2 {
+			factoryCalls++
+			return &serviceInstance2{}
+		})
+		AddSingleton[service3](&serviceInstance2{})
+		instance := &plannedService{}
+		AddSingleton[*plannedService](instance)
+		AddSingleton[*plannedRepo](&plannedRepo{})
+
+		plan, err := globalContainer.(DryResolver).DryResolve(TypeOf[*plannedService]())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if instance.initialized != 0 || factoryCalls != 0 {
+			t.Error("dry resolving should not invoke initializer or factory")
+			return
+		}
+		if !plan.Resolved || plan.From != globalContainer || plan.Lifetime != LifetimeSingleton || len(plan.Dependencies) != 5 {
+			t.Errorf("root should be singleton with 5 dependencies, but\n%v", plan)
+			return
+		}
+		deps := plan.Dependencies
+		if deps[0].Name != "S1" || deps[0].From != parent || deps[0].Lifetime != LifetimeSingleton {
+			t.Errorf("field 'S1' should be from parent, but\n%v", plan)
+			return
+		}
+		if deps[1].Name != "S3" || deps[1].From != globalContainer || !deps[1].Resolved {
+			t.Errorf("field 'S3' should be from current, but\n%v", plan)
+			return
+		}
+		if deps[2].Name != "Repo" || len(deps[2].Dependencies) != 1 || !deps[2].Dependencies[0].Cycle {
+			t.Errorf("field 'Repo' should refer to root as cycle, but\n%v", plan)
+			return
+		}
+		if deps[3].Name != "Missing" || deps[3].Resolved {
+			t.Errorf("field 'Missing' should not be resolved, but\n%v", plan)
+			return
+		}
+		if deps[4].Name != "#0" || deps[4].Lifetime != LifetimeTransient || len(deps[4].Dependencies) != 0 {
+			t.Errorf("param of initializer should be transient, but\n%v", plan)
+			return
+		}
+		if !strings.Contains(plan.String(), "  Repo *ioc.plannedRepo: resolved, Singleton\n    Svc *ioc.plannedService: cycle\n") {
+			t.Errorf("plan should be printed as tree, but\n%v", plan)
+			return
+		}
+	})
+
+	t.Run("service not found should return error", func(t *testing.T) {
+		globalContainer = New()
+		plan, err := globalContainer.(DryResolver).DryResolve(TypeOf[service1]())
+		if err == nil || plan.Resolved {
+			t.Error("should return error if service not found")
+			return
+		}
+		if _, err = globalContainer.(DryResolver).DryResolve(nil); err == nil {
+			t.Error("should return error if service type is null")
+			return
+		}
+	})
+}
@@ -0,0 +1,110 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DuplicatePolicy controls what adding service does when the service already exists in current container.
+type DuplicatePolicy int32
+
+const (
+	// DuplicateIgnore to ignore the new one and keep the existing service, it's the default policy.
+	DuplicateIgnore DuplicatePolicy = iota
+	// DuplicateError to return error naming the service and both registration sites.
+	DuplicateError
+	// DuplicateReplace to replace the existing service with the new one.
+	DuplicateReplace
+)
+
+// DuplicatePolicySetter is implemented by container to control adding service which already exists.
+type DuplicatePolicySetter interface {
+	// SetDuplicatePolicy to control what adding service does when the service already exists in current container, default is 'DuplicateIgnore'.
+	//
+	//  // catch accidental double-registration during development
+	//  container.(ioc.DuplicatePolicySetter).SetDuplicatePolicy(ioc.DuplicateError)
+	SetDuplicatePolicy(policy DuplicatePolicy)
+}
+
+var _ DuplicatePolicySetter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetDuplicatePolicy(policy DuplicatePolicy) {
+	atomic.StoreInt32(&c.duplicate, int32(policy))
+}
+
+func (c *defaultContainer) getDuplicatePolicy() DuplicatePolicy {
+	return DuplicatePolicy(atomic.LoadInt32(&c.duplicate))
+}
+
+// storeBinding to store binding by the duplicate policy, returns whether the binding is stored.
+func (c *defaultContainer) storeBinding(bindings *sync.Map, key any, binding *serviceBinding) (bool, error) {
+	binding.RegisteredAt = registrationSite()
+	binding.Seq = atomic.AddUint64(&c.seq, 1)
+	defer c.bindingsChanged()
+	switch c.getDuplicatePolicy() {
+	case DuplicateReplace:
+		if existing, ok := bindings.Load(key); ok && existing.(*serviceBinding).Primary {
+			// keep primary
+			return false, nil
+		}
+		bindings.Store(key, binding)
+		return true, nil
+	case DuplicateError:
+		if existing, loaded := bindings.LoadOrStore(key, binding); loaded {
+			return false, fmt.Errorf("duplicate service '%v': registered at '%s', and again at '%s'",
+				binding.ServiceType, existing.(*serviceBinding).RegisteredAt, binding.RegisteredAt)
+		}
+		return true, nil
+	default:
+		_, loaded := bindings.LoadOrStore(key, binding)
+		return !loaded, nil
+	}
+}
+
+var packageDir string
+
+func init() {
+	if _, file, _, ok := runtime.Caller(0); ok {
+		packageDir = filepath.Dir(file)
+	}
+}
+
+// registrationSite to get the first caller outside this package as "file:line".
+func registrationSite() string {
+	var pcs [16]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return fmt.Sprintf("%s:%d", frame.File, frame.Line)
+		}
+		if !more {
+			return "unknown"
+		}
+	}
+}
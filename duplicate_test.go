@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSetDuplicatePolicy(t *testing.T) {
+	serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+
+	t.Run("ignore duplicate by default", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		if err := globalContainer.AddSingleton(serviceType, svc1); err != nil {
+			t.Errorf("add singleton fail: %v", err)
+			return
+		}
+		if err := globalContainer.AddSingleton(serviceType, &serviceInstance1{name: "new"}); err != nil {
+			t.Error("duplicate should be ignored")
+			return
+		}
+		if GetService[service1]() != svc1 {
+			t.Error("existing service should be kept")
+			return
+		}
+	})
+
+	t.Run("error on duplicate", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateError)
+		svc1 := &serviceInstance1{name: "instance1"}
+		if err := globalContainer.AddSingleton(serviceType, svc1); err != nil {
+			t.Errorf("add singleton fail: %v", err)
+			return
+		}
+		err := globalContainer.AddTransient(serviceType, func() any { return &serviceInstance1{name: "new"} })
+		if err == nil {
+			t.Error("duplicate should fail")
+			return
+		}
+		if !strings.Contains(err.Error(), "service1") || strings.Count(err.Error(), "duplicate_test.go") != 2 {
+			t.Errorf("error should name the service and both registration sites, but got '%v'", err)
+			return
+		}
+		if GetService[service1]() != svc1 {
+			t.Error("existing service should be kept")
+			return
+		}
+		if err = globalContainer.(KeyedContainer).AddKeyedSingleton("key", serviceType, svc1); err != nil {
+			t.Errorf("add keyed singleton fail: %v", err)
+			return
+		}
+		if err = globalContainer.(KeyedContainer).AddKeyedSingleton("key", serviceType, svc1); err == nil {
+			t.Error("duplicate keyed service should fail")
+			return
+		}
+	})
+
+	t.Run("replace on duplicate", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateReplace)
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		svc1 := &serviceInstance1{name: "new"}
+		AddSingleton[service1](svc1)
+		if GetService[service1]() != svc1 {
+			t.Error("existing service should be replaced")
+			return
+		}
+		AddTransient[service1](func() service1 { return &serviceInstance1{name: "transient"} })
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "transient" {
+			t.Error("existing service should be replaced")
+			return
+		}
+	})
+}
@@ -0,0 +1,101 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+)
+
+// AddElementFactory to add transient factory of element 'TElem' of collection to global container, see 'ElementFactoryAdder.AddElementFactory'.
+//
+// It will panic if 'TElem' or 'instanceFactory' is invalid.
+//
+//	ioc.AddElementFactory[Handler](func() Handler { return &requestLogger{} })
+//	ioc.AddElementFactory[Handler](func() Handler { return &rateLimiter{} })
+func AddElementFactory[TElem any](instanceFactory func() TElem) {
+	AddElementFactoryToC[TElem](globalContainer, instanceFactory)
+}
+
+// AddElementFactoryToC to add transient factory of element 'TElem' of collection to container, see 'ElementFactoryAdder.AddElementFactory'.
+//
+// It will panic if 'TElem' or 'instanceFactory' is invalid.
+func AddElementFactoryToC[TElem any](container Container, instanceFactory func() TElem) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	err := container.(ElementFactoryAdder).AddElementFactory(TypeOf[TElem](), func() any {
+		return instanceFactory()
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ElementFactoryAdder is implemented by container to add factories of elements of collections.
+type ElementFactoryAdder interface {
+	// AddElementFactory to add transient factory of element of collection, which can be added multiple times for the same type,
+	// and each one creates a new element for each 'ResolveAll' and injecting to field of slice, such as '[]Handler'.
+	// Elements are collected with other registrations of the type in registration order, in which singletons are cached,
+	// and they are not resolved by 'Resolve' as a single service.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ElementFactoryAdder).AddElementFactory(reflect.TypeOf((*Handler)(nil)).Elem(), func() any { return &requestLogger{} })
+	AddElementFactory(elemType reflect.Type, instanceFactory func() any) error
+}
+
+var _ ElementFactoryAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddElementFactory(elemType reflect.Type, instanceFactory func() any) error {
+	if elemType == nil {
+		return errors.New("param 'elemType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	return c.addElement(&serviceBinding{ServiceType: elemType, InstanceFactory: instanceFactory})
+}
+
+// addElement to add binding as element of collection of it's service type.
+func (c *defaultContainer) addElement(binding *serviceBinding) error {
+	if err := validateBinding(binding); err != nil {
+		return err
+	}
+	binding.RegisteredAt = registrationSite()
+	binding.Seq = atomic.AddUint64(&c.seq, 1)
+
+	elemType := binding.ServiceType
+	c.locker.Lock()
+	var elements []*serviceBinding
+	if val, ok := c.elements.Load(elemType); ok {
+		elements = val.([]*serviceBinding)
+	}
+	// copy on write, so elements can be read without lock
+	newElements := make([]*serviceBinding, 0, len(elements)+1)
+	newElements = append(newElements, elements...)
+	newElements = append(newElements, binding)
+	c.elements.Store(elemType, newElements)
+	c.locker.Unlock()
+	c.bindingsChanged()
+	return nil
+}
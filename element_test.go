@@ -0,0 +1,81 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+type elementsClient struct {
+	Handlers []service1 `ioc-inject:"true"`
+}
+
+func TestAddElementFactory(t *testing.T) {
+	t.Run("elements should be created fresh for each injection", func(t *testing.T) {
+		globalContainer = New()
+		AddElementFactory[service1](func() service1 { return &serviceInstance1{name: "logger"} })
+		AddElementFactory[service1](func() service1 { return &serviceInstance1{name: "limiter"} })
+		first, second := &elementsClient{}, &elementsClient{}
+		Inject(first)
+		Inject(second)
+		if len(first.Handlers) != 2 || first.Handlers[0].GetName() != "logger" || first.Handlers[1].GetName() != "limiter" {
+			t.Errorf("each factory should create an element in registration order, but %v", first.Handlers)
+			return
+		}
+		if len(second.Handlers) != 2 || first.Handlers[0] == second.Handlers[0] || first.Handlers[1] == second.Handlers[1] {
+			t.Error("elements should be fresh for each injection")
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("element should not be resolved as a single service")
+			return
+		}
+	})
+
+	t.Run("singletons should be cached in mixed collection", func(t *testing.T) {
+		globalContainer = New()
+		shared := &serviceInstance1{name: "shared"}
+		AddSingleton[service1](shared)
+		AddElementFactory[service1](func() service1 { return &serviceInstance1{name: "fresh"} })
+		first, second := ResolveAll[service1](), ResolveAll[service1]()
+		if len(first) != 2 || first[0] != service1(shared) || second[0] != service1(shared) {
+			t.Error("singleton should be cached in collection")
+			return
+		}
+		if first[1].GetName() != "fresh" || first[1] == second[1] {
+			t.Error("element should be fresh in collection")
+			return
+		}
+	})
+
+	t.Run("invalid params should be rejected", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(ElementFactoryAdder).AddElementFactory(TypeOf[service1](), nil); err == nil {
+			t.Error("null factory should be rejected")
+			return
+		}
+		if err := globalContainer.(ElementFactoryAdder).AddElementFactory(TypeOf[string](), func() any { return "" }); err == nil {
+			t.Error("element type which is not interface or *struct should be rejected")
+			return
+		}
+	})
+}
@@ -0,0 +1,135 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AddHandler to add handler of event 'E' to global container, see 'HandlerRegistry.AddHandler'.
+//
+// It will panic if 'handler' is invalid.
+func AddHandler[E any](handler any) {
+	AddHandlerToC[E](globalContainer, handler)
+}
+
+// AddHandlerToC to add handler of event 'E' to container, see 'HandlerRegistry.AddHandler'.
+//
+// It will panic if 'handler' is invalid.
+func AddHandlerToC[E any](container Container, handler any) {
+	if err := container.(HandlerRegistry).AddHandler(TypeOf[E](), handler); err != nil {
+		panic(err)
+	}
+}
+
+// Publish to invoke handlers of event 'E' in global container with the event, see 'PublishToC'.
+func Publish[E any](event E) error {
+	return PublishToC[E](globalContainer, event)
+}
+
+// PublishToC to invoke handlers of event 'E' in container with the event in the order of 'HandlerRegistry.ResolveHandlers',
+// and params after event are resolved from container. It won't stop on error, but invoke all and returns the aggregated errors.
+//
+//	err := ioc.PublishToC(container, OrderCreated{Customer: "jerry"})
+func PublishToC[E any](container Container, event E) error {
+	eventType := TypeOf[E]()
+	eventVal := reflect.New(eventType).Elem()
+	if val := reflect.ValueOf(event); val.IsValid() {
+		eventVal.Set(val)
+	}
+	var errs []error
+	for i, handler := range container.(HandlerRegistry).ResolveHandlers(eventType) {
+		handlerType := handler.Type()
+		in := make([]reflect.Value, handlerType.NumIn())
+		in[0] = eventVal
+		for j := 1; j < handlerType.NumIn(); j++ {
+			if in[j] = container.Resolve(handlerType.In(j)); !in[j].IsValid() {
+				in[j] = reflect.Zero(handlerType.In(j))
+			}
+		}
+		outs := handler.Call(in)
+		if len(outs) == 1 && !outs[0].IsNil() {
+			errs = append(errs, fmt.Errorf("handler[%d] of event '%v' fail: %w", i, eventType, outs[0].Interface().(error)))
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// HandlerRegistry is implemented by container to add handlers of events and resolve them.
+type HandlerRegistry interface {
+	// AddHandler to add handler of event, which is 'func(E)' or 'func(E, deps...)' with params after event resolved
+	// from container when publishing, and it can return nothing or error. See 'ioc.Publish'.
+	//
+	//  err := container.(ioc.HandlerRegistry).AddHandler(reflect.TypeOf(OrderCreated{}), func(e OrderCreated, mailer Mailer) error {
+	//      return mailer.Send(e.Customer, "order created")
+	//  })
+	AddHandler(eventType reflect.Type, handler any) error
+
+	// ResolveHandlers to get handlers of event from parent chain and current container, ancestors' first,
+	// and then in registration order.
+	ResolveHandlers(eventType reflect.Type) []reflect.Value
+}
+
+var _ HandlerRegistry = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddHandler(eventType reflect.Type, handler any) error {
+	if eventType == nil {
+		return errors.New("param 'eventType' is null")
+	}
+	if handler == nil {
+		return errors.New("param 'handler' is null")
+	}
+	handlerVal := reflect.ValueOf(handler)
+	if handlerVal.Kind() != reflect.Func || handlerVal.IsNil() {
+		return fmt.Errorf("param 'handler' should be func, but '%T'", handler)
+	}
+	handlerType := handlerVal.Type()
+	if handlerType.NumIn() == 0 || handlerType.In(0) != eventType || handlerType.IsVariadic() {
+		return fmt.Errorf("handler '%v' should be 'func(%v)' or 'func(%v, deps...)'", handlerType, eventType, eventType)
+	}
+	if handlerType.NumOut() > 1 || handlerType.NumOut() == 1 && handlerType.Out(0) != errorType {
+		return fmt.Errorf("handler '%v' should return nothing or error", handlerType)
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if c.handlers == nil {
+		c.handlers = make(map[reflect.Type][]reflect.Value)
+	}
+	c.handlers[eventType] = append(c.handlers[eventType], handlerVal)
+	return nil
+}
+
+func (c *defaultContainer) ResolveHandlers(eventType reflect.Type) []reflect.Value {
+	if eventType == nil {
+		return nil
+	}
+	var handlers []reflect.Value
+	if parent, ok := c.parent.(HandlerRegistry); ok {
+		handlers = parent.ResolveHandlers(eventType)
+	}
+	c.locker.Lock()
+	handlers = append(handlers, c.handlers[eventType]...)
+	c.locker.Unlock()
+	return handlers
+}
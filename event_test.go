@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type orderCreated struct {
+	ID string
+}
+
+func TestPublish(t *testing.T) {
+	t.Run("event should be published to handlers in order with deps injected", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		var log []string
+		AddHandlerToC[orderCreated](parent, func(e orderCreated) { log = append(log, "parent:"+e.ID) })
+		globalContainer.SetParent(parent)
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddHandler[orderCreated](func(e orderCreated, svc service1) {
+			log = append(log, svc.GetName()+":"+e.ID)
+		})
+		AddHandler[orderCreated](func(e orderCreated, svc service2) error {
+			if svc != nil {
+				t.Error("missing dependency should be zero value")
+			}
+			log = append(log, "missing:"+e.ID)
+			return nil
+		})
+		if err := Publish(orderCreated{ID: "1"}); err != nil {
+			t.Error(err)
+			return
+		}
+		if expected := []string{"parent:1", "instance1:1", "missing:1"}; !reflect.DeepEqual(log, expected) {
+			t.Errorf("expected %v, but got %v", expected, log)
+			return
+		}
+		if len(globalContainer.(HandlerRegistry).ResolveHandlers(TypeOf[orderCreated]())) != 3 || len(parent.(HandlerRegistry).ResolveHandlers(TypeOf[orderCreated]())) != 1 {
+			t.Error("handlers should be resolved from parent chain")
+			return
+		}
+	})
+
+	t.Run("errors of handlers should be aggregated", func(t *testing.T) {
+		globalContainer = New()
+		handlerErr := errors.New("handle fail")
+		called := false
+		AddHandler[orderCreated](func(e orderCreated) error { return handlerErr })
+		AddHandler[orderCreated](func(e orderCreated) { called = true })
+		if err := Publish(orderCreated{ID: "1"}); !errors.Is(err, handlerErr) || !called {
+			t.Errorf("errors of handlers should be aggregated, but got '%v'", err)
+			return
+		}
+		if err := Publish("no handlers"); err != nil {
+			t.Error("event without handlers should do nothing")
+			return
+		}
+	})
+
+	t.Run("invalid handler should fail", func(t *testing.T) {
+		globalContainer = New()
+		eventType := TypeOf[orderCreated]()
+		for _, handler := range []any{nil, "handler", func() {}, func(e string) {}, func(e orderCreated) string { return "" },
+			func(e orderCreated, deps ...service1) {}} {
+			if err := globalContainer.(HandlerRegistry).AddHandler(eventType, handler); err == nil {
+				t.Errorf("invalid handler '%T' should fail", handler)
+				return
+			}
+		}
+	})
+}
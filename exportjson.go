@@ -0,0 +1,122 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// registrationJSON is the machine-readable description of binding, see 'JSONExporter.ExportJSON'.
+type registrationJSON struct {
+	Type         string   `json:"type"`
+	Package      string   `json:"package"`
+	Lifetime     string   `json:"lifetime"`
+	Key          string   `json:"key,omitempty"`
+	HasKey       bool     `json:"hasKey,omitempty"`
+	Primary      bool     `json:"primary,omitempty"`
+	Initialized  bool     `json:"initialized"`
+	Initializer  string   `json:"initializer,omitempty"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// JSONExporter is implemented by container to describe services as JSON for tooling.
+type JSONExporter interface {
+	// ExportJSON to describe services in current container as JSON for tooling, such as IDE plugins and architecture linters.
+	// Each registration has type name, package path, lifetime, key, whether initialized, initializer method name,
+	// and type names of injectable dependencies, sorted by type name and then key. Nothing is instantiated.
+	//
+	//  data, err := container.(ioc.JSONExporter).ExportJSON()
+	//  // {"registrations":[{"type":"*app.Service","package":"example.com/app","lifetime":"Singleton",...}]}
+	ExportJSON() ([]byte, error)
+}
+
+var _ JSONExporter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ExportJSON() ([]byte, error) {
+	registrations := make([]registrationJSON, 0)
+	keyed := make(map[*serviceBinding]bool)
+	c.keyedBindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		keyed[binding] = true
+		reg := binding.exportJSON()
+		reg.Key = fmt.Sprintf("%v", key.(bindingKey).Key)
+		reg.HasKey = true
+		registrations = append(registrations, reg)
+		return true
+	})
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		if binding.ServiceType == resolverType || keyed[binding] {
+			return true
+		}
+		registrations = append(registrations, binding.exportJSON())
+		return true
+	})
+	sort.SliceStable(registrations, func(i, j int) bool {
+		if registrations[i].Type != registrations[j].Type {
+			return registrations[i].Type < registrations[j].Type
+		}
+		if registrations[i].HasKey != registrations[j].HasKey {
+			return !registrations[i].HasKey
+		}
+		return registrations[i].Key < registrations[j].Key
+	})
+	return json.Marshal(struct {
+		Registrations []registrationJSON `json:"registrations"`
+	}{registrations})
+}
+
+// exportJSON to describe binding without initializing it, dependencies are the same as walked by dependency cycle detection.
+func (b *serviceBinding) exportJSON() registrationJSON {
+	descriptor := b.descriptor()
+	reg := registrationJSON{
+		Type:         b.ServiceType.String(),
+		Package:      pkgPathOf(b.ServiceType),
+		Lifetime:     descriptor.Lifetime.String(),
+		Primary:      b.Primary,
+		Initialized:  descriptor.Initialized,
+		Dependencies: make([]string, 0),
+	}
+	target := b.implementation()
+	if target.InstanceInitializer.IsValid() {
+		reg.Initializer = target.InstanceInitializerName
+	}
+	for _, dep := range target.dependencies() {
+		reg.Dependencies = append(reg.Dependencies, dep.String())
+	}
+	return reg
+}
+
+// pkgPathOf to get package path of type, or the one of element type for unnamed pointer, slice and so on.
+func pkgPathOf(t reflect.Type) string {
+	for t.Name() == "" {
+		switch t.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+			t = t.Elem()
+		default:
+			return ""
+		}
+	}
+	return t.PkgPath()
+}
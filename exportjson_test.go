@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type exportedClient struct {
+	Svc1 service1 `ioc-inject:"true"`
+}
+
+func (c *exportedClient) Initialize(svc2 service2) {}
+
+func TestExportJSON(t *testing.T) {
+	t.Run("registrations should be described in sorted order", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*exportedClient](&exportedClient{})
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		AddKeyed[service2]("b", &serviceInstance2{})
+		AddKeyed[service2]("a", &serviceInstance2{})
+		data, err := globalContainer.(JSONExporter).ExportJSON()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		var actual map[string]any
+		if err = json.Unmarshal(data, &actual); err != nil {
+			t.Error(err)
+			return
+		}
+		expected := map[string]any{"registrations": []any{
+			map[string]any{"type": "*ioc.exportedClient", "package": "gopkg.berkaroad.top/ioc", "lifetime": "Singleton",
+				"initialized": false, "initializer": "Initialize", "dependencies": []any{"ioc.service1", "ioc.service2"}},
+			map[string]any{"type": "ioc.service1", "package": "gopkg.berkaroad.top/ioc", "lifetime": "Transient",
+				"initialized": false, "dependencies": []any{}},
+			map[string]any{"type": "ioc.service2", "package": "gopkg.berkaroad.top/ioc", "lifetime": "Singleton",
+				"key": "a", "hasKey": true, "initialized": false, "dependencies": []any{}},
+			map[string]any{"type": "ioc.service2", "package": "gopkg.berkaroad.top/ioc", "lifetime": "Singleton",
+				"key": "b", "hasKey": true, "initialized": false, "dependencies": []any{}},
+		}}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("unexpected JSON: %s", data)
+			return
+		}
+	})
+
+	t.Run("exporting should not initialize singletons", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*exportedClient](&exportedClient{})
+		if _, err := globalContainer.(JSONExporter).ExportJSON(); err != nil {
+			t.Error(err)
+			return
+		}
+		globalContainer.(Ranger).Range(func(serviceType reflect.Type, descriptor ServiceDescriptor) bool {
+			if descriptor.Initialized {
+				t.Errorf("exporting should not initialize singleton '%v'", serviceType)
+			}
+			return true
+		})
+		GetService[*exportedClient]()
+		data, _ := globalContainer.(JSONExporter).ExportJSON()
+		var actual struct {
+			Registrations []registrationJSON `json:"registrations"`
+		}
+		if err := json.Unmarshal(data, &actual); err != nil || len(actual.Registrations) != 1 || !actual.Registrations[0].Initialized {
+			t.Errorf("resolved singleton should be exported as initialized: %s", data)
+			return
+		}
+	})
+}
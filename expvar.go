@@ -0,0 +1,128 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"expvar"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// resolveStats is statistics of resolving in container.
+type resolveStats struct {
+	resolves      uint64
+	misses        uint64
+	initDurations sync.Map // reflect.Type -> time.Duration
+}
+
+func (s *resolveStats) recordResolve(found bool) {
+	atomic.AddUint64(&s.resolves, 1)
+	if !found {
+		atomic.AddUint64(&s.misses, 1)
+	}
+}
+
+func (s *resolveStats) recordInit(serviceType reflect.Type, start time.Time) {
+	s.initDurations.Store(serviceType, time.Since(start))
+}
+
+// loadStats to get statistics, nil if not published.
+func (c *defaultContainer) loadStats() *resolveStats {
+	stats, _ := c.stats.Load().(*resolveStats)
+	return stats
+}
+
+var (
+	expvarLocker    sync.Mutex
+	expvarPublished = make(map[string]*defaultContainer)
+)
+
+// ExpvarPublisher is implemented by container to publish statistics of resolving as expvar variables.
+type ExpvarPublisher interface {
+	// PublishExpvar to publish statistics of resolving as expvar variables with 'prefix', which are
+	// "<prefix>.resolves" for count of resolving, "<prefix>.misses" for count of services not found,
+	// "<prefix>.singletons" for count of initialized singletons in current container,
+	// and "<prefix>.init_durations" for duration of the last initializing of each singleton.
+	// Statistics are collected only after published or enabled by 'SetStatsEnabled', and publishing again with the same prefix does nothing.
+	// It returns error if the name is used by other variable.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ExpvarPublisher).PublishExpvar("ioc")
+	PublishExpvar(prefix string) error
+}
+
+var _ ExpvarPublisher = (*defaultContainer)(nil)
+
+func (c *defaultContainer) PublishExpvar(prefix string) error {
+	if prefix == "" {
+		return errors.New("param 'prefix' is empty")
+	}
+	defer expvarLocker.Unlock()
+	expvarLocker.Lock()
+	if published, ok := expvarPublished[prefix]; ok {
+		if published == c {
+			return nil
+		}
+		return fmt.Errorf("expvar with prefix '%s' is already published by other container", prefix)
+	}
+	names := []string{prefix + ".resolves", prefix + ".misses", prefix + ".singletons", prefix + ".init_durations"}
+	for _, name := range names {
+		if expvar.Get(name) != nil {
+			return fmt.Errorf("expvar '%s' already exists", name)
+		}
+	}
+
+	stats := c.loadStats()
+	if stats == nil {
+		stats = &resolveStats{}
+		c.stats.Store(stats)
+	}
+	expvar.Publish(names[0], expvar.Func(func() any {
+		return atomic.LoadUint64(&stats.resolves)
+	}))
+	expvar.Publish(names[1], expvar.Func(func() any {
+		return atomic.LoadUint64(&stats.misses)
+	}))
+	expvar.Publish(names[2], expvar.Func(func() any {
+		count := 0
+		for _, binding := range c.singletonBindings() {
+			if instance, ok := binding.InitializedInstance.Load().(reflect.Value); ok && instance.IsValid() {
+				count++
+			}
+		}
+		return count
+	}))
+	expvar.Publish(names[3], expvar.Func(func() any {
+		durations := make(map[string]string)
+		stats.initDurations.Range(func(key, val any) bool {
+			durations[key.(reflect.Type).String()] = val.(time.Duration).String()
+			return true
+		})
+		return durations
+	}))
+	expvarPublished[prefix] = c
+	return nil
+}
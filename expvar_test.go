@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"encoding/json"
+	"expvar"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPublishExpvar(t *testing.T) {
+	t.Run("published variables should be read back", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		GetService[*serviceInstance7]()
+		// unique for each run, since expvar can't be unpublished
+		prefix := fmt.Sprintf("ioc_test_%d", time.Now().UnixNano())
+		if err := globalContainer.(ExpvarPublisher).PublishExpvar(prefix); err != nil {
+			t.Error(err)
+			return
+		}
+		if err := globalContainer.(ExpvarPublisher).PublishExpvar(prefix); err != nil {
+			t.Error("publishing again should do nothing")
+			return
+		}
+
+		GetService[*serviceInstance8]()
+		GetService[service1]()
+		if resolves := expvar.Get(prefix + ".resolves").String(); resolves != "3" {
+			t.Errorf("resolves should be counted after published, but %s", resolves)
+			return
+		}
+		if misses := expvar.Get(prefix + ".misses").String(); misses != "1" {
+			t.Errorf("misses should be counted, but %s", misses)
+			return
+		}
+		if singletons := expvar.Get(prefix + ".singletons").String(); singletons != "2" {
+			t.Errorf("initialized singletons should be counted, but %s", singletons)
+			return
+		}
+		var durations map[string]string
+		if err := json.Unmarshal([]byte(expvar.Get(prefix+".init_durations").String()), &durations); err != nil {
+			t.Error(err)
+			return
+		}
+		if _, ok := durations[reflect.TypeOf((*serviceInstance8)(nil)).String()]; !ok || len(durations) != 1 {
+			t.Errorf("duration of initializing after published should be recorded, but %v", durations)
+			return
+		}
+
+		if err := New().(ExpvarPublisher).PublishExpvar(prefix); err == nil {
+			t.Error("publishing the same prefix by other container should fail")
+			return
+		}
+		if err := New().(ExpvarPublisher).PublishExpvar(""); err == nil {
+			t.Error("empty prefix should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,136 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// FactoryOption is option of 'FactoryForAdder.AddFactoryFor'.
+type FactoryOption func(opts *factoryOptions)
+
+// factoryOptions is the options of 'FactoryForAdder.AddFactoryFor'.
+type factoryOptions struct {
+	Singleton bool
+}
+
+// AsSingleton to create singleton by factory on first resolving, instead of transient for each resolving.
+func AsSingleton() FactoryOption {
+	return func(opts *factoryOptions) {
+		opts.Singleton = true
+	}
+}
+
+// FactoryError is the error returned by factory added by 'FactoryForAdder.AddFactoryFor'.
+type FactoryError struct {
+	ServiceType reflect.Type
+	Err         error
+}
+
+func (e *FactoryError) Error() string {
+	return fmt.Sprintf("factory of service '%v' fail: %v", e.ServiceType, e.Err)
+}
+
+func (e *FactoryError) Unwrap() error {
+	return e.Err
+}
+
+// AddFactoryFor to add factory of service 'TService' to global container, which gets resolver and returns error,
+// see 'FactoryForAdder.AddFactoryFor'.
+//
+// It will panic if 'TService' or 'factory' is invalid.
+//
+//	ioc.AddFactoryFor[*sql.DB](func(r ioc.Resolver) (*sql.DB, error) {
+//	    return sql.Open("mysql", ioc.GetServiceFromC[*Config](r.(ioc.Container)).DSN)
+//	}, ioc.AsSingleton())
+func AddFactoryFor[TService any](factory func(r Resolver) (TService, error), opts ...FactoryOption) {
+	AddFactoryForToC[TService](globalContainer, factory, opts...)
+}
+
+// AddFactoryForToC to add factory of service 'TService' to container, which gets resolver and returns error,
+// see 'FactoryForAdder.AddFactoryFor'.
+//
+// It will panic if 'TService' or 'factory' is invalid.
+func AddFactoryForToC[TService any](container Container, factory func(r Resolver) (TService, error), opts ...FactoryOption) {
+	if factory == nil {
+		panic("param 'factory' is null")
+	}
+	err := container.(FactoryForAdder).AddFactoryFor(TypeOf[TService](), func(r Resolver) (any, error) {
+		return factory(r)
+	}, opts...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// FactoryForAdder is implemented by container to add factory which gets resolver and returns error.
+type FactoryForAdder interface {
+	// AddFactoryFor to add factory of service, which gets resolver of the container where resolving started and returns error,
+	// it's transient by default, and singleton created on first resolving with 'ioc.AsSingleton'.
+	// Error of factory fails the resolving, such as returned by 'ResolveE' and 'GetServiceE', or panic of 'Resolve' and 'Build',
+	// and failure of singleton is not cached, so it's created again on next resolving.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.FactoryForAdder).AddFactoryFor(reflect.TypeOf((*sql.DB)(nil)), func(r ioc.Resolver) (any, error) {
+	//      return sql.Open("mysql", ioc.GetServiceFromC[*Config](r.(ioc.Container)).DSN)
+	//  }, ioc.AsSingleton())
+	AddFactoryFor(serviceType reflect.Type, factory func(r Resolver) (any, error), opts ...FactoryOption) error
+}
+
+var _ FactoryForAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddFactoryFor(serviceType reflect.Type, factory func(r Resolver) (any, error), opts ...FactoryOption) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if factory == nil {
+		return errors.New("param 'factory' is null")
+	}
+	var options factoryOptions
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&options)
+		}
+	}
+	resolverFactory := func(r Resolver) any {
+		instance, err := factory(r)
+		if err != nil {
+			panic(&FactoryError{ServiceType: serviceType, Err: err})
+		}
+		return instance
+	}
+	binding := &serviceBinding{
+		ServiceType: serviceType,
+		// used by scope and the others that call factory without resolver
+		InstanceFactory: func() any {
+			return resolverFactory(c)
+		},
+		ResolverFactory: resolverFactory,
+	}
+	if options.Singleton {
+		binding.Lazy = true
+		binding.Memo.Store(newLazyMemo())
+	}
+	return c.addBinding(binding)
+}
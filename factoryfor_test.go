@@ -0,0 +1,123 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"testing"
+)
+
+var errFactoryFor = errors.New("connection refused")
+
+type factoryForClient struct {
+	Dep service1 `ioc-inject:"true"`
+}
+
+func TestAddFactoryFor(t *testing.T) {
+	t.Run("factory should be transient by default", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance2](&serviceInstance2{name: "dep"})
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			dep := GetServiceFromC[*serviceInstance2](r.(Container))
+			return &serviceInstance1{name: dep.name}, nil
+		})
+		first, second := GetService[service1](), GetService[service1]()
+		if first.GetName() != "dep" || first == second {
+			t.Error("factory should create instance with resolver for each resolving")
+			return
+		}
+	})
+
+	t.Run("factory should be singleton with option", func(t *testing.T) {
+		globalContainer = New()
+		calls := 0
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			calls++
+			return &serviceInstance1{}, nil
+		}, AsSingleton())
+		if GetService[service1]() != GetService[service1]() || calls != 1 {
+			t.Error("factory should create singleton once")
+			return
+		}
+	})
+
+	t.Run("error should propagate to resolving", func(t *testing.T) {
+		globalContainer = New()
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			return nil, errFactoryFor
+		})
+		_, err := GetServiceE[service1]()
+		var factoryErr *FactoryError
+		if !errors.Is(err, errFactoryFor) || !errors.As(err, &factoryErr) || factoryErr.ServiceType != TypeOf[service1]() {
+			t.Errorf("error of factory should be returned, but '%v'", err)
+			return
+		}
+
+		func() {
+			defer func() {
+				err, _ := recover().(error)
+				if !errors.Is(err, errFactoryFor) {
+					t.Errorf("building should panic with error of factory, but '%v'", err)
+				}
+			}()
+			Build[*factoryForClient](globalContainer)
+		}()
+
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		if GetService[service1]() != nil {
+			t.Error("failure should be zero value if recovering is enabled")
+			return
+		}
+	})
+
+	t.Run("failure of singleton should not be cached", func(t *testing.T) {
+		globalContainer = New()
+		calls := 0
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			calls++
+			if calls == 1 {
+				return nil, errFactoryFor
+			}
+			return &serviceInstance1{name: "retried"}, nil
+		}, AsSingleton())
+		if _, err := GetServiceE[service1](); !errors.Is(err, errFactoryFor) {
+			t.Errorf("first resolving should fail, but '%v'", err)
+			return
+		}
+		if svc, err := GetServiceE[service1](); err != nil || svc.GetName() != "retried" || GetService[service1]() != svc {
+			t.Errorf("singleton should be created again after failure, but '%v'", err)
+			return
+		}
+	})
+
+	t.Run("invalid params should be rejected", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(FactoryForAdder).AddFactoryFor(TypeOf[service1](), nil); err == nil {
+			t.Error("null factory should be rejected")
+			return
+		}
+		if err := globalContainer.(FactoryForAdder).AddFactoryFor(nil, func(r Resolver) (any, error) { return nil, nil }); err == nil {
+			t.Error("null service type should be rejected")
+			return
+		}
+	})
+}
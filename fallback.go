@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AddSingletonWithFallback to add 'primary' as singleton instance to global container if it's usable, otherwise 'fallback'.
+//
+// It will panic if neither is usable.
+func AddSingletonWithFallback[TService any](primary TService, fallback TService) {
+	AddSingletonWithFallbackToC[TService](globalContainer, primary, fallback)
+}
+
+// AddSingletonWithFallbackToC to add 'primary' as singleton instance to container if it's usable, otherwise 'fallback'.
+//
+// It will panic if neither is usable.
+func AddSingletonWithFallbackToC[TService any](container Container, primary TService, fallback TService) {
+	if err := container.(FallbackAdder).AddSingletonWithFallback(TypeOf[TService](), primary, fallback); err != nil {
+		panic(err)
+	}
+}
+
+// FallbackAdder is implemented by container to add singleton with fallback instance.
+type FallbackAdder interface {
+	// AddSingletonWithFallback to add 'primary' as singleton instance if it's non-nil and implements the service,
+	// otherwise 'fallback', such as primary implementation unavailable on some platforms.
+	// It returns error if neither is usable.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.FallbackAdder).AddSingletonWithFallback(reflect.TypeOf((*Notifier)(nil)).Elem(), newNativeNotifier(), &LogNotifier{})
+	AddSingletonWithFallback(serviceType reflect.Type, primary any, fallback any) error
+}
+
+var _ FallbackAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSingletonWithFallback(serviceType reflect.Type, primary any, fallback any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	primaryErr := checkCandidate(serviceType, primary)
+	if primaryErr == nil {
+		return c.AddSingleton(serviceType, primary)
+	}
+	fallbackErr := checkCandidate(serviceType, fallback)
+	if fallbackErr == nil {
+		return c.AddSingleton(serviceType, fallback)
+	}
+	return fmt.Errorf("neither instance of service '%v' is usable: primary %v, and fallback %v", serviceType, primaryErr, fallbackErr)
+}
+
+// checkCandidate to check whether instance is non-nil and assignable to service type.
+func checkCandidate(serviceType reflect.Type, instance any) error {
+	if instance == nil || reflect.ValueOf(instance).IsZero() {
+		return errors.New("is null")
+	}
+	if instanceType := reflect.TypeOf(instance); !instanceType.AssignableTo(serviceType) {
+		return fmt.Errorf("'%v' doesn't implement the service", instanceType)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddSingletonWithFallback(t *testing.T) {
+	t.Run("primary present should be used", func(t *testing.T) {
+		globalContainer = New()
+		AddSingletonWithFallback[service1](&serviceInstance1{name: "primary"}, &serviceInstance1{name: "fallback"})
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "primary" {
+			t.Error("primary should be used")
+			return
+		}
+	})
+
+	t.Run("primary nil should use fallback", func(t *testing.T) {
+		globalContainer = New()
+		var primary *serviceInstance1
+		AddSingletonWithFallback[service1](primary, &serviceInstance1{name: "fallback"})
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "fallback" {
+			t.Error("fallback should be used if primary is nil")
+			return
+		}
+
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(FallbackAdder).AddSingletonWithFallback(serviceType, &serviceInstance8{}, &serviceInstance1{name: "fallback"}); err != nil {
+			t.Error(err)
+			return
+		}
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "fallback" {
+			t.Error("fallback should be used if primary doesn't implement the service")
+			return
+		}
+	})
+
+	t.Run("both invalid should fail", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		err := globalContainer.(FallbackAdder).AddSingletonWithFallback(serviceType, nil, &serviceInstance8{})
+		if err == nil || !strings.Contains(err.Error(), "primary is null") || !strings.Contains(err.Error(), "'*ioc.serviceInstance8'") {
+			t.Errorf("error should report both candidates, but %v", err)
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("nothing should be added")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("both nil should panic")
+			}
+		}()
+		AddSingletonWithFallback[service1](nil, nil)
+	})
+}
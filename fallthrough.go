@@ -0,0 +1,60 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// fallthroughHook is the hook of 'FallthroughObserver.OnFallthrough'.
+type fallthroughHook func(serviceType reflect.Type, fromDepth int)
+
+// FallthroughObserver is implemented by container to observe resolving delegated to parents.
+type FallthroughObserver interface {
+	// OnFallthrough to observe resolving started from current container which misses in a container and delegates to it's parent.
+	// It's invoked for each delegation with depth of the parent delegated to, 1 for parent of current, 2 for grandparent and so on.
+	// It replaces the previous one, and nil removes it.
+	//
+	//  container.(ioc.FallthroughObserver).OnFallthrough(func(serviceType reflect.Type, fromDepth int) {
+	//      log.Printf("'%v' delegated to ancestor at depth %d", serviceType, fromDepth)
+	//  })
+	OnFallthrough(hook func(serviceType reflect.Type, fromDepth int))
+}
+
+var _ FallthroughObserver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) OnFallthrough(hook func(serviceType reflect.Type, fromDepth int)) {
+	c.fallthroughHook.Store(fallthroughHook(hook))
+}
+
+// notifyFallthrough to invoke hook of current when resolving started from current delegates from 'missed' to it's parent.
+func (c *defaultContainer) notifyFallthrough(serviceType reflect.Type, missed *defaultContainer) {
+	hook, _ := c.fallthroughHook.Load().(fallthroughHook)
+	if hook == nil {
+		return
+	}
+	depth := 1
+	for current := c; current != nil && current != missed; depth++ {
+		current, _ = current.Parent().(*defaultContainer)
+	}
+	hook(serviceType, depth)
+}
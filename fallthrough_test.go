@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOnFallthrough(t *testing.T) {
+	t.Run("hook should be invoked with depth for each delegation", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "root"})
+		middle := globalContainer.(ScopeContainer).NewScope()
+		AddSingletonToC[service2](middle, &serviceInstance2{name: "middle"})
+		leaf := middle.(ScopeContainer).NewScope()
+		AddSingletonToC[*serviceInstance1](leaf, &serviceInstance1{name: "leaf"})
+
+		var events []string
+		leaf.(FallthroughObserver).OnFallthrough(func(serviceType reflect.Type, fromDepth int) {
+			events = append(events, fmt.Sprintf("%v@%d", serviceType, fromDepth))
+		})
+		expected := map[reflect.Type]string{
+			TypeOf[service1]():          "ioc.service1@1,ioc.service1@2",
+			TypeOf[service2]():          "ioc.service2@1",
+			TypeOf[*serviceInstance1](): "",
+			TypeOf[*serviceInstance3](): "*ioc.serviceInstance3@1,*ioc.serviceInstance3@2",
+		}
+		for serviceType, expectedEvents := range expected {
+			events = nil
+			leaf.Resolve(serviceType)
+			if strings.Join(events, ",") != expectedEvents {
+				t.Errorf("resolving '%v' should fire '%s', but '%s'", serviceType, expectedEvents, strings.Join(events, ","))
+				return
+			}
+		}
+
+		events = nil
+		middle.Resolve(TypeOf[service1]())
+		if len(events) != 0 {
+			t.Error("hook should be invoked only for resolving started from the container it's set")
+			return
+		}
+		leaf.(FallthroughObserver).OnFallthrough(nil)
+		leaf.Resolve(TypeOf[service1]())
+		if len(events) != 0 {
+			t.Error("hook removed should not be invoked")
+			return
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"sync/atomic"
+)
+
+// featureGroup is the group of services turned on or off together, see 'FeatureToggler.AddFeature'.
+type featureGroup struct {
+	name    string
+	enabled int32
+}
+
+func (f *featureGroup) isEnabled() bool {
+	return atomic.LoadInt32(&f.enabled) == 1
+}
+
+// FeatureToggler is implemented by container to turn on or off services by feature group.
+type FeatureToggler interface {
+	// AddFeature to add feature group enabled, services registered with option 'ioc.InFeature' of it can be turned on
+	// or off together at runtime. It does nothing if the feature group exists.
+	//
+	//  container.(ioc.FeatureToggler).AddFeature("recommendation")
+	//  container.(ioc.Registerer).Register(&MLRanker{}, ioc.As(ioc.TypeOf[Ranker]()), ioc.InFeature("recommendation"))
+	//  container.(ioc.FeatureToggler).DisableFeature("recommendation") // 'Ranker' is resolved from parent or not found
+	AddFeature(name string) error
+
+	// EnableFeature to make services in feature group resolvable.
+	EnableFeature(name string) error
+
+	// DisableFeature to make services in feature group not resolvable without removing them, which are resolved as not found
+	// in current container, and from parent if it has.
+	DisableFeature(name string) error
+}
+
+var _ FeatureToggler = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddFeature(name string) error {
+	if name == "" {
+		return errors.New("param 'name' is empty")
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if c.features == nil {
+		c.features = make(map[string]*featureGroup)
+	}
+	if _, ok := c.features[name]; !ok {
+		c.features[name] = &featureGroup{name: name, enabled: 1}
+	}
+	return nil
+}
+
+func (c *defaultContainer) EnableFeature(name string) error {
+	return c.setFeatureEnabled(name, 1)
+}
+
+func (c *defaultContainer) DisableFeature(name string) error {
+	return c.setFeatureEnabled(name, 0)
+}
+
+func (c *defaultContainer) setFeatureEnabled(name string, enabled int32) error {
+	feature := c.getFeature(name)
+	if feature == nil {
+		return fmt.Errorf("feature group '%s' not found", name)
+	}
+	if atomic.SwapInt32(&feature.enabled, enabled) != enabled {
+		c.bindingsChanged()
+	}
+	return nil
+}
+
+// getFeature to get feature group by name, nil if not found.
+func (c *defaultContainer) getFeature(name string) *featureGroup {
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	return c.features[name]
+}
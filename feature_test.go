@@ -0,0 +1,110 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestFeature(t *testing.T) {
+	t.Run("services in disabled feature group should not be resolvable", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service1](parent, &serviceInstance1{name: "parent"})
+		globalContainer.SetParent(parent)
+		if err := globalContainer.(FeatureToggler).AddFeature("beta"); err != nil {
+			t.Error(err)
+			return
+		}
+		Register(&serviceInstance1{name: "beta"}, As(TypeOf[service1]()), InFeature("beta"))
+		Register(&serviceInstance2{name: "beta"}, As(TypeOf[service2]()), InFeature("beta"))
+		Register(&serviceInstance1{name: "keyed"}, As(TypeOf[service1]()), Keyed("k"), InFeature("beta"))
+		if GetService[service1]().GetName() != "beta" || GetService[service2]() == nil || GetKeyed[service1]("k") == nil {
+			t.Error("services in enabled feature group should be resolvable")
+			return
+		}
+
+		if err := globalContainer.(FeatureToggler).DisableFeature("beta"); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]().GetName() != "parent" {
+			t.Error("service in disabled feature group should be resolved from parent")
+			return
+		}
+		if GetService[service2]() != nil || GetKeyed[service1]("k") != nil || len(ResolveAll[service2]()) != 0 {
+			t.Error("services in disabled feature group should not be resolvable")
+			return
+		}
+
+		if err := globalContainer.(FeatureToggler).EnableFeature("beta"); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]().GetName() != "beta" || len(ResolveAll[service2]()) != 1 {
+			t.Error("services in enabled feature group should be resolvable again")
+			return
+		}
+	})
+
+	t.Run("sealed resolver should skip services in disabled feature group", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service1](parent, &serviceInstance1{name: "parent"})
+		globalContainer.SetParent(parent)
+		globalContainer.(FeatureToggler).AddFeature("beta")
+		Register(&serviceInstance1{name: "beta"}, As(TypeOf[service1]()), InFeature("beta"))
+		Register(&serviceInstance2{name: "beta"}, As(TypeOf[service2]()), InFeature("beta"))
+		globalContainer.(FeatureToggler).DisableFeature("beta")
+
+		sealed := globalContainer.(Sealer).Seal()
+		if val := sealed.Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface().(service1).GetName() != "parent" {
+			t.Error("service in disabled feature group should be resolved from parent by sealed resolver")
+			return
+		}
+		if sealed.Resolve(TypeOf[service2]()).IsValid() {
+			t.Error("service in disabled feature group should not be resolvable by sealed resolver")
+			return
+		}
+		globalContainer.(FeatureToggler).EnableFeature("beta")
+		if val := globalContainer.(Sealer).Seal().Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface().(service1).GetName() != "beta" {
+			t.Error("service in enabled feature group should be resolved by sealed resolver")
+			return
+		}
+	})
+
+	t.Run("feature group not added should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, InFeature("unknown")); err == nil {
+			t.Error("registering in feature group not added should fail")
+			return
+		}
+		if globalContainer.(FeatureToggler).DisableFeature("unknown") == nil || globalContainer.(FeatureToggler).EnableFeature("unknown") == nil {
+			t.Error("toggling feature group not added should fail")
+			return
+		}
+		if globalContainer.(FeatureToggler).AddFeature("") == nil {
+			t.Error("empty name should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// InstanceFilterAdder is implemented by container to wrap or replace instances after constructing.
+type InstanceFilterAdder interface {
+	// AddInstanceFilter to add a filter, which can wrap or replace the instance after constructing,
+	// before the singleton instance is cached or the transient instance is returned.
+	//
+	// Filters compose in adding order. The returns of filter will be skipped if it is invalid or can't be assigned to the service type.
+	//
+	//  var container ioc.Container
+	//  container.(ioc.InstanceFilterAdder).AddInstanceFilter(func(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+	//      if serviceType == reflect.TypeOf((*Service1)(nil)).Elem() {
+	//          return reflect.ValueOf(&Service1Proxy{inner: instance.Interface().(Service1)})
+	//      }
+	//      return instance
+	//  })
+	AddInstanceFilter(filter func(serviceType reflect.Type, instance reflect.Value) reflect.Value)
+}
+
+var _ InstanceFilterAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddInstanceFilter(filter func(serviceType reflect.Type, instance reflect.Value) reflect.Value) {
+	if filter == nil {
+		return
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	filters, _ := c.filters.Load().([]func(serviceType reflect.Type, instance reflect.Value) reflect.Value)
+	// copy on write, so filters can be read without lock
+	newFilters := make([]func(serviceType reflect.Type, instance reflect.Value) reflect.Value, 0, len(filters)+1)
+	newFilters = append(newFilters, filters...)
+	newFilters = append(newFilters, filter)
+	c.filters.Store(newFilters)
+}
+
+// filterInstance to apply filters to instance in order, skip filter whose returns is invalid or can't be assigned to service type.
+func (c *defaultContainer) filterInstance(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+	filters, _ := c.filters.Load().([]func(serviceType reflect.Type, instance reflect.Value) reflect.Value)
+	if len(filters) == 0 || !instance.IsValid() {
+		return instance
+	}
+	for _, filter := range filters {
+		filtered := filter(serviceType, instance)
+		if !filtered.IsValid() || !filtered.Type().AssignableTo(serviceType) {
+			continue
+		}
+		instance = filtered
+	}
+	return instance
+}
@@ -0,0 +1,88 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddInstanceFilter(t *testing.T) {
+	t.Run("filters should compose in order for singleton and transient", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		AddTransient[service2](func() service2 { return &serviceInstance2{name: "instance2"} })
+		globalContainer.(InstanceFilterAdder).AddInstanceFilter(func(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+			if svc, ok := instance.Interface().(service1); ok {
+				return reflect.ValueOf(&serviceProxy{inner: svc, prefix: "a-"})
+			}
+			return instance
+		})
+		globalContainer.(InstanceFilterAdder).AddInstanceFilter(func(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+			if svc, ok := instance.Interface().(service1); ok {
+				return reflect.ValueOf(&serviceProxy{inner: svc, prefix: "b-"})
+			}
+			return instance
+		})
+
+		svc1FromIoc := GetService[service1]()
+		if svc1FromIoc == nil || svc1FromIoc.GetName() != "b-a-instance1" {
+			t.Error("filters should compose in order")
+			return
+		}
+		if GetService[service1]() != svc1FromIoc {
+			t.Error("filtered singleton should be cached")
+			return
+		}
+		svc2FromIoc := GetService[service2]()
+		if svc2FromIoc == nil || svc2FromIoc.GetName() != "instance2" {
+			t.Error("filter not matched should keep the instance")
+			return
+		}
+	})
+
+	t.Run("invalid returns of filter should be skipped", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[*serviceInstance1](svc1)
+		globalContainer.(InstanceFilterAdder).AddInstanceFilter(func(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+			return reflect.Value{}
+		})
+		globalContainer.(InstanceFilterAdder).AddInstanceFilter(func(serviceType reflect.Type, instance reflect.Value) reflect.Value {
+			return reflect.ValueOf(&serviceInstance2{})
+		})
+		if GetService[*serviceInstance1]() != svc1 {
+			t.Error("invalid or unassignable returns should be skipped")
+			return
+		}
+	})
+}
+
+type serviceProxy struct {
+	inner  service1
+	prefix string
+}
+
+func (proxy *serviceProxy) GetName() string {
+	return proxy.prefix + proxy.inner.GetName()
+}
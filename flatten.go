@@ -0,0 +1,53 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// Flattener is implemented by container to list services resolvable with their lifetimes.
+type Flattener interface {
+	// Flatten to get services resolvable by type from current container and it's parent chain, with the lifetime each would resolve as.
+	// Service of child shadows the same one of parent, and not inherited services of parents are excluded.
+	// Nothing is instantiated, and parent which is not created by 'ioc.New' stops the walking.
+	Flatten() map[reflect.Type]Lifetime
+}
+
+var _ Flattener = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Flatten() map[reflect.Type]Lifetime {
+	flattened := make(map[reflect.Type]Lifetime)
+	for current := c; current != nil; {
+		current.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+			if binding.ServiceType == resolverType || binding.NotInherited && current != c {
+				return true
+			}
+			if _, shadowed := flattened[binding.ServiceType]; !shadowed {
+				flattened[binding.ServiceType] = binding.lifetime()
+			}
+			return true
+		})
+		current, _ = current.Parent().(*defaultContainer)
+	}
+	return flattened
+}
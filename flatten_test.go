@@ -0,0 +1,71 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFlatten(t *testing.T) {
+	t.Run("child should shadow parent in flattened view", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "root"})
+		AddTransient[service2](func() service2 { return &serviceInstance2{} })
+		Register(&serviceInstance1{name: "not inherited"}, NotInherited())
+
+		middle := New()
+		middle.SetParent(globalContainer)
+		AddTransientToC[service1](middle, func() service1 { return &serviceInstance1{} })
+		AddScopedToC[*serviceInstance2](middle, func() *serviceInstance2 { return &serviceInstance2{} })
+
+		constructed := 0
+		leaf := New()
+		leaf.SetParent(middle)
+		AddTransientToC[service2](leaf, func() service2 {
+			constructed++
+			return &serviceInstance2{}
+		})
+		RebindToC[service2](leaf, LifetimeSingleton)
+
+		expected := map[reflect.Type]Lifetime{
+			TypeOf[service1]():          LifetimeTransient,
+			TypeOf[service2]():          LifetimeSingleton,
+			TypeOf[*serviceInstance2](): LifetimeScoped,
+		}
+		flattened := leaf.(Flattener).Flatten()
+		if !reflect.DeepEqual(flattened, expected) {
+			t.Errorf("flattened should be %v, but %v", expected, flattened)
+			return
+		}
+		if constructed != 0 {
+			t.Error("flatten should not instantiate anything")
+			return
+		}
+
+		flattened = globalContainer.(Flattener).Flatten()
+		if len(flattened) != 3 || flattened[TypeOf[service1]()] != LifetimeSingleton || flattened[TypeOf[*serviceInstance1]()] != LifetimeSingleton {
+			t.Errorf("not inherited service should be in it's own container, but %v", flattened)
+			return
+		}
+	})
+}
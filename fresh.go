@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// ResolveFresh to get an independent copy of singleton 'TService' from global container, see 'FreshResolver.ResolveFresh'.
+// It returns zero value if the copy can't be created.
+func ResolveFresh[TService any]() TService {
+	return ResolveFreshFromC[TService](globalContainer)
+}
+
+// ResolveFreshFromC to get an independent copy of singleton 'TService' from container, see 'FreshResolver.ResolveFresh'.
+// It returns zero value if the copy can't be created.
+func ResolveFreshFromC[TService any](container Container) TService {
+	var instance TService
+	if val := container.(FreshResolver).ResolveFresh(TypeOf[TService]()); val.IsValid() {
+		instance, _ = val.Interface().(TService)
+	}
+	return instance
+}
+
+// FreshResolver is implemented by container to get independent copies of singletons.
+type FreshResolver interface {
+	// ResolveFresh to get an independent copy of singleton '*struct' registered by instance, and it's not cached.
+	// The copy has exported fields of registered instance, and then is injected and initialized again.
+	// It's invalid value if service is not registered by instance, or instance is not '*struct',
+	// and factory is never invoked.
+	//
+	//  var container ioc.Container
+	//  settings := container.(ioc.FreshResolver).ResolveFresh(reflect.TypeOf((*Settings)(nil))).Interface().(*Settings)
+	ResolveFresh(serviceType reflect.Type) reflect.Value
+}
+
+var _ FreshResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveFresh(serviceType reflect.Type) reflect.Value {
+	if serviceType == nil {
+		return reflect.Value{}
+	}
+	binding := c.lookupBinding(serviceType)
+	if binding == nil {
+		return reflect.Value{}
+	}
+	binding = binding.implementation()
+	registered := binding.Instance
+	if !registered.IsValid() || registered.Kind() != reflect.Pointer || registered.Type().Elem().Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+
+	fresh := reflect.New(registered.Type().Elem())
+	structType := registered.Type().Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		if structType.Field(i).IsExported() {
+			fresh.Elem().Field(i).Set(registered.Elem().Field(i))
+		}
+	}
+	InjectFromC(c, fresh)
+	if binding.InstanceInitializerName != "" {
+		if initializer := fresh.MethodByName(binding.InstanceInitializerName); initializer.IsValid() {
+			func() {
+				defer recover()
+				InjectFromC(c, initializer)
+			}()
+		}
+	}
+	if binding.InitCallback != nil {
+		binding.InitCallback(fresh.Interface(), c)
+	}
+	return fresh
+}
@@ -0,0 +1,86 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+type freshSettings struct {
+	Name        string
+	Dep         service1 `ioc-inject:"true"`
+	initialized int
+}
+
+func (s *freshSettings) Initialize(dep service2) {
+	s.initialized++
+}
+
+func TestResolveFresh(t *testing.T) {
+	t.Run("fresh copy should be independent of the shared singleton", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "dep"})
+		AddSingleton[service2](&serviceInstance2{name: "instance2"})
+		shared := &freshSettings{Name: "shared"}
+		AddSingleton[*freshSettings](shared)
+		if GetService[*freshSettings]() != shared || shared.initialized != 1 {
+			t.Error("shared singleton should be initialized once")
+			return
+		}
+
+		fresh := ResolveFresh[*freshSettings]()
+		if fresh == nil || fresh == shared {
+			t.Error("fresh copy should be a new instance")
+			return
+		}
+		if fresh.Name != "shared" || fresh.Dep == nil || fresh.Dep.GetName() != "dep" || fresh.initialized != 1 {
+			t.Error("fresh copy should copy exported fields, and be injected and initialized")
+			return
+		}
+		fresh.Name = "changed"
+		if shared.Name != "shared" || GetService[*freshSettings]() != shared || shared.initialized != 1 {
+			t.Error("mutating fresh copy should not affect the shared singleton")
+			return
+		}
+		if ResolveFresh[*freshSettings]() == fresh {
+			t.Error("fresh copy should not be cached")
+			return
+		}
+	})
+
+	t.Run("service not registered by *struct instance should be invalid", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { return &serviceInstance1{} })
+		if globalContainer.(FreshResolver).ResolveFresh(TypeOf[*serviceInstance1]()).IsValid() {
+			t.Error("transient should not be copied")
+			return
+		}
+		if ResolveFresh[service2]() != nil {
+			t.Error("service not registered should be zero value")
+			return
+		}
+		if globalContainer.(FreshResolver).ResolveFresh(nil).IsValid() {
+			t.Error("null service type should be invalid")
+			return
+		}
+	})
+}
@@ -0,0 +1,118 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// AddGoroutineScoped to add service instance factory to global container, whose instance is cached for each goroutine.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddGoroutineScoped[TService any](instanceFactory func() TService) {
+	AddGoroutineScopedToC[TService](globalContainer, instanceFactory)
+}
+
+// AddGoroutineScopedToC to add service instance factory to container, whose instance is cached for each goroutine.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddGoroutineScopedToC[TService any](container Container, instanceFactory func() TService) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	err := container.(GoroutineScopeContainer).AddGoroutineScoped(TypeOf[TService](), func() any {
+		return instanceFactory()
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GoroutineScopeContainer is implemented by container to add services cached for each goroutine and release them.
+type GoroutineScopeContainer interface {
+	// AddGoroutineScoped to add service instance factory, whose instance is cached for each goroutine, for legacy code storing per-goroutine state.
+	//
+	// Go has no official goroutine-local storage, so id of goroutine is parsed from it's stack, which costs more than other lifetimes.
+	// Instances are cached until 'ReleaseGoroutineScoped' is invoked in the goroutine, or they will leak after the goroutine exits,
+	// and a new goroutine may reuse the id. Goroutines started by the instance don't share it.
+	//
+	//  go func() {
+	//      defer container.(ioc.GoroutineScopeContainer).ReleaseGoroutineScoped()
+	//      ...
+	//  }()
+	AddGoroutineScoped(serviceType reflect.Type, instanceFactory func() any) error
+
+	// ReleaseGoroutineScoped to drop instances cached for current goroutine by services added by 'AddGoroutineScoped' in current container,
+	// and dispose the ones that implement 'ioc.Disposable', returns the aggregated errors.
+	ReleaseGoroutineScoped() error
+}
+
+var _ GoroutineScopeContainer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddGoroutineScoped(serviceType reflect.Type, instanceFactory func() any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory, GoroutineScoped: true})
+}
+
+func (c *defaultContainer) ReleaseGoroutineScoped() error {
+	gid := goroutineID()
+	var errs []error
+	c.bindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		if !binding.GoroutineScoped {
+			return true
+		}
+		cached, ok := binding.goroutineInstances.LoadAndDelete(gid)
+		if !ok {
+			return true
+		}
+		instance := cached.(reflect.Value)
+		if disposable, ok := instance.Interface().(Disposable); ok {
+			if err := disposable.Dispose(); err != nil {
+				errs = append(errs, fmt.Errorf("dispose service '%v' fail: %w", binding.ServiceType, err))
+			}
+		}
+		return true
+	})
+	return aggregateErrors(errs)
+}
+
+// resolveGoroutineScoped to get instance cached for current goroutine, and create it if not exists.
+func (b *serviceBinding) resolveGoroutineScoped(owner *defaultContainer, path *resolvePath) reflect.Value {
+	gid := goroutineID()
+	if cached, ok := b.goroutineInstances.Load(gid); ok {
+		return cached.(reflect.Value)
+	}
+	instance := b.newTransient(owner, owner, path)
+	if instance.IsValid() && !instance.IsZero() {
+		// only the current goroutine stores by it's id
+		b.goroutineInstances.Store(gid, instance)
+	}
+	return instance
+}
@@ -0,0 +1,88 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAddGoroutineScoped(t *testing.T) {
+	t.Run("each goroutine should get it's own instance", func(t *testing.T) {
+		globalContainer = New()
+		AddGoroutineScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(TypeOf[*disposableService]()); lifetime != LifetimeGoroutine {
+			t.Errorf("lifetime should be goroutine, but %v", lifetime)
+			return
+		}
+
+		main := GetService[*disposableService]()
+		if main == nil || GetService[*disposableService]() != main {
+			t.Error("instance should be cached in the same goroutine")
+			return
+		}
+		instances := make([]*disposableService, 10)
+		var wg sync.WaitGroup
+		for i := range instances {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				defer globalContainer.(GoroutineScopeContainer).ReleaseGoroutineScoped()
+				instances[i] = GetService[*disposableService]()
+				if GetService[*disposableService]() != instances[i] {
+					instances[i] = nil
+				}
+			}(i)
+		}
+		wg.Wait()
+		seen := map[*disposableService]bool{main: true}
+		for _, instance := range instances {
+			if instance == nil || seen[instance] {
+				t.Error("goroutines should be isolated, and cached in each")
+				return
+			}
+			seen[instance] = true
+			if instance.disposed != 1 {
+				t.Error("released instance should be disposed")
+				return
+			}
+		}
+	})
+
+	t.Run("released instance should not be cached", func(t *testing.T) {
+		globalContainer = New()
+		AddGoroutineScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		first := GetService[*disposableService]()
+		if err := globalContainer.(GoroutineScopeContainer).ReleaseGoroutineScoped(); err != nil || first.disposed != 1 {
+			t.Errorf("release should dispose instance of current goroutine, but %v", err)
+			return
+		}
+		if GetService[*disposableService]() == first {
+			t.Error("new instance should be created after released")
+			return
+		}
+		if err := globalContainer.(Memoizer).Memoize(TypeOf[*disposableService](), func() any { return 1 }); err == nil {
+			t.Error("goroutine scoped service should not be memoized")
+			return
+		}
+	})
+}
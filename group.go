@@ -0,0 +1,147 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddToGroup to add singleton instance of service 'TService' to group.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+//
+//	ioc.AddToGroup[Handler]("handlers", &Handler1{})
+//	ioc.AddToGroup[*Handler2]("handlers", &Handler2{})
+func AddToGroup[TService any](groupName string, instance TService) {
+	AddToGroupToC[TService](globalContainer, groupName, instance)
+}
+
+// AddToGroupToC to add singleton instance of service 'TService' to group of container.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+func AddToGroupToC[TService any](container Container, groupName string, instance TService) {
+	err := container.(Grouper).AddToGroup(groupName, TypeOf[TService](), instance)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ResolveGroup to get instances of group sorted by 'ioc.Order' and then registration order, which can be assigned to 'T'.
+//
+//	// all instances in group
+//	instances := ioc.ResolveGroup[any]("handlers")
+//	// instances implement 'Handler' in group
+//	handlers := ioc.ResolveGroup[Handler]("handlers")
+func ResolveGroup[T any](groupName string, opts ...CollectOption) []T {
+	return ResolveGroupFromC[T](globalContainer, groupName, opts...)
+}
+
+// ResolveGroupFromC to get instances of group from container sorted by 'ioc.Order' and then registration order, which can be assigned to 'T'.
+func ResolveGroupFromC[T any](container Container, groupName string, opts ...CollectOption) []T {
+	instanceVals := container.(Grouper).ResolveGroup(groupName, opts...)
+	instances := make([]T, 0, len(instanceVals))
+	for _, instanceVal := range instanceVals {
+		if instance, ok := instanceVal.Interface().(T); ok {
+			instances = append(instances, instance)
+		}
+	}
+	return instances
+}
+
+// Grouper is implemented by container to add instances to named groups and resolve them.
+type Grouper interface {
+	// AddToGroup to add singleton instance of service to group, independent of registration of service.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.Grouper).AddToGroup("handlers", TypeOf[Handler](), &Handler1{})
+	//  err = container.(ioc.Grouper).AddToGroup("handlers", reflect.TypeOf((*Handler2)(nil)), &Handler2{})
+	AddToGroup(groupName string, serviceType reflect.Type, instance any) error
+
+	// ResolveGroup to get all instances of group sorted by 'ioc.Order' and then registration order.
+	// It will resolve from parent if group not found in current.
+	//
+	// Instance in group by multiple types is included once with 'ioc.Distinct'.
+	//
+	//  var container ioc.Container
+	//  handlers := container.(ioc.Grouper).ResolveGroup("handlers")
+	ResolveGroup(groupName string, opts ...CollectOption) []reflect.Value
+}
+
+var _ Grouper = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddToGroup(groupName string, serviceType reflect.Type, instance any) error {
+	if groupName == "" {
+		return errors.New("param 'groupName' is empty")
+	}
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err != nil {
+		return err
+	}
+	return c.addToGroup(groupName, binding)
+}
+
+func (c *defaultContainer) addToGroup(groupName string, binding *serviceBinding) error {
+	if err := validateBinding(binding); err != nil {
+		return err
+	}
+
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if c.groups == nil {
+		c.groups = make(map[string][]*serviceBinding)
+	}
+	c.groups[groupName] = append(c.groups[groupName], binding)
+	return nil
+}
+
+func (c *defaultContainer) ResolveGroup(groupName string, opts ...CollectOption) []reflect.Value {
+	return collectOptionsOf(opts).apply(c.resolveGroup(groupName, c, nil))
+}
+
+func (c *defaultContainer) resolveGroup(groupName string, origin *defaultContainer, path *resolvePath) []reflect.Value {
+	c.locker.Lock()
+	bindings := c.groups[groupName]
+	c.locker.Unlock()
+	if len(bindings) == 0 {
+		switch parent := c.parent.(type) {
+		case *defaultContainer:
+			return parent.resolveGroup(groupName, origin, path)
+		case Grouper:
+			return parent.ResolveGroup(groupName)
+		}
+		return nil
+	}
+
+	bindings = sortByOrder(bindings)
+	instances := make([]reflect.Value, 0, len(bindings))
+	for _, binding := range bindings {
+		instances = append(instances, binding.resolve(c, origin, path))
+	}
+	return instances
+}
@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("resolve group in registration order should success", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		svc1 := &serviceInstance1{name: "instance1"}
+		svc8 := &serviceInstance8{}
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddToGroup[service1]("group1", svc1)
+		AddToGroup[*serviceInstance8]("group1", svc8)
+		AddToGroup[service2]("group1", svc2)
+
+		instances := ResolveGroup[any]("group1")
+		if len(instances) != 3 || instances[0] != svc1 || instances[1] != svc8 || instances[2] != svc2 {
+			t.Error("instances should be in registration order")
+			return
+		}
+		if svc8.GetS7Name() != "instance7" {
+			t.Error("instance in group should be initialized")
+			return
+		}
+		named := ResolveGroup[service1]("group1")
+		if len(named) != 2 || named[0] != svc1 || named[1] != svc2 {
+			t.Error("only instances can be assigned to 'T' should be returned")
+			return
+		}
+		if GetService[service1]() != nil {
+			t.Error("add to group should not register service")
+			return
+		}
+	})
+
+	t.Run("resolve group from parent if not found in current", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddToGroupToC[service1](parent, "group1", svc1)
+		SetParent(parent)
+
+		instances := ResolveGroup[service1]("group1")
+		if len(instances) != 1 || instances[0] != svc1 {
+			t.Error("group should be resolved from parent")
+			return
+		}
+		if instances := ResolveGroup[any]("group2"); len(instances) != 0 {
+			t.Error("group not found should be empty")
+			return
+		}
+	})
+
+	t.Run("invalid instance should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Grouper).AddToGroup("", reflect.TypeOf((*service1)(nil)).Elem(), &serviceInstance1{}); err == nil {
+			t.Error("empty group name should fail")
+			return
+		}
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("null instance should fail")
+				}
+			}()
+			AddToGroup[*serviceInstance1]("group1", nil)
+		}()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("type of service 'serviceInstance1' should be interface or *struct")
+				}
+			}()
+			AddToGroup[serviceInstance1]("group1", serviceInstance1{name: "instance1"})
+		}()
+	})
+}
+
+func TestInjectGroup(t *testing.T) {
+	t.Run("inject slice field from group should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddToGroup[service1]("group1", svc1)
+		AddToGroup[*serviceInstance8]("group1", &serviceInstance8{})
+		AddToGroup[service2]("group1", svc2)
+
+		c := &groupClient{}
+		Inject(c)
+		if len(c.Members) != 2 || c.Members[0] != svc1 || c.Members[1] != svc2 {
+			t.Error("element type mismatched should be skipped, others in registration order")
+			return
+		}
+		if c.Empty == nil || len(c.Empty) != 0 {
+			t.Error("empty group should be injected as empty slice")
+			return
+		}
+	})
+
+	t.Run("inject map field from keyed should success", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		primary := &serviceInstance1{name: "primary"}
+		replica := &serviceInstance1{name: "replica"}
+		_ = parent.(KeyedContainer).AddKeyedSingleton("primary", serviceType, &serviceInstance1{name: "parent"})
+		_ = parent.(KeyedContainer).AddKeyedSingleton("replica", serviceType, replica)
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton("primary", serviceType, primary)
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton(1, serviceType, &serviceInstance1{name: "1"})
+		SetParent(parent)
+
+		c := &groupClient{}
+		Inject(c)
+		if len(c.Keyed) != 2 || c.Keyed["primary"] != primary || c.Keyed["replica"] != replica {
+			t.Error("map should be injected with keyed services, current shadows parent, key type mismatched skipped")
+			return
+		}
+		if c.EmptyKeyed == nil || len(c.EmptyKeyed) != 0 {
+			t.Error("map without keyed services should be injected as empty map")
+			return
+		}
+	})
+}
+
+type groupClient struct {
+	Members    []service1          `ioc-inject:"group=group1"`
+	Empty      []service1          `ioc-inject:"group=group2"`
+	Keyed      map[string]service1 `ioc-inject:"true"`
+	EmptyKeyed map[string]service2 `ioc-inject:"true"`
+}
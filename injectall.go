@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// InjectAll to inject to each of func or *struct targets with service from global container,
+// and aggregate errors of targets failed, see 'ioc.InjectAllFromC'.
+//
+//	err := ioc.InjectAll(&handler, &worker, client.Configure)
+func InjectAll(targets ...any) error {
+	return InjectAllFromC(globalContainer, targets...)
+}
+
+// InjectAllFromC to inject to each of func or *struct targets with service from container.
+// Invalid target is skipped, and panic during injecting is converted into error, then the rest are still injected.
+// Errors of targets failed are aggregated as '*ioc.AggregateError', with index and type of each target.
+func InjectAllFromC(container Container, targets ...any) error {
+	if container == nil {
+		return errors.New("param 'container' is null")
+	}
+	var errs []error
+	for i, target := range targets {
+		if err := safeInject(container, target); err != nil {
+			errs = append(errs, fmt.Errorf("inject to target[%d] '%s' fail: %w", i, typeNameOfTarget(target), err))
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// safeInject to inject to target, and convert panic during injecting into error.
+func safeInject(container Container, target any) (err error) {
+	targetVal := injectTargetOf(target)
+	if !targetVal.IsValid() || targetVal.IsZero() {
+		return errors.New("target is null")
+	}
+	if targetType := targetVal.Type(); targetType.Kind() != reflect.Func &&
+		!(targetType.Kind() == reflect.Pointer && targetType.Elem().Kind() == reflect.Struct) {
+		return errors.New("target should be func or *struct")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			if recoveredErr, ok := r.(error); ok {
+				err = fmt.Errorf("panic: %w", recoveredErr)
+			} else {
+				err = fmt.Errorf("panic: %v", r)
+			}
+		}
+	}()
+	InjectFromC(container, targetVal)
+	return nil
+}
+
+// typeNameOfTarget to get type name of target, unwrapping 'reflect.Value'.
+func typeNameOfTarget(target any) string {
+	if targetVal := injectTargetOf(target); targetVal.IsValid() {
+		return targetVal.Type().String()
+	}
+	return "nil"
+}
@@ -0,0 +1,81 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInjectAll(t *testing.T) {
+	t.Run("inject all should inject to func and *struct targets", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		var target buildTarget
+		var s1 service1
+		fn := func(s service1) { s1 = s }
+
+		if err := InjectAll(&target, fn, reflect.ValueOf(&target)); err != nil {
+			t.Error(err)
+			return
+		}
+		if target.S1 == nil || s1 == nil {
+			t.Error("all targets should be injected")
+			return
+		}
+	})
+
+	t.Run("inject all should skip invalid targets and report them", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		var target buildTarget
+		var s1 service1
+
+		err := InjectAll(nil, 1, func(s service1, _ service2) { s1 = s }, &target)
+		var aggregateErr *AggregateError
+		if !errors.As(err, &aggregateErr) || len(aggregateErr.Errors) != 3 {
+			t.Errorf("errors of invalid targets should be aggregated, but %v", err)
+			return
+		}
+		message := err.Error()
+		if !strings.Contains(message, "target[0] 'nil'") || !strings.Contains(message, "target[1] 'int'") ||
+			!strings.Contains(message, "target[2] 'func(ioc.service1, ioc.service2)'") {
+			t.Errorf("error should report index and type of targets failed, but %s", message)
+			return
+		}
+		if s1 != nil {
+			t.Error("func failed to resolve params should not be called")
+			return
+		}
+		if target.S1 == nil {
+			t.Error("valid target after failed ones should be injected")
+			return
+		}
+		if InjectAllFromC(nil, &target) == nil {
+			t.Error("nil container should fail")
+			return
+		}
+	})
+}
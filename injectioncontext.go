@@ -0,0 +1,97 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync"
+)
+
+// InjectionContext is the metadata of injection, which is injected to field of it's type without tag,
+// so a logger or tracer embedded in a service can know the type it lives in.
+// It's immutable, and copying it is cheap.
+//
+//	type OrderService struct {
+//	    Ctx ioc.InjectionContext
+//	}
+//
+//	func (s *OrderService) Initialize() {
+//	    s.logger = log.New(os.Stderr, s.Ctx.Target().String()+" ", log.LstdFlags)
+//	}
+type InjectionContext struct {
+	target    reflect.Type
+	container Resolver
+	chain     []reflect.Type
+}
+
+var injectionContextType reflect.Type = TypeOf[InjectionContext]()
+
+// Target to get type of the instance injected to, such as '*OrderService'.
+func (ic InjectionContext) Target() reflect.Type {
+	return ic.target
+}
+
+// Container to get the resolver which injects to the instance.
+func (ic InjectionContext) Container() Resolver {
+	return ic.container
+}
+
+// Chain to get services being created in the resolving call when injecting, outermost first,
+// so the last one is the service being resolved for the instance. It's empty if the instance is not injected by resolving,
+// such as by 'ioc.Inject'.
+func (ic InjectionContext) Chain() []reflect.Type {
+	return append([]reflect.Type(nil), ic.chain...)
+}
+
+// structTypeToContextFieldsCache is the cache of index of 'InjectionContext' fields by struct type.
+var structTypeToContextFieldsCache sync.Map
+
+// getContextFields to get index of exported and not embedded fields of type 'InjectionContext'.
+func getContextFields(structType reflect.Type) []int {
+	if val, ok := structTypeToContextFieldsCache.Load(structType); ok {
+		return val.([]int)
+	}
+	var fields []int
+	for i := 0; i < structType.NumField(); i++ {
+		if field := structType.Field(i); field.IsExported() && !field.Anonymous && field.Type == injectionContextType {
+			fields = append(fields, i)
+		}
+	}
+	structTypeToContextFieldsCache.Store(structType, fields)
+	return fields
+}
+
+// injectContext to inject metadata of injection in the call of 'path' to fields of type 'InjectionContext' of *struct.
+func injectContext(container Container, targetVal reflect.Value, path *resolvePath) {
+	fields := getContextFields(targetVal.Type().Elem())
+	if len(fields) == 0 {
+		return
+	}
+	ctx := reflect.ValueOf(InjectionContext{
+		target:    targetVal.Type(),
+		container: container,
+		chain:     path.types(),
+	})
+	for _, index := range fields {
+		targetVal.Elem().Field(index).Set(ctx)
+	}
+}
@@ -0,0 +1,86 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+type contextAwareService struct {
+	Ctx   InjectionContext
+	Inner *contextAwareInner `ioc-inject:"true"`
+}
+
+type contextAwareInner struct {
+	Ctx InjectionContext
+}
+
+func TestInjectionContext(t *testing.T) {
+	t.Run("struct should receive context describing itself", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*contextAwareInner](&contextAwareInner{})
+		AddSingleton[*contextAwareService](&contextAwareService{})
+		svc := GetService[*contextAwareService]()
+		if svc.Ctx.Target() != TypeOf[*contextAwareService]() || svc.Ctx.Container() != globalContainer {
+			t.Errorf("context should describe the service, but target '%v'", svc.Ctx.Target())
+			return
+		}
+		if chain := svc.Ctx.Chain(); !reflect.DeepEqual(chain, []reflect.Type{TypeOf[*contextAwareService]()}) {
+			t.Errorf("chain should be the service resolved, but %v", chain)
+			return
+		}
+		inner := svc.Inner
+		if inner.Ctx.Target() != TypeOf[*contextAwareInner]() {
+			t.Errorf("context of dependency should describe the dependency, but target '%v'", inner.Ctx.Target())
+			return
+		}
+		expected := []reflect.Type{TypeOf[*contextAwareService](), TypeOf[*contextAwareInner]()}
+		if chain := inner.Ctx.Chain(); !reflect.DeepEqual(chain, expected) {
+			t.Errorf("chain of dependency should start from the service resolved, but %v", chain)
+			return
+		}
+	})
+
+	t.Run("context should be immutable", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*contextAwareInner](&contextAwareInner{})
+		inner := GetService[*contextAwareInner]()
+		inner.Ctx.Chain()[0] = nil
+		if inner.Ctx.Chain()[0] != TypeOf[*contextAwareInner]() {
+			t.Error("chain should not be changed by caller")
+			return
+		}
+	})
+
+	t.Run("injecting directly should have empty chain", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		AddSingleton[*contextAwareInner](&contextAwareInner{})
+		svc := &contextAwareService{}
+		Inject(svc)
+		if svc.Ctx.Target() != TypeOf[*contextAwareService]() || len(svc.Ctx.Chain()) != 0 || svc.Inner == nil {
+			t.Error("context should be injected without chain")
+			return
+		}
+	})
+}
@@ -0,0 +1,139 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// Invalidate to drop initialized instance of singleton 'TService' and singletons depend on it in global container.
+func Invalidate[TService any]() {
+	InvalidateToC[TService](globalContainer)
+}
+
+// InvalidateToC to drop initialized instance of singleton 'TService' and singletons depend on it in container.
+func InvalidateToC[TService any](container Container) {
+	container.(Invalidator).Invalidate(TypeOf[TService]())
+}
+
+// Invalidator is implemented by container to drop cached singletons, so they're created again.
+type Invalidator interface {
+	// Invalidate to drop cached instance of singleton, and singletons depend on it transitively in current container and ancestors,
+	// so the next resolving will create new ones. Lazy singleton is created by its factory again, and singleton registered with
+	// instance of struct pointer is initialized from a shallow copy of the instance, that is injecting to fields and invoking
+	// initialize method and callback of the copy. Other singletons are kept, and dependencies of lazy singletons are unknown
+	// so they are not invalidated as dependents.
+	// Resolving in progress will finish before invalidated, and instances got before invalidated are not changed.
+	//
+	//  // reload config
+	//  container.(ioc.Invalidator).Invalidate(TypeOf[Config]())
+	Invalidate(serviceType reflect.Type)
+}
+
+var _ Invalidator = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Invalidate(serviceType reflect.Type) {
+	if serviceType == nil {
+		return
+	}
+	invalidated := map[reflect.Type]bool{serviceType: true}
+	queue := []reflect.Type{serviceType}
+	for len(queue) > 0 {
+		depType := queue[0]
+		queue = queue[1:]
+		if binding := c.lookupBinding(depType); binding != nil {
+			c.untrackInitialized(binding.invalidate())
+		}
+		for _, dependent := range c.dependentsOf(depType) {
+			c.untrackInitialized(dependent.invalidate())
+			if !invalidated[dependent.ServiceType] {
+				invalidated[dependent.ServiceType] = true
+				queue = append(queue, dependent.ServiceType)
+			}
+		}
+	}
+}
+
+// dependentsOf to get singleton bindings depend on the service directly, in current container and ancestors.
+func (c *defaultContainer) dependentsOf(serviceType reflect.Type) []*serviceBinding {
+	var dependents []*serviceBinding
+	for container := c; container != nil; {
+		for _, binding := range container.singletonBindings() {
+			for _, depType := range binding.dependencies() {
+				if depType == serviceType {
+					dependents = append(dependents, binding)
+					break
+				}
+			}
+		}
+		container, _ = container.parent.(*defaultContainer)
+	}
+	return dependents
+}
+
+// invalidate to drop cached instance, so a new one is created by factory of lazy singleton, or initialized from a copy of
+// registered instance, on next resolving. It returns the instance initialized before, if it's dropped.
+func (b *serviceBinding) invalidate() reflect.Value {
+	b = b.implementation()
+	if b.Lazy {
+		if b.Weak != nil {
+			b.Weak.reset()
+		}
+		if _, ok := b.Memo.Load().(*memoCache); ok {
+			b.Memo.Store(newLazyMemo())
+		}
+		return reflect.Value{}
+	}
+	if b.Instance.Kind() != reflect.Pointer || b.Instance.Elem().Kind() != reflect.Struct {
+		// instance which can't be copied is kept, instead of injecting to it again
+		return reflect.Value{}
+	}
+	for {
+		b.initializerLocker.Lock()
+		if b.state != bindingInitializing {
+			break
+		}
+		done := b.done
+		b.initializerLocker.Unlock()
+		<-done
+	}
+	defer b.initializerLocker.Unlock()
+	if b.state != bindingInitialized {
+		return reflect.Value{}
+	}
+	injected := b.injected
+	b.InitializedInstance.Store(reflect.Value{})
+	b.injected = reflect.Value{}
+	b.state = bindingUninitialized
+	b.invalidated = true
+	return injected
+}
+
+// copyInstance to copy registered instance if it's pointer to struct, otherwise the instance itself is returned.
+func (b *serviceBinding) copyInstance() reflect.Value {
+	instance := b.Instance
+	if instance.Kind() == reflect.Pointer && instance.Elem().Kind() == reflect.Struct {
+		instance = reflect.New(instance.Type().Elem())
+		instance.Elem().Set(b.Instance.Elem())
+	}
+	return instance
+}
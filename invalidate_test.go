@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestInvalidate(t *testing.T) {
+	t.Run("invalidate should cascade to dependents", func(t *testing.T) {
+		globalContainer = New()
+		version := "v1"
+		AddTransient[service1](func() service1 { return &serviceInstance1{name: version} })
+		Rebind[service1](LifetimeSingleton)
+		AddSingleton[*invalidateClient](&invalidateClient{})
+		AddSingleton[*invalidateClient2](&invalidateClient2{})
+
+		client := GetService[*invalidateClient]()
+		client2 := GetService[*invalidateClient2]()
+		if client2.Client.Config.GetName() != "v1" || client2.initialized != 1 {
+			t.Error("dependents should be initialized")
+			return
+		}
+
+		version = "v2"
+		Invalidate[service1]()
+		if GetService[service1]().GetName() != "v2" {
+			t.Error("lazy singleton should be created by factory again")
+			return
+		}
+		if newClient := GetService[*invalidateClient](); newClient == client || newClient.Config.GetName() != "v2" {
+			t.Error("direct dependent should be initialized again as a new instance")
+			return
+		}
+		if newClient2 := GetService[*invalidateClient2](); newClient2 == client2 || newClient2.Client.Config.GetName() != "v2" {
+			t.Error("transitive dependent should be initialized again as a new instance")
+			return
+		}
+		if client.Config.GetName() != "v1" || client2.initialized != 1 {
+			t.Error("instances got before invalidated should not be changed")
+			return
+		}
+	})
+
+	t.Run("invalidate service not registered should be ignored", func(t *testing.T) {
+		globalContainer = New()
+		Invalidate[service1]()
+		globalContainer.(Invalidator).Invalidate(nil)
+	})
+}
+
+type invalidateClient struct {
+	Config service1 `ioc-inject:"true"`
+}
+
+type invalidateClient2 struct {
+	Client      *invalidateClient
+	initialized int
+}
+
+func (c *invalidateClient2) Initialize(client *invalidateClient) {
+	c.Client = client
+	c.initialized++
+}
@@ -0,0 +1,142 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// methodKey is the key of method index cache.
+type methodKey struct {
+	Type reflect.Type
+	Name string
+}
+
+// methodIndexCache is the cache of method index by type and name, -1 if not found.
+var methodIndexCache sync.Map
+
+var errorType reflect.Type = TypeOf[error]()
+
+// Invoke to call func with params resolved from container, and returns it's result as 'R'.
+// The func should return 'R' or '(R, error)'.
+//
+// It will panic if 'fn' is invalid, or it returns error.
+//
+//	server := ioc.Invoke[*http.Server](container, func(handler http.Handler, cfg *Config) *http.Server {
+//	    return &http.Server{Addr: cfg.Addr, Handler: handler}
+//	})
+func Invoke[R any](c Container, fn any) R {
+	result, err := InvokeE[R](c, fn)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
+
+// InvokeE to call func with params resolved from container, and returns it's result as 'R' and the error.
+// The func should return 'R' or '(R, error)', and it's result type should be assignable to 'R'.
+//
+//	db, err := ioc.InvokeE[*sql.DB](container, func(cfg *Config) (*sql.DB, error) {
+//	    return sql.Open("mysql", cfg.DSN)
+//	})
+func InvokeE[R any](c Container, fn any) (R, error) {
+	var result R
+	if c == nil {
+		return result, errors.New("param 'c' is null")
+	}
+	if fn == nil {
+		return result, errors.New("param 'fn' is null")
+	}
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func || fnVal.IsNil() {
+		return result, fmt.Errorf("param 'fn' should be func, but '%T'", fn)
+	}
+	fnType := fnVal.Type()
+	if fnType.NumOut() == 0 || fnType.NumOut() > 2 || fnType.NumOut() == 2 && fnType.Out(1) != errorType {
+		return result, fmt.Errorf("func '%v' should return 'R' or '(R, error)'", fnType)
+	}
+	if resultType := TypeOf[R](); !fnType.Out(0).AssignableTo(resultType) {
+		return result, fmt.Errorf("result '%v' of func is not assignable to '%v'", fnType.Out(0), resultType)
+	}
+	outs := callWithServices(c, fnVal, nil)
+	if len(outs) == 2 && !outs[1].IsNil() {
+		return result, outs[1].Interface().(error)
+	}
+	result, _ = outs[0].Interface().(R)
+	return result, nil
+}
+
+// InvokeMethod to call method of instance with params resolved from global container, and returns it's results.
+//
+//	type Client struct {
+//	    service Service1
+//	}
+//	func(c *Client) Configure(s Service1) error {
+//	    c.service = s
+//	    return nil
+//	}
+//
+//	results := ioc.InvokeMethod(&Client{}, "Configure")
+func InvokeMethod(instance any, methodName string) []reflect.Value {
+	return globalContainer.(MethodInvoker).InvokeMethod(instance, methodName)
+}
+
+// MethodInvoker is implemented by container to call methods with params resolved.
+type MethodInvoker interface {
+	// InvokeMethod to call method of instance with params resolved from container, and returns it's results.
+	// It returns nil if instance is nil or method not found, and the same as 'Inject' for missing params.
+	//
+	//  var container ioc.Container
+	//  results := container.(ioc.MethodInvoker).InvokeMethod(client, "Configure")
+	InvokeMethod(instance any, methodName string) []reflect.Value
+}
+
+var _ MethodInvoker = (*defaultContainer)(nil)
+
+func (c *defaultContainer) InvokeMethod(instance any, methodName string) []reflect.Value {
+	instanceVal := injectTargetOf(instance)
+	if !instanceVal.IsValid() || instanceVal.Kind() == reflect.Pointer && instanceVal.IsNil() || methodName == "" {
+		return nil
+	}
+	index := methodIndexOf(instanceVal.Type(), methodName)
+	if index < 0 {
+		return nil
+	}
+	return callWithServices(c, instanceVal.Method(index), nil)
+}
+
+// methodIndexOf to get index of exported method by name in method set of type, returns -1 if not found.
+func methodIndexOf(instanceType reflect.Type, methodName string) int {
+	key := methodKey{Type: instanceType, Name: methodName}
+	if val, ok := methodIndexCache.Load(key); ok {
+		return val.(int)
+	}
+	index := -1
+	if method, ok := instanceType.MethodByName(methodName); ok {
+		index = method.Index
+	}
+	methodIndexCache.Store(key, index)
+	return index
+}
@@ -0,0 +1,168 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"testing"
+)
+
+type configurableClient struct {
+	s1 service1
+	s2 service2
+}
+
+func (c *configurableClient) Configure(s1 service1, s2 service2) (string, error) {
+	c.s1 = s1
+	c.s2 = s2
+	if s2 == nil {
+		return "", errors.New("service2 not found")
+	}
+	return s1.GetName(), nil
+}
+
+func TestInvokeMethod(t *testing.T) {
+	t.Run("invoke method should resolve params and return results", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+		AddSingleton[service2](&serviceInstance2{name: "s2"})
+		client := &configurableClient{}
+
+		results := InvokeMethod(client, "Configure")
+		if len(results) != 2 {
+			t.Errorf("should return 2 results, but %d", len(results))
+			return
+		}
+		if results[0].String() != "s1" || !results[1].IsNil() {
+			t.Error("should return results of method")
+			return
+		}
+		if client.s1 == nil || client.s2 == nil || client.s2.GetName() != "s2" {
+			t.Error("params should be resolved from container")
+			return
+		}
+	})
+
+	t.Run("invoke method with missing params should pass zero value", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+
+		results := InvokeMethod(&configurableClient{}, "Configure")
+		if len(results) != 2 || results[1].IsNil() {
+			t.Error("missing param should be zero value")
+			return
+		}
+	})
+
+	t.Run("invoke method not found should return nil", func(t *testing.T) {
+		globalContainer = New()
+		if InvokeMethod(&configurableClient{}, "NotExists") != nil {
+			t.Error("method not found should return nil")
+			return
+		}
+		if InvokeMethod(&configurableClient{}, "configure") != nil {
+			t.Error("unexported method should return nil")
+			return
+		}
+		var client *configurableClient
+		if InvokeMethod(client, "Configure") != nil || InvokeMethod(nil, "Configure") != nil {
+			t.Error("nil instance should return nil")
+			return
+		}
+	})
+}
+
+func TestInvoke(t *testing.T) {
+	t.Run("invoke should resolve params and return result", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+		AddSingleton[service2](&serviceInstance2{name: "s2"})
+
+		client := Invoke[*configurableClient](globalContainer, func(s1 service1, s2 service2) *configurableClient {
+			return &configurableClient{s1: s1, s2: s2}
+		})
+		if client == nil || client.s1.GetName() != "s1" || client.s2.GetName() != "s2" {
+			t.Error("params should be resolved from container")
+			return
+		}
+		// result assignable to interface
+		svc := Invoke[service1](globalContainer, func(s2 service2) *serviceInstance1 {
+			return &serviceInstance1{name: s2.GetName() + "-derived"}
+		})
+		if svc == nil || svc.GetName() != "s2-derived" {
+			t.Error("result should be returned as 'R'")
+			return
+		}
+		if Invoke[service1](globalContainer, func() service1 { return nil }) != nil {
+			t.Error("nil result should be zero value")
+			return
+		}
+	})
+
+	t.Run("invoke with error should return error", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+
+		name, err := InvokeE[string](globalContainer, func(s1 service1) (string, error) {
+			return s1.GetName(), nil
+		})
+		if err != nil || name != "s1" {
+			t.Errorf("result should be returned, but %v", err)
+			return
+		}
+		if _, err = InvokeE[string](globalContainer, func(s2 service2) (string, error) {
+			return "", errors.New("service2 not found")
+		}); err == nil || err.Error() != "service2 not found" {
+			t.Errorf("error of func should be returned, but %v", err)
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("error of func should panic")
+			}
+		}()
+		Invoke[string](globalContainer, func() (string, error) { return "", errors.New("fail") })
+	})
+
+	t.Run("invalid func should fail", func(t *testing.T) {
+		globalContainer = New()
+		invalids := []any{
+			nil,
+			"not func",
+			(func() string)(nil),
+			func() {},
+			func() (string, string) { return "", "" },
+			func() (string, error, error) { return "", nil, nil },
+			func() int { return 0 },
+		}
+		for _, fn := range invalids {
+			if _, err := InvokeE[string](globalContainer, fn); err == nil {
+				t.Errorf("invalid func '%T' should fail", fn)
+				return
+			}
+		}
+		if _, err := InvokeE[string](nil, func() string { return "" }); err == nil {
+			t.Error("null container should fail")
+			return
+		}
+	})
+}
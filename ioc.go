@@ -28,7 +28,12 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 const DefaultInitializeMethodName string = "Initialize"
@@ -40,8 +45,40 @@ type CustomInitializer interface {
 	InitializeMethodName() string
 }
 
+// SelfInjector can inject dependencies by itself, instead of reflection-based injection to fields tagged with 'ioc-inject'.
+// It's useful for code generators or hand-written types on hot paths.
+type SelfInjector interface {
+	// InjectDeps to inject dependencies resolved from resolver.
+	InjectDeps(resolver Resolver)
+}
+
 var globalContainer Container = New()
-var resolverType reflect.Type = reflect.TypeOf((*Resolver)(nil)).Elem()
+var resolverType reflect.Type = TypeOf[Resolver]()
+
+// TypeOf to get type of 'T', which works for both interface and *struct, to use with methods of 'ioc.Container'.
+//
+//	err := container.AddSingleton(ioc.TypeOf[Service1](), &ServiceImplementation1{})
+//	err = container.AddSingleton(ioc.TypeOf[*ServiceImplementation1](), &ServiceImplementation1{})
+func TypeOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// TypesOf2 to get types of 'T1' and 'T2' in order, the same as 'ioc.TypeOf' for each, to register or resolve several types at once.
+//
+//	err := container.(ioc.Warmer).Warm(ioc.TypesOf2[Service1, *ServiceImplementation2]()...)
+func TypesOf2[T1, T2 any]() []reflect.Type {
+	return []reflect.Type{TypeOf[T1](), TypeOf[T2]()}
+}
+
+// TypesOf3 to get types of 'T1', 'T2' and 'T3' in order, the same as 'ioc.TypeOf' for each.
+func TypesOf3[T1, T2, T3 any]() []reflect.Type {
+	return []reflect.Type{TypeOf[T1](), TypeOf[T2](), TypeOf[T3]()}
+}
+
+// TypesOf4 to get types of 'T1', 'T2', 'T3' and 'T4' in order, the same as 'ioc.TypeOf' for each.
+func TypesOf4[T1, T2, T3, T4 any]() []reflect.Type {
+	return []reflect.Type{TypeOf[T1](), TypeOf[T2](), TypeOf[T3](), TypeOf[T4]()}
+}
 
 // New ioc container, and add singleton service 'ioc.Resolver' to it.
 func New() Container {
@@ -103,6 +140,8 @@ type Container interface {
 // Resolver can resolve service.
 type Resolver interface {
 	// Set parent resolver, for resolving from parent if service not found in current.
+	// If current already has parent, it will be set as parent of the last ancestor.
+	// It will panic if cycle would form in the parent chain.
 	SetParent(parent Resolver)
 
 	// Resolve to get service.
@@ -156,13 +195,89 @@ func AddSingleton[TService any](instance TService) {
 //
 // It will panic if 'TService' or 'instance' is invalid.
 func AddSingletonToC[TService any](container Container, instance TService) {
-	err := container.AddSingleton(reflect.TypeOf((*TService)(nil)).Elem(), instance)
+	err := container.AddSingleton(TypeOf[TService](), instance)
+	if err != nil {
+		panic(err)
+	}
+	getFieldsToInject(reflect.ValueOf(instance).Type())
+}
+
+// GetOrAddSingleton to get service from global container if exists, otherwise create singleton instance by 'instanceFactory', add and resolve it.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func GetOrAddSingleton[TService any](instanceFactory func() TService) TService {
+	return GetOrAddSingletonToC[TService](globalContainer, instanceFactory)
+}
+
+// GetOrAddSingletonToC to get service from container if exists, otherwise create singleton instance by 'instanceFactory', add and resolve it.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func GetOrAddSingletonToC[TService any](container Container, instanceFactory func() TService) TService {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	val, err := container.(GetOrAdder).GetOrAddSingleton(TypeOf[TService](), func() any {
+		return instanceFactory()
+	})
+	if err != nil {
+		panic(err)
+	}
+	var service TService
+	if val.IsValid() && !val.IsZero() {
+		service = val.Interface().(TService)
+	}
+	return service
+}
+
+// AddSingletonWithInit to add singleton instance with a post-construct callback, instead of requiring an initialize method.
+// The callback will be invoked once on first resolving, after injecting to fields and it's initialize method.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+//
+//	ioc.AddSingletonWithInit[Service1](&ServiceImplementation1{}, func(instance Service1, resolver ioc.Resolver) {
+//	    instance.(*ServiceImplementation1).resolver = resolver
+//	})
+func AddSingletonWithInit[TService any](instance TService, init func(instance TService, resolver Resolver)) {
+	AddSingletonWithInitToC[TService](globalContainer, instance, init)
+}
+
+// AddSingletonWithInitToC to add singleton instance with a post-construct callback to container.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+func AddSingletonWithInitToC[TService any](container Container, instance TService, init func(instance TService, resolver Resolver)) {
+	if init == nil {
+		panic("param 'init' is null")
+	}
+	err := container.(InitAdder).AddSingletonWithInit(TypeOf[TService](), instance, func(instance any, resolver Resolver) {
+		init(instance.(TService), resolver)
+	})
 	if err != nil {
 		panic(err)
 	}
 	getFieldsToInject(reflect.ValueOf(instance).Type())
 }
 
+// WithSingleton to add singleton instance temporarily for the lifetime of 'fn', and restore the existing service after 'fn' returns.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+//
+//	ioc.WithSingleton[Clock](&FakeClock{}, func() {
+//	    clock := ioc.GetService[Clock]() // *FakeClock
+//	})
+func WithSingleton[TService any](instance TService, fn func()) {
+	WithSingletonToC[TService](globalContainer, instance, fn)
+}
+
+// WithSingletonToC to add singleton instance to container temporarily for the lifetime of 'fn'.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+func WithSingletonToC[TService any](container Container, instance TService, fn func()) {
+	err := container.(TemporaryOverrider).WithSingleton(TypeOf[TService](), instance, fn)
+	if err != nil {
+		panic(err)
+	}
+}
+
 // AddTransient to add transient service instance factory.
 //
 // It will panic if 'TService' or 'instance' is invalid.
@@ -196,7 +311,7 @@ func AddTransientToC[TService any](container Container, instanceFactory func() T
 	if instanceFactory == nil {
 		panic("param 'instanceFactory' is null")
 	}
-	err := container.AddTransient(reflect.TypeOf((*TService)(nil)).Elem(), func() any {
+	err := container.AddTransient(TypeOf[TService](), func() any {
 		return instanceFactory()
 	})
 	if err != nil {
@@ -227,7 +342,7 @@ func GetService[TService any]() TService {
 // GetServiceFromC to get service from container.
 func GetServiceFromC[TService any](container Container) TService {
 	var instance TService
-	instanceVal := container.Resolve(reflect.TypeOf((*TService)(nil)).Elem())
+	instanceVal := container.Resolve(TypeOf[TService]())
 	if !instanceVal.IsValid() {
 		return instance
 	}
@@ -240,8 +355,30 @@ func GetServiceFromC[TService any](container Container) TService {
 	return instance
 }
 
+// SafeResolve to resolve service from container, and convert panic during resolving into error,
+// which includes the recovered value and the service type.
+//
+//	val, err := ioc.SafeResolve(container, reflect.TypeOf((*Service1)(nil)).Elem())
+func SafeResolve(c Container, serviceType reflect.Type) (val reflect.Value, err error) {
+	if c == nil {
+		return reflect.Value{}, errors.New("param 'c' is null")
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			val = reflect.Value{}
+			if recoveredErr, ok := r.(error); ok {
+				err = fmt.Errorf("resolve service '%v' panic: %w", serviceType, recoveredErr)
+			} else {
+				err = fmt.Errorf("resolve service '%v' panic: %v", serviceType, r)
+			}
+		}
+	}()
+	return c.Resolve(serviceType), nil
+}
+
 // Inject to func or *struct with service.
 // Field with type 'ioc.Resolver', will always been injected.
+// *struct implements 'ioc.SelfInjector' will inject by itself instead.
 //
 //	// service
 //	type Service1 interface {
@@ -274,48 +411,230 @@ func Inject(target any) {
 }
 
 // InjectFromC to inject to func or *struct or their's reflect.Value with service from container.
-// Field with type 'ioc.Resolver', will always been injected.
+// Field with type 'ioc.Resolver' or 'ioc.InjectionContext', will always been injected.
 func InjectFromC(container Container, target any) {
-	var targetVal reflect.Value
-	if val, ok := target.(reflect.Value); ok {
-		targetVal = val
-	} else {
-		targetVal = reflect.ValueOf(target)
-	}
+	injectFrom(container, target, nil)
+}
+
+// injectFrom to inject to target with services from container, in the call of 'path' which is nil for a new call.
+func injectFrom(container Container, target any, path *resolvePath) {
+	targetVal := injectTargetOf(target)
 	if !targetVal.IsValid() || targetVal.IsZero() {
 		return
 	}
 	targetType := targetVal.Type()
 	if targetType.Kind() == reflect.Func {
 		// inject to func
-		var in = make([]reflect.Value, targetType.NumIn())
-		for i := 0; i < targetType.NumIn(); i++ {
-			argType := targetType.In(i)
-			val := container.Resolve(argType)
-			if !val.IsValid() {
-				in[i] = reflect.Zero(argType)
-			} else {
-				in[i] = val
-			}
-		}
-		targetVal.Call(in)
+		callWithServices(container, targetVal, path)
 	} else if targetType.Kind() == reflect.Pointer && targetType.Elem().Kind() == reflect.Struct {
-		// skip implementation of ioc.Resolver
-		if targetType.Implements(resolverType) {
+		// skip implementation of ioc.Resolver, and singleton sealed
+		if targetType.Implements(resolverType) || isSealed(container, targetVal) {
 			return
 		}
 
+		// prefer injecting by itself without reflection
+		if targetVal.CanInterface() {
+			if selfInjector, ok := targetVal.Interface().(SelfInjector); ok {
+				selfInjector.InjectDeps(container)
+				return
+			}
+		}
+
 		// inject to *struct
 		structType := targetType.Elem()
-		fields := getFieldsToInject(structType)
+		var fields []structField
+		if c, ok := container.(*defaultContainer); ok {
+			fields = c.fieldsToInject(structType)
+		} else {
+			fields = getFieldsToInject(structType)
+		}
+		injectContext(container, targetVal, path)
+		recorder := path.recorderOf()
 		for _, field := range fields {
-			fieldVal := targetVal.Elem().Field(field.FieldIndex)
-			val := container.Resolve(field.FieldType)
+			if recorder != nil {
+				recorder.start(field.FieldName, field.FieldType)
+			}
+			var val reflect.Value
+			if field.When == "" || conditionHolds(container, field.When) {
+				val = resolveField(container, field, path)
+			}
 			if val.IsValid() {
-				fieldVal.Set(val)
+				targetVal.Elem().Field(field.FieldIndex).Set(val)
+			}
+			if recorder != nil {
+				recorder.finish(container, val.IsValid() && !val.IsZero())
+			}
+		}
+		if c, ok := container.(*defaultContainer); ok && c.autoInjects(structType) {
+			c.injectUntagged(targetVal, structType, path)
+		}
+	}
+}
+
+// callWithServices to call func with params resolved from container in the call of 'path', and zero value for missing ones.
+func callWithServices(container Resolver, fn reflect.Value, path *resolvePath) []reflect.Value {
+	c, isDefault := container.(*defaultContainer)
+	recorder := path.recorderOf()
+	fnType := fn.Type()
+	var in = make([]reflect.Value, fnType.NumIn())
+	for i := 0; i < fnType.NumIn(); i++ {
+		argType := fnType.In(i)
+		if recorder != nil {
+			recorder.start(fmt.Sprintf("#%d", i), argType)
+		}
+		var val reflect.Value
+		if isDefault {
+			val = c.resolveRequired(argType, path)
+		} else {
+			val = container.Resolve(argType)
+		}
+		if recorder != nil {
+			recorder.finish(container, val.IsValid())
+		}
+		if !val.IsValid() {
+			in[i] = reflect.Zero(argType)
+		} else {
+			in[i] = val
+		}
+	}
+	return fn.Call(in)
+}
+
+// injectTargetOf to normalize target of injection, which is func or *struct.
+// For 'reflect.Value', interface is unwrapped, and addressable struct is converted to it's address.
+func injectTargetOf(target any) reflect.Value {
+	targetVal, ok := target.(reflect.Value)
+	if !ok {
+		return reflect.ValueOf(target)
+	}
+	for targetVal.IsValid() && targetVal.Kind() == reflect.Interface {
+		targetVal = targetVal.Elem()
+	}
+	if targetVal.IsValid() && targetVal.Kind() == reflect.Struct && targetVal.CanAddr() {
+		targetVal = targetVal.Addr()
+	}
+	return targetVal
+}
+
+// resolveField to resolve service for field in the call of 'path', from parent if 'from=parent'.
+//
+// Slice field with group is filled from 'ResolveGroup', other slice field from 'ResolveAll' of it's element type,
+// and map field is filled from 'ResolveKeyedMap'.
+// Others are resolved with precedence: explicit key > field name (if enabled by 'SetFieldNameAsKey') > type-only.
+func resolveField(container Container, field structField, path *resolvePath) reflect.Value {
+	recorder := path.recorderOf()
+	if field.HasConfig {
+		if recorder != nil {
+			recorder.found(locateConfig(container, field.ConfigKey), LifetimeUnknown)
+		}
+		return configValueOf(container, field.ConfigKey, field.FieldType)
+	}
+	if field.FromParent {
+		// bypass current container, and leave zero value if no parent
+		switch parent := container.(Hierarchical).Parent().(type) {
+		case Container:
+			container = parent
+		case nil:
+			return reflect.Value{}
+		default:
+			if recorder != nil {
+				recorder.found(parent, LifetimeUnknown)
 			}
+			return parent.Resolve(field.FieldType)
+		}
+	}
+	if field.OptionalOf != nil {
+		return resolveOptional(container, field, path)
+	}
+	if field.ProviderOf != nil {
+		return resolveProvider(container, field)
+	}
+	c, isDefault := container.(*defaultContainer)
+	switch {
+	case field.Group != "" && field.FieldType.Kind() == reflect.Slice:
+		elemType := field.FieldType.Elem()
+		var instances []reflect.Value
+		if isDefault {
+			instances = c.resolveGroup(field.Group, c, path)
+		} else {
+			instances = container.(Grouper).ResolveGroup(field.Group)
+		}
+		slice := reflect.MakeSlice(field.FieldType, 0, len(instances))
+		for _, instance := range instances {
+			// skip element type mismatched
+			if instance.IsValid() && instance.Type().AssignableTo(elemType) {
+				slice = reflect.Append(slice, instance)
+			}
+		}
+		return slice
+	case field.FieldType.Kind() == reflect.Slice:
+		elemType := field.FieldType.Elem()
+		var instances []reflect.Value
+		if isDefault {
+			instances = sortByWeight(c.resolveAllIn(elemType, path))
+		} else {
+			instances = sortByWeight(container.(AllResolver).ResolveAll(elemType))
+		}
+		slice := reflect.MakeSlice(field.FieldType, 0, len(instances))
+		for _, instance := range instances {
+			if instance.IsValid() && instance.Type().AssignableTo(elemType) {
+				slice = reflect.Append(slice, instance)
+			}
+		}
+		return slice
+	case field.FieldType.Kind() == reflect.Map:
+		keyType := field.FieldType.Key()
+		instances := make(map[any]reflect.Value)
+		if isDefault {
+			c.resolveKeyedMap(field.FieldType.Elem(), c, instances, path)
+		} else {
+			instances = container.(KeyedContainer).ResolveKeyedMap(field.FieldType.Elem())
+		}
+		m := reflect.MakeMapWithSize(field.FieldType, len(instances))
+		for key, instance := range instances {
+			keyVal := reflect.ValueOf(key)
+			// skip key type mismatched
+			if instance.IsValid() && keyVal.Type().AssignableTo(keyType) {
+				m.SetMapIndex(keyVal, instance)
+			}
+		}
+		return m
+	}
+	if !isDefault {
+		switch {
+		case field.HasKey:
+			return container.(KeyedContainer).ResolveKeyed(field.Key, field.FieldType)
+		case isFieldNameAsKey(container):
+			if val := container.(KeyedContainer).ResolveKeyed(field.FieldName, field.FieldType); val.IsValid() {
+				return val
+			}
+		}
+		return container.Resolve(field.FieldType)
+	}
+	switch {
+	case field.HasKey:
+		return c.resolveKeyed(field.Key, field.FieldType, c, path)
+	case c.isFieldNameAsKey():
+		if val := c.resolveKeyed(field.FieldName, field.FieldType, c, path); val.IsValid() {
+			return val
+		}
+	}
+	if field.Optional {
+		// optional field won't panic in strict mode
+		return c.resolveLenient(field.FieldType, path)
+	}
+	if path == nil {
+		if instance, ok := c.initializedInstance(field.FieldType); ok {
+			return instance
 		}
 	}
+	return c.resolveRequired(field.FieldType, path)
+}
+
+// isFieldNameAsKey to check whether container resolves field by it's name as key, see 'SetFieldNameAsKey'.
+func isFieldNameAsKey(container Container) bool {
+	c, ok := container.(interface{ isFieldNameAsKey() bool })
+	return ok && c.isFieldNameAsKey()
 }
 
 // Set parent resolver, for resolving from parent if service not found in current.
@@ -326,86 +645,354 @@ func SetParent(parent Resolver) {
 var structTypeToFieldsCache sync.Map
 
 func getFieldsToInject(targetType reflect.Type) []structField {
+	fields, _ := walkFieldsToInject(targetType, 0)
+	return fields
+}
+
+// walkFieldsToInject to get fields to inject, and stop walking with false once count of them exceeds 'limit' if it's positive.
+func walkFieldsToInject(targetType reflect.Type, limit int) ([]structField, bool) {
 	structType := targetType
 	for structType.Kind() == reflect.Pointer {
 		structType = structType.Elem()
 	}
 	if structType.Kind() != reflect.Struct {
-		return nil
+		return nil, true
 	}
 
 	if val, ok := structTypeToFieldsCache.Load(structType); ok {
-		return val.([]structField)
+		fields := val.([]structField)
+		return fields, limit <= 0 || len(fields) <= limit
 	}
 	fields := make([]structField, 0, structType.NumField())
 	for i := 0; i < structType.NumField(); i++ {
 		field := structType.Field(i)
-		if !field.IsExported() || field.Anonymous {
+		if !field.IsExported() || field.Anonymous || field.Type == injectionContextType {
 			continue
 		}
 		canInject := field.Type == resolverType
-		if !canInject {
-			if val, ok := field.Tag.Lookup("ioc-inject"); ok && val == "true" {
+		var tag injectTag
+		if val, ok := field.Tag.Lookup("ioc-inject"); ok {
+			if tag, ok = parseInjectTag(val); ok {
 				canInject = true
 			}
 		}
+		configKey := field.Tag.Get("ioc-config")
+		if limit > 0 && (configKey != "" || canInject) && len(fields) == limit {
+			// partial fields are not cached
+			return nil, false
+		}
+		if configKey != "" {
+			fields = append(fields, structField{FieldIndex: i, FieldName: field.Name, FieldType: field.Type, ConfigKey: configKey, HasConfig: true})
+			continue
+		}
 		if canInject {
 			fields = append(fields, structField{
 				FieldIndex: i,
+				FieldName:  field.Name,
 				FieldType:  field.Type,
+				OptionalOf: optionalTypeOf(field.Type),
+				ProviderOf: providerTypeOf(field.Type),
+				Key:        tag.Key,
+				HasKey:     tag.HasKey,
+				Group:      tag.Group,
+				FromParent: tag.FromParent,
+				Optional:   tag.Optional,
+				Order:      tag.Order,
+				When:       tag.When,
 			})
 		}
 	}
+	// injected by ascending order, and then declaration order
+	sort.SliceStable(fields, func(i, j int) bool {
+		return fields[i].Order < fields[j].Order
+	})
 	structTypeToFieldsCache.Store(structType, fields)
-	return fields
+	return fields, true
+}
+
+// injectTag is the parsed value of struct tag 'ioc-inject', such as 'ioc-inject:"true"', 'ioc-inject:"key=Primary"', 'ioc-inject:"group=http"', 'ioc-inject:"from=parent"', 'ioc-inject:"optional"' or 'ioc-inject:"order=1"'.
+type injectTag struct {
+	Key        string
+	HasKey     bool
+	Group      string
+	FromParent bool
+	Optional   bool
+	Order      int
+	When       string
+}
+
+// parseInjectTag to parse comma-separated options of struct tag 'ioc-inject', returns false if not injectable.
+func parseInjectTag(val string) (injectTag, bool) {
+	var tag injectTag
+	canInject := false
+	for _, option := range strings.Split(val, ",") {
+		option = strings.TrimSpace(option)
+		name, value, hasValue := strings.Cut(option, "=")
+		switch {
+		case option == "true":
+			canInject = true
+		case hasValue && name == "key":
+			tag.Key = value
+			tag.HasKey = true
+			canInject = true
+		case hasValue && name == "group":
+			tag.Group = value
+			canInject = true
+		case hasValue && name == "from" && value == "parent":
+			tag.FromParent = true
+			canInject = true
+		case option == "optional":
+			tag.Optional = true
+			canInject = true
+		case hasValue && name == "when":
+			tag.When = value
+			canInject = true
+		case hasValue && name == "order":
+			if order, err := strconv.Atoi(value); err == nil {
+				tag.Order = order
+				canInject = true
+			}
+		}
+	}
+	return tag, canInject
 }
 
 type structField struct {
 	FieldIndex int
+	FieldName  string
 	FieldType  reflect.Type
+	Key        string
+	HasKey     bool
+	Group      string
+	FromParent bool
+	Optional   bool
+	Order      int          // order of injection, fields are injected by ascending order and then declaration order
+	OptionalOf reflect.Type // type of service if field is 'ioc.Optional[T]'
+	ProviderOf reflect.Type // type of service if field is 'func() T', which resolves for each call
+	ConfigKey  string       // key of config value if tagged with 'ioc-config'
+	HasConfig  bool
+	When       string // name of condition which should be true to inject, see 'SetCondition'
 }
 
 var _ Container = (*defaultContainer)(nil)
 
 type defaultContainer struct {
-	bindings sync.Map
-	parent   Resolver
-	locker   sync.Mutex
+	bindings  sync.Map
+	parent    Resolver
+	locker    sync.Mutex
+	overrides map[reflect.Type]struct{}
+	groups    map[string][]*serviceBinding
+
+	keyedBindings    sync.Map
+	fieldNameAsKey   int32
+	autoInjectPkgs   atomic.Value // []string, package paths of structs injected without tag
+	pointerAdapt     int32
+	matchers         atomic.Value // []func(serviceType reflect.Type) (reflect.Value, bool)
+	strict           int32
+	seal             int32        // 1 after 'Seal', singletons initialized are sealed
+	fallthroughHook  atomic.Value // func(serviceType reflect.Type, fromDepth int)
+	retry            atomic.Value // resolveRetry
+	initialized      sync.Map     // any (instance) -> struct{}, singletons initialized, see 'Seal'
+	filters          atomic.Value // []func(serviceType reflect.Type, instance reflect.Value) reflect.Value
+	typeNames        map[string][]reflect.Type
+	adopted          []*adoption
+	decorators       sync.Map // reflect.Type -> []func(inner reflect.Value, resolver Resolver) reflect.Value
+	decorated        int32    // 1 after 'Decorate', so instances aren't looked up for decorators if unused
+	elements         sync.Map // reflect.Type -> []*serviceBinding, see 'AddElementFactory'
+	afterInits       sync.Map // reflect.Type -> []func(instance any, r Resolver)
+	duplicate        int32    // DuplicatePolicy
+	assignable       int32    // AssignablePolicy
+	seq              uint64   // sequence of registration
+	started          []reflect.Value
+	phaseHooks       map[string][]func(r Resolver)
+	handlers         map[reflect.Type][]reflect.Value
+	features         map[string]*featureGroup
+	profiled         map[reflect.Type][]*serviceBinding
+	profiles         atomic.Value                         // *profileSet
+	scopeParent      *defaultContainer                    // parent which creates current as scope
+	scopes           map[weakRef[defaultContainer]]uint64 // live child scopes -> sequence of creation
+	scopeSeq         uint64                               // sequence of the last child scope created
+	scopesLive       int                                  // count of live child scopes after the last pruning
+	scopedInstances  sync.Map                             // *serviceBinding -> *scopedInstance
+	valuesOf         *defaultContainer                    // container resolving with values, if current is created by 'ResolveWithValues'
+	disposables      []disposableInstance                 // instances owned by current in initialization order
+	disposed         int32
+	getOrAddLockers  sync.Map     // reflect.Type -> *sync.Mutex
+	allocators       sync.Map     // reflect.Type of *struct -> func() any
+	stats            atomic.Value // *resolveStats, only after published
+	configValues     sync.Map     // string -> any
+	conditions       sync.Map     // string -> func() bool
+	autoInterfaces   []reflect.Type
+	defaultLifetimes map[string]Lifetime // package path -> default lifetime used by 'Add'
+	bindingsVersion  uint64              // changed when bindings changed
+	implementers     sync.Map            // reflect.Type -> *implementers
+	recoverFactory   int32
+	maxFields        int32
+	logger           atomic.Value // loggerHolder
 }
 
 func (c *defaultContainer) Resolve(serviceType reflect.Type) reflect.Value {
-	binding := c.getBinding(serviceType)
-	if binding != nil {
-		if binding.Instance.IsValid() {
-			if !binding.InstanceInitialized {
-				defer binding.Unlock()
-				binding.Lock()
-				Inject(binding.Instance)
-				if binding.InstanceInitializer.IsValid() {
-					func() {
-						defer recover()
-						Inject(binding.InstanceInitializer)
-					}()
-				}
-				binding.InstanceInitialized = true
+	if instance, ok := c.initializedInstance(serviceType); ok {
+		return instance
+	}
+	return c.resolveRequired(serviceType, nil)
+}
+
+// initializedInstance to get singleton initialized by current container without resolving, which is the fast path of
+// a new call if no profile is active, stats are disabled and it's not in a feature, as they change or observe resolving.
+func (c *defaultContainer) initializedInstance(serviceType reflect.Type) (reflect.Value, bool) {
+	bindingVal, ok := c.bindings.Load(serviceType)
+	if !ok || c.loadProfiles() != nil || c.loadStats() != nil {
+		return reflect.Value{}, false
+	}
+	binding := bindingVal.(*serviceBinding)
+	instance, ok := binding.InitializedInstance.Load().(reflect.Value)
+	return instance, ok && instance.IsValid() && binding.Feature == nil && atomic.LoadInt32(&binding.Used) == 1
+}
+
+// resolvingContainer is container passed to user code running in the call of 'path', such as deferred registration.
+// Services resolved by it are in the same call, so cycles through the user code are reported instead of waiting forever.
+type resolvingContainer struct {
+	*defaultContainer
+	path *resolvePath
+}
+
+func (c resolvingContainer) Resolve(serviceType reflect.Type) reflect.Value {
+	return c.defaultContainer.resolveRequired(serviceType, c.path)
+}
+
+// resolveRequired to resolve service in the call of 'path', which is recorded by stats and panics in strict mode if not found.
+func (c *defaultContainer) resolveRequired(serviceType reflect.Type, path *resolvePath) reflect.Value {
+	val := c.resolveLenient(serviceType, path)
+	if stats := c.loadStats(); stats != nil && !path.isDry() {
+		stats.recordResolve(val.IsValid())
+	}
+	if !val.IsValid() {
+		if atomic.LoadInt32(&c.strict) == 1 {
+			err := notFoundError(path, serviceType)
+			path.callOf().fail(err.Stack, true)
+			panic(err)
+		}
+		if path != nil {
+			// dependency not found fails 'ResolveE' even if not strict
+			path.call.fail(append(path.types(), serviceType), true)
+		}
+	}
+	return val
+}
+
+// StrictResolver is implemented by container to control whether resolving panics if service not found.
+type StrictResolver interface {
+	// SetStrictResolve to panic if service not found after resolving from parent chain, default is false.
+	// It's for surfacing missing registrations during development, and field tagged with 'ioc-inject:"optional"' is left zero instead of panic.
+	SetStrictResolve(enabled bool)
+}
+
+var _ StrictResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetStrictResolve(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&c.strict, 1)
+	} else {
+		atomic.StoreInt32(&c.strict, 0)
+	}
+}
+
+// resolveLenient to resolve service in the call of 'path' without panic on missing in strict mode.
+func (c *defaultContainer) resolveLenient(serviceType reflect.Type, path *resolvePath) reflect.Value {
+	val := c.resolve(serviceType, c, path)
+	if !val.IsValid() && serviceType != nil && c.isPointerValueAdapt() {
+		if recorder := path.recorderOf(); recorder != nil {
+			recorder.adapt()
+		}
+		return c.adaptPointerValue(serviceType, path)
+	}
+	return val
+}
+
+// resolve service for 'origin', the container which the resolving started from, in the call of 'path' which is nil for a new call.
+// Singleton instance is initialized with services from the container owning it when resolving first time.
+func (c *defaultContainer) resolve(serviceType reflect.Type, origin *defaultContainer, path *resolvePath) reflect.Value {
+	if val, found := c.resolveHere(serviceType, origin, path); found {
+		return val
+	}
+	parent := c.parent
+	if parent != nil {
+		origin.notifyFallthrough(serviceType, c)
+	}
+	if parentC, ok := parent.(*defaultContainer); ok {
+		return parentC.resolve(serviceType, origin, path)
+	} else if parent != nil {
+		val := parent.Resolve(serviceType)
+		if val.IsValid() {
+			if recorder := path.recorderOf(); recorder != nil {
+				recorder.found(parent, LifetimeUnknown)
+			}
+			if provenance := path.provenanceOf(); provenance != nil {
+				provenance.found(origin, parent, LifetimeUnknown)
 			}
-			return binding.Instance
 		}
-		return reflect.ValueOf(binding.InstanceFactory())
+		return val
 	} else {
-		parent := c.parent
-		if parent != nil {
-			return parent.Resolve(serviceType)
-		} else {
-			return reflect.Value{}
+		return reflect.Value{}
+	}
+}
+
+// resolveHere to resolve service for 'origin' only from current container, without parent.
+// It's not found if service is not registered or matched in current container.
+func (c *defaultContainer) resolveHere(serviceType reflect.Type, origin *defaultContainer, path *resolvePath) (reflect.Value, bool) {
+	binding := c.getBinding(serviceType)
+	if binding == nil && len(c.overrides) > 0 {
+		binding = c.getOverridingBinding(serviceType)
+	}
+	if binding != nil {
+		if binding.NotInherited && origin != c {
+			// not shared with child containers
+			return reflect.Value{}, true
+		}
+		if recorder := path.recorderOf(); recorder != nil {
+			recorder.found(c, binding.lifetime())
+		}
+		if provenance := path.provenanceOf(); provenance != nil {
+			provenance.found(origin, c, binding.lifetime())
+		}
+		return binding.resolve(c, origin, path), true
+	}
+	val, found := c.matchResolver(serviceType)
+	if found {
+		if recorder := path.recorderOf(); recorder != nil {
+			recorder.found(c, LifetimeUnknown)
+		}
+		if provenance := path.provenanceOf(); provenance != nil {
+			provenance.found(origin, c, LifetimeUnknown)
 		}
 	}
+	return val, found
 }
 
 func (c *defaultContainer) SetParent(parent Resolver) {
+	if parent == nil {
+		return
+	}
+	// parent will be the last one of ancestors, so any container in both chains will cause cycle.
+	chain := append([]Resolver{c}, c.Ancestors()...)
+	for _, ancestor := range chain {
+		if ancestor == parent {
+			// already in parent chain
+			return
+		}
+	}
+	for _, parentAncestor := range append([]Resolver{parent}, ancestorsOf(parent)...) {
+		for _, ancestor := range chain {
+			if ancestor == parentAncestor {
+				panic(errors.New("cycle reference: parent chain of the parent contains current container or it's ancestors"))
+			}
+		}
+	}
+
 	defer c.locker.Unlock()
 	c.locker.Lock()
-	if parent == nil || c.parent == parent {
+	if c.parent == parent {
 		return
 	}
 
@@ -416,37 +1003,184 @@ func (c *defaultContainer) SetParent(parent Resolver) {
 	}
 }
 
+// Hierarchical is implemented by container to inspect the parent chain of container.
+type Hierarchical interface {
+	// Parent to get the immediate parent resolver, or nil if not set.
+	Parent() Resolver
+
+	// Ancestors to get all resolvers in the parent chain, from the immediate parent to the root.
+	Ancestors() []Resolver
+}
+
+var _ Hierarchical = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Parent() Resolver {
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	return c.parent
+}
+
+func (c *defaultContainer) Ancestors() []Resolver {
+	return ancestorsOf(c)
+}
+
+// ancestorsOf to walk the parent chain of resolver, which can provide it's parent by method 'Parent() Resolver'.
+func ancestorsOf(resolver Resolver) []Resolver {
+	var ancestors []Resolver
+	for {
+		child, ok := resolver.(interface{ Parent() Resolver })
+		if !ok {
+			return ancestors
+		}
+		if resolver = child.Parent(); resolver == nil {
+			return ancestors
+		}
+		for _, ancestor := range ancestors {
+			if ancestor == resolver {
+				// stop walking at cycle
+				return ancestors
+			}
+		}
+		ancestors = append(ancestors, resolver)
+	}
+}
+
 func (c *defaultContainer) AddSingleton(serviceType reflect.Type, instance any) error {
 	if serviceType == nil {
 		return errors.New("param 'serviceType' is null")
 	}
-	if instance == nil || reflect.ValueOf(instance).IsZero() {
-		return errors.New("param 'instance' is null")
+	if err := checkInstance(instance); err != nil {
+		return err
 	}
-	binding := c.getBinding(serviceType)
-	if binding != nil {
-		// ignore exists service in current container
-		return nil
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err != nil {
+		return err
 	}
-	binding = &serviceBinding{ServiceType: serviceType, Instance: reflect.ValueOf(instance)}
-	if serviceType != resolverType {
-		initializeMethodName := DefaultInitializeMethodName
-		if initializer, ok := binding.Instance.Interface().(CustomInitializer); ok {
-			initializeMethodName = initializer.InitializeMethodName()
-		}
-		if foundMethod := binding.Instance.MethodByName(initializeMethodName); foundMethod.IsValid() {
-			methodType := foundMethod.Type()
-			for i := 0; i < methodType.NumIn(); i++ {
-				if methodType.In(i) == serviceType {
-					return fmt.Errorf("cycle reference: param[%d]'s type in method '%s' equals to service '%v'", i, initializeMethodName, serviceType)
-				}
-			}
-			binding.InstanceInitializer = foundMethod
-		}
+	return c.addBinding(binding)
+}
+
+// GetOrAdder is implemented by container to get service or add it as singleton on first use.
+type GetOrAdder interface {
+	// GetOrAddSingleton to get service if exists in current container or parents, otherwise create singleton instance by 'instanceFactory',
+	// add and resolve it. The factory runs at most once for concurrent callers of the same service type,
+	// but it should not invoke 'GetOrAddSingleton' of the same service type.
+	//
+	//  var container ioc.Container
+	//  cache, err := container.(ioc.GetOrAdder).GetOrAddSingleton(TypeOf[Cache](), func() any {
+	//      return &CacheImpl{}
+	//  })
+	GetOrAddSingleton(serviceType reflect.Type, instanceFactory func() any) (reflect.Value, error)
+}
+
+var _ GetOrAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) GetOrAddSingleton(serviceType reflect.Type, instanceFactory func() any) (reflect.Value, error) {
+	if serviceType == nil {
+		return reflect.Value{}, errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return reflect.Value{}, errors.New("param 'instanceFactory' is null")
+	}
+	// serialize by service type, so factory can resolve or add other services
+	lockerVal, _ := c.getOrAddLockers.LoadOrStore(serviceType, &sync.Mutex{})
+	locker := lockerVal.(*sync.Mutex)
+	defer locker.Unlock()
+	locker.Lock()
+	if val, _, found := c.ResolveWithInfo(serviceType); found {
+		return val, nil
+	}
+	if err := c.AddSingleton(serviceType, instanceFactory()); err != nil {
+		return reflect.Value{}, err
 	}
+	return c.Resolve(serviceType), nil
+}
+
+// InitAdder is implemented by container to add singleton with a post-construct callback.
+type InitAdder interface {
+	// AddSingletonWithInit to add singleton instance with a post-construct callback,
+	// which will be invoked once on first resolving, after injecting to fields and it's initialize method.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.InitAdder).AddSingletonWithInit(reflect.TypeOf((*Service1)(nil)).Elem(), &ServiceImplementation1{}, func(instance any, resolver ioc.Resolver) {
+	//      instance.(*ServiceImplementation1).resolver = resolver
+	//  })
+	AddSingletonWithInit(serviceType reflect.Type, instance any, init func(instance any, resolver Resolver)) error
+}
+
+var _ InitAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSingletonWithInit(serviceType reflect.Type, instance any, init func(instance any, resolver Resolver)) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	if init == nil {
+		return errors.New("param 'init' is null")
+	}
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err != nil {
+		return err
+	}
+	binding.InitCallback = init
 	return c.addBinding(binding)
 }
 
+// TemporaryOverrider is implemented by container to replace service temporarily, such as in tests.
+type TemporaryOverrider interface {
+	// WithSingleton to add singleton instance temporarily for the lifetime of 'fn', the existing service in current container
+	// is shadowed while 'fn' is running, and restored after 'fn' returns or panics.
+	//
+	// It mutates the shared container, so services resolved by other goroutines while 'fn' is running will get the instance too.
+	//
+	//  err := container.(ioc.TemporaryOverrider).WithSingleton(TypeOf[Clock](), &FakeClock{}, func() {
+	//      // resolve 'Clock' to get '*FakeClock'
+	//  })
+	WithSingleton(serviceType reflect.Type, instance any, fn func()) error
+}
+
+var _ TemporaryOverrider = (*defaultContainer)(nil)
+
+func (c *defaultContainer) WithSingleton(serviceType reflect.Type, instance any, fn func()) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	if fn == nil {
+		return errors.New("param 'fn' is null")
+	}
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err != nil {
+		return err
+	}
+	if err = validateBinding(binding); err != nil {
+		return err
+	}
+
+	c.locker.Lock()
+	prior, hasPrior := c.bindings.Load(serviceType)
+	c.bindings.Store(serviceType, binding)
+	c.indexTypeName(serviceType)
+	c.bindingsChanged()
+	c.locker.Unlock()
+	defer func() {
+		defer c.locker.Unlock()
+		c.locker.Lock()
+		if hasPrior {
+			c.bindings.Store(serviceType, prior)
+		} else {
+			c.bindings.Delete(serviceType)
+			c.unindexTypeName(serviceType)
+		}
+		c.bindingsChanged()
+	}()
+	fn()
+	return nil
+}
+
 func (c *defaultContainer) AddTransient(serviceType reflect.Type, instanceFactory func() any) error {
 	if serviceType == nil {
 		return errors.New("param 'serviceType' is null")
@@ -454,53 +1188,408 @@ func (c *defaultContainer) AddTransient(serviceType reflect.Type, instanceFactor
 	if instanceFactory == nil {
 		return errors.New("param 'instanceFactory' is null")
 	}
-	binding := c.getBinding(serviceType)
-	if binding != nil {
-		// ignore exists service in current container
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory})
+}
+
+func (c *defaultContainer) addBinding(binding *serviceBinding) error {
+	_, err := c.tryAddBinding(binding)
+	return err
+}
+
+// tryAddBinding to add binding by the duplicate policy, returns whether the binding is stored.
+func (c *defaultContainer) tryAddBinding(binding *serviceBinding) (bool, error) {
+	if binding == nil || binding.ServiceType == nil {
+		return false, nil
+	}
+	if err := validateBinding(binding); err != nil {
+		return false, err
+	}
+	stored, err := c.storeBinding(&c.bindings, binding.ServiceType, binding)
+	if err != nil {
+		return false, err
+	}
+	if stored {
+		c.locker.Lock()
+		c.indexTypeName(binding.ServiceType)
+		c.locker.Unlock()
+	}
+	return stored, nil
+}
+
+// checkInstance to check whether instance to add is null, and distinguish a nil pointer of concrete type from a literal nil.
+func checkInstance(instance any) error {
+	if instance == nil {
+		return errors.New("param 'instance' is null")
+	}
+	val := reflect.ValueOf(instance)
+	if !val.IsZero() {
 		return nil
 	}
-	binding = &serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory}
-	return c.addBinding(binding)
+	switch val.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func:
+		return fmt.Errorf("param 'instance' is a nil '%v', it should be initialized before adding", val.Type())
+	}
+	return fmt.Errorf("param 'instance' is a zero value of '%v'", val.Type())
 }
 
-func (c *defaultContainer) addBinding(binding *serviceBinding) error {
-	if binding != nil && binding.ServiceType != nil {
-		if binding.ServiceType.Kind() != reflect.Interface &&
-			!(binding.ServiceType.Kind() == reflect.Pointer && binding.ServiceType.Elem().Kind() == reflect.Struct) {
-			return fmt.Errorf("type of service '%v' should be an interface or *struct", binding.ServiceType)
-		}
-		if binding.Instance.IsValid() {
-			if !binding.Instance.Type().AssignableTo(binding.ServiceType) {
-				return fmt.Errorf("instance should implement the service '%v'", binding.ServiceType)
-			}
+func validateBinding(binding *serviceBinding) error {
+	if binding.ServiceType.Kind() != reflect.Interface &&
+		!(binding.ServiceType.Kind() == reflect.Pointer && binding.ServiceType.Elem().Kind() == reflect.Struct) {
+		return fmt.Errorf("type of service '%v' should be an interface or *struct", binding.ServiceType)
+	}
+	if binding.Instance.IsValid() {
+		if !binding.Instance.Type().AssignableTo(binding.ServiceType) {
+			return fmt.Errorf("instance should implement the service '%v'", binding.ServiceType)
 		}
-		c.bindings.LoadOrStore(binding.ServiceType, binding)
 	}
 	return nil
 }
 
 func (c *defaultContainer) getBinding(serviceType reflect.Type) *serviceBinding {
+	if binding := c.getProfiledBinding(serviceType); binding != nil {
+		return binding
+	}
 	if bindingVal, ok := c.bindings.Load(serviceType); ok {
 		binding := bindingVal.(*serviceBinding)
+		if binding.Feature != nil && !binding.Feature.isEnabled() {
+			return nil
+		}
 		return binding
 	}
 	return nil
 }
 
+// rangeBindings to iterate bindings without key which are resolvable by service type in current container, the same as 'getBinding':
+// bindings in disabled features are skipped, and binding chosen in active profiles takes the place of the one registered without profiles.
+// Primary keyed binding is iterated with it's service type. It stops if 'fn' returns false.
+func (c *defaultContainer) rangeBindings(fn func(serviceType reflect.Type, binding *serviceBinding) bool) {
+	set := c.loadProfiles()
+	proceed := true
+	c.bindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		if binding.Feature != nil && !binding.Feature.isEnabled() ||
+			set != nil && c.getProfiledBinding(binding.ServiceType) != nil {
+			return true
+		}
+		proceed = fn(key.(reflect.Type), binding)
+		return proceed
+	})
+	if !proceed || set == nil {
+		return
+	}
+	for serviceType := range set.bindings {
+		if binding := c.getProfiledBinding(serviceType); binding != nil && !fn(serviceType, binding) {
+			return
+		}
+	}
+}
+
+// newSingletonBinding to create binding of singleton instance, with it's initialize method.
+func newSingletonBinding(serviceType reflect.Type, instance any) (*serviceBinding, error) {
+	return newSingletonBindingWithInitializer(serviceType, instance, "")
+}
+
+// newSingletonBindingWithInitializer to create singleton binding, with the initialize method specified by 'initializerName',
+// which should exist if it's not empty.
+func newSingletonBindingWithInitializer(serviceType reflect.Type, instance any, initializerName string) (*serviceBinding, error) {
+	binding := &serviceBinding{ServiceType: serviceType, Instance: reflect.ValueOf(instance)}
+	if serviceType != resolverType {
+		initializeMethodName := DefaultInitializeMethodName
+		if initializerName != "" {
+			initializeMethodName = initializerName
+			if !binding.Instance.MethodByName(initializeMethodName).IsValid() {
+				return nil, fmt.Errorf("initialize method '%s' not found in '%v'", initializeMethodName, binding.Instance.Type())
+			}
+		} else if initializer, ok := binding.Instance.Interface().(CustomInitializer); ok {
+			initializeMethodName = initializer.InitializeMethodName()
+		}
+		if foundMethod := binding.Instance.MethodByName(initializeMethodName); foundMethod.IsValid() {
+			methodType := foundMethod.Type()
+			for i := 0; i < methodType.NumIn(); i++ {
+				if methodType.In(i) == serviceType {
+					return nil, fmt.Errorf("cycle reference: param[%d]'s type in method '%s' equals to service '%v'", i, initializeMethodName, serviceType)
+				}
+			}
+			binding.InstanceInitializer = foundMethod
+			binding.InstanceInitializerName = initializeMethodName
+		}
+	}
+	return binding, nil
+}
+
 type serviceBinding struct {
-	ServiceType         reflect.Type
-	Instance            reflect.Value
-	InstanceInitializer reflect.Value
-	InstanceInitialized bool
-	InstanceFactory     func() any
+	ServiceType             reflect.Type
+	Instance                reflect.Value
+	InstanceInitializer     reflect.Value
+	InstanceInitializerName string
+	InitializedInstance     atomic.Value // reflect.Value, stored after initialized, and invalid value after invalidated
+	InstanceFactory         func() any
+	InitCallback            func(instance any, resolver Resolver)
+	Selector                func(resolver Resolver) string // select key of keyed service for each resolving
+	Scoped                  bool                           // instance of factory is cached in the container where resolving started
+	GoroutineScoped         bool                           // instance of factory is cached for each goroutine in goroutineInstances
+	goroutineInstances      sync.Map                       // goroutine id -> reflect.Value
+	Order                   int                            // order in group and 'ResolveAll'
+	DisposeOrder            int                            // order of disposing, see 'DisposeOrder'
+	NotInherited            bool                           // not resolved by child containers
+	Memo                    atomic.Value                   // *memoCache, cache of transient instances by key
+	TTL                     *ttlCache                      // cache of transient instance for a duration, see 'CacheFor'
+	Weak                    *weakCache                     // weak reference of lazy singleton, see 'Weak'
+	Feature                 *featureGroup                  // feature group which can disable the binding, see 'InFeature'
+	Profiles                []string                       // profiles any of which should be active to resolve the binding, see 'ForProfiles'
+	Primary                 bool                           // the primary one resolved without key
+	Lazy                    bool                           // singleton created by factory on first resolving, cached by Memo
+	Target                  *serviceBinding                // binding which is resolved instead, for interface registered by 'AutoRegister'
+	ResolverFactory         func(resolver Resolver) any    // factory with resolver where resolving started, preferred to InstanceFactory
+	AliasOf                 reflect.Type                   // service resolved instead when resolving, for alias added by 'Alias'
+	Deferred                func(c Container) any          // registration deferred until first resolving, see 'AddDeferred'
+	Async                   *asyncSingleton                // singleton constructed in background, see 'AddSingletonAsync'
+	Swappable               *swappable                     // singleton swapped at runtime, see 'AddSwappable'
+	ContextScoped           *contextScoped                 // instance cached until context is done, see 'AddContextScoped'
+	RegisteredAt            string                         // registration site as "file:line"
+	Seq                     uint64                         // sequence of registration in container
+	Used                    int32                          // 1 after resolved once
 
 	initializerLocker sync.Mutex
+	state             int32         // bindingUninitialized, bindingInitializing or bindingInitialized
+	invalidated       bool          // a copy of Instance is initialized instead, guarded by 'initializerLocker'
+	injected          reflect.Value // instance being injected and initialized, before decorated, guarded by 'initializerLocker'
+	initializer       *resolveCall  // call initializing, guarded by 'initWaits'
+	done              chan struct{} // closed after initializing finished
 }
 
-func (b *serviceBinding) Lock() {
-	b.initializerLocker.Lock()
+// resolve instance of binding registered in 'owner' for 'origin', in the call of 'path' which is nil for a new call.
+func (b *serviceBinding) resolve(owner *defaultContainer, origin *defaultContainer, path *resolvePath) reflect.Value {
+	dry := path.isDry()
+	if atomic.LoadInt32(&b.Used) == 0 && !dry {
+		// load first to avoid writing shared memory for each resolving
+		atomic.StoreInt32(&b.Used, 1)
+	}
+	if instance, ok := b.InitializedInstance.Load().(reflect.Value); ok && instance.IsValid() {
+		// fast path: only an atomic load if initialized
+		return instance
+	}
+	if b.Target != nil {
+		// share initializing with the binding of implementation
+		return b.Target.resolve(owner, origin, path)
+	}
+	if b.AliasOf != nil {
+		return owner.resolveAlias(b, origin, path)
+	}
+	if b.Deferred != nil {
+		return owner.resolveDeferred(b, path)
+	}
+	if b.Async != nil {
+		return owner.resolveAsync(b, path)
+	}
+	if b.Swappable != nil {
+		return b.Swappable.value.Load().(reflect.Value)
+	}
+	if b.ContextScoped != nil {
+		return b.ContextScoped.get(b, owner, path)
+	}
+	if b.Instance.IsValid() {
+		if dry {
+			return b.dryInitialize(owner, path)
+		}
+		return b.initialize(owner, path)
+	}
+	if b.Selector != nil {
+		// keyed service has been decorated and filtered
+		return origin.resolveKeyed(b.Selector(origin), b.ServiceType, origin, path)
+	}
+	if b.Scoped {
+		return origin.resolveScoped(b, owner, path)
+	}
+	// instances cached in the binding are created with services from 'owner', so they don't keep
+	// services of the container where resolving started
+	if dry && (b.GoroutineScoped || b.Weak != nil || b.TTL != nil || b.Memo.Load() != nil) {
+		return b.newTransient(owner, owner, path)
+	}
+	if b.GoroutineScoped {
+		return b.resolveGoroutineScoped(owner, path)
+	}
+	if b.Weak != nil {
+		return b.Weak.get(func() reflect.Value {
+			return b.newTransient(owner, owner, path)
+		})
+	}
+	if b.TTL != nil {
+		return b.TTL.get(func() reflect.Value {
+			return b.newTransient(owner, owner, path)
+		})
+	}
+	if memo, ok := b.Memo.Load().(*memoCache); ok {
+		return memo.get(func() reflect.Value {
+			return b.newTransient(owner, owner, path)
+		})
+	}
+	return b.newTransient(owner, origin, path)
+}
+
+// newTransient to create instance by factory, and decorate and filter it.
+func (b *serviceBinding) newTransient(owner *defaultContainer, origin *defaultContainer, path *resolvePath) reflect.Value {
+	if provenance := path.provenanceOf(); provenance != nil {
+		provenance.construct("")
+	}
+	created := false
+	defer func() {
+		if !created && path != nil {
+			// panic during creating
+			path.call.fail(append(path.types(), b.ServiceType), false)
+		}
+	}()
+	instance, ok := owner.callFactory(b, origin, path)
+	created = true
+	if !ok {
+		return reflect.Value{}
+	}
+	instance = owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, instance, origin))
+	if !instance.IsValid() {
+		// normalize nil returns of factory to zero value of service type
+		instance = reflect.Zero(b.ServiceType)
+	}
+	return instance
+}
+
+// binding states of singleton initializing.
+const (
+	bindingUninitialized int32 = iota
+	bindingInitializing
+	bindingInitialized
+)
+
+// initialize singleton instance with services from 'owner' once.
+// Services of the container where resolving started are never injected, otherwise the singleton shared by all
+// child containers would keep services of the child resolving it first.
+//
+// The lock only guards the state transitions, and dependencies are resolved outside it by the call which starts
+// initializing, with services being created passed explicitly by 'path'. Other calls wait for it to finish,
+// and it panics with error of cycle reference if the binding is in the path, or it's waited by a factory invoked
+// for it on the same goroutine, which resolves it in a new call. If waiting makes calls of different goroutines
+// wait for each other, the singleton registered with instance is returned without waiting, and the call initializing
+// it finishes after that, so singletons depending on each other are both resolved when resolved concurrently.
+func (b *serviceBinding) initialize(owner *defaultContainer, path *resolvePath) reflect.Value {
+	return b.initOnce(path, func(path *resolvePath) reflect.Value {
+		return b.doInitialize(owner, path)
+	})
+}
+
+// initOnce to create instance of binding by 'create' in the call of 'path' once, and it's created again if 'create'
+// returns invalid value or panics. See 'initialize' for waiting and cycle reference.
+func (b *serviceBinding) initOnce(path *resolvePath, create func(path *resolvePath) reflect.Value) reflect.Value {
+	if err := path.cycleOf(b); err != nil {
+		panic(err)
+	}
+	if path == nil {
+		path = newResolvePath()
+	}
+	for {
+		b.initializerLocker.Lock()
+		switch b.state {
+		case bindingInitialized:
+			b.initializerLocker.Unlock()
+			if instance, ok := b.InitializedInstance.Load().(reflect.Value); ok && instance.IsValid() {
+				return instance
+			}
+			continue
+		case bindingInitializing:
+			if early, err := initWaits.wait(path, b); err != nil {
+				injected := b.injected
+				b.initializerLocker.Unlock()
+				if early && injected.IsValid() {
+					// the other call finishes initializing it after current returns
+					return injected
+				}
+				panic(err)
+			}
+			done := b.done
+			b.initializerLocker.Unlock()
+			<-done
+			initWaits.done(path)
+			continue
+		}
+		b.state = bindingInitializing
+		initWaits.start(path, b)
+		b.done = make(chan struct{})
+		b.initializerLocker.Unlock()
+		return b.runInit(path.push(b), create)
+	}
 }
 
-func (b *serviceBinding) Unlock() {
+// resolverValueOf to get value of type Resolver holding the resolver, so setting it to fields or params of Resolver
+// doesn't check by reflection that it implements Resolver, which is slow for containers having many methods.
+func resolverValueOf(resolver reflect.Value) reflect.Value {
+	r, ok := resolver.Interface().(Resolver)
+	if !ok {
+		return resolver
+	}
+	return reflect.ValueOf(&r).Elem()
+}
+
+// runInit to create instance by 'create', and finish the transition even if it panics.
+func (b *serviceBinding) runInit(path *resolvePath, create func(path *resolvePath) reflect.Value) (instance reflect.Value) {
+	created := false
+	defer func() {
+		if !created {
+			// panic during creating
+			path.call.fail(path.types(), false)
+		}
+		b.initializerLocker.Lock()
+		if instance.IsValid() {
+			if b.ServiceType == resolverType {
+				instance = resolverValueOf(instance)
+			}
+			b.InitializedInstance.Store(instance)
+			b.state = bindingInitialized
+		} else {
+			b.state = bindingUninitialized
+		}
+		initWaits.finish(b)
+		close(b.done)
+		b.initializerLocker.Unlock()
+	}()
+	if provenance := path.prev.provenanceOf(); provenance != nil {
+		provenance.construct("")
+	}
+	instance = create(path)
+	created = true
+	return instance
+}
+
+// doInitialize to initialize singleton instance in the call of 'path', which is a copy of 'Instance' after invalidated.
+func (b *serviceBinding) doInitialize(owner *defaultContainer, path *resolvePath) reflect.Value {
+	if provenance := path.prev.provenanceOf(); provenance != nil {
+		provenance.construct(b.InstanceInitializerName)
+	}
+	if stats := owner.loadStats(); stats != nil {
+		defer stats.recordInit(b.ServiceType, time.Now())
+	}
+	b.initializerLocker.Lock()
+	instance, initializer := b.Instance, b.InstanceInitializer
+	if b.invalidated {
+		// not inject to the instance got before invalidated
+		instance = b.copyInstance()
+		if initializer.IsValid() {
+			initializer = instance.MethodByName(b.InstanceInitializerName)
+		}
+	}
+	b.injected = instance
 	b.initializerLocker.Unlock()
+	injectFrom(owner, instance, path)
+	if initializer.IsValid() {
+		func() {
+			defer recover()
+			injectFrom(owner, initializer, path)
+		}()
+	}
+	if b.InitCallback != nil {
+		b.InitCallback(instance.Interface(), owner)
+	}
+	owner.runAfterInits(b.ServiceType, instance.Interface())
+	if b.ServiceType != resolverType {
+		owner.trackDisposable(instance, b.DisposeOrder)
+		owner.trackInitialized(instance)
+	}
+	return owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, instance, owner))
 }
@@ -22,9 +22,14 @@
 package ioc
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestAddSingleton(t *testing.T) {
@@ -117,6 +122,119 @@ func TestAddSingleton(t *testing.T) {
 	})
 }
 
+func TestAddSingletonWithInit(t *testing.T) {
+	t.Run("callback should be invoked once after injecting", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		var invokedTimes int32
+		svc8 := &serviceInstance8{}
+		AddSingletonWithInit[*serviceInstance8](svc8, func(instance *serviceInstance8, resolver Resolver) {
+			atomic.AddInt32(&invokedTimes, 1)
+			if instance != svc8 || resolver != globalContainer {
+				t.Error("callback should receive the instance and resolver")
+			}
+			if instance.s7 == nil {
+				t.Error("callback should be invoked after initialize method")
+			}
+		})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if GetService[*serviceInstance8]() != svc8 {
+					t.Error("service should be singleton")
+				}
+			}()
+		}
+		wg.Wait()
+		if atomic.LoadInt32(&invokedTimes) != 1 {
+			t.Error("callback should be invoked once")
+			return
+		}
+	})
+
+	t.Run("null callback should fail", func(t *testing.T) {
+		globalContainer = New()
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("null callback should fail")
+				}
+			}()
+			AddSingletonWithInit[*serviceInstance1](&serviceInstance1{}, nil)
+		}()
+		if err := globalContainer.(InitAdder).AddSingletonWithInit(reflect.TypeOf((*serviceInstance1)(nil)), &serviceInstance1{}, nil); err == nil {
+			t.Error("null callback should fail")
+			return
+		}
+	})
+}
+
+func TestWithSingleton(t *testing.T) {
+	t.Run("instance should be available only in callback", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		tempSvc1 := &serviceInstance1{name: "temp"}
+		invoked := false
+		WithSingleton[service1](tempSvc1, func() {
+			invoked = true
+			if GetService[service1]() != tempSvc1 {
+				t.Error("instance should shadow the existing service in callback")
+			}
+		})
+		if !invoked {
+			t.Error("callback should be invoked")
+			return
+		}
+		if GetService[service1]() != svc1 {
+			t.Error("existing service should be restored")
+			return
+		}
+
+		WithSingleton[*serviceInstance1](tempSvc1, func() {})
+		if GetService[*serviceInstance1]() != nil {
+			t.Error("service not exists before should be removed")
+			return
+		}
+	})
+
+	t.Run("existing service should be restored even if callback panics", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("panic in callback should be propagated")
+				}
+			}()
+			WithSingleton[service1](&serviceInstance1{name: "temp"}, func() {
+				panic("callback fail")
+			})
+		}()
+		if GetService[service1]() != svc1 {
+			t.Error("existing service should be restored")
+			return
+		}
+	})
+
+	t.Run("invalid param should fail", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(TemporaryOverrider).WithSingleton(serviceType, &serviceInstance1{}, nil); err == nil {
+			t.Error("null callback should fail")
+			return
+		}
+		if err := globalContainer.(TemporaryOverrider).WithSingleton(reflect.TypeOf((*service2)(nil)).Elem(), &serviceInstance1{}, func() {}); err == nil {
+			t.Error("instance should implement the service")
+			return
+		}
+	})
+}
+
 func TestAddTransient(t *testing.T) {
 	t.Run("use interface as service and get service success", func(t *testing.T) {
 		globalContainer = New()
@@ -160,6 +278,44 @@ func TestAddTransient(t *testing.T) {
 		}
 	})
 
+	t.Run("factory returns nil should resolve as zero value", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service2](func() service2 { return nil })
+		AddTransient[*serviceInstance4](func() *serviceInstance4 { return nil })
+		globalContainer.AddTransient(reflect.TypeOf((*service3)(nil)).Elem(), func() any { return nil })
+
+		val := globalContainer.Resolve(reflect.TypeOf((*service3)(nil)).Elem())
+		if !val.IsValid() || !val.IsZero() || val.Type() != reflect.TypeOf((*service3)(nil)).Elem() {
+			t.Error("nil returns of factory should be normalized to zero value of service type")
+			return
+		}
+		if svc := GetService[service2](); svc != nil {
+			t.Error("service should be nil")
+			return
+		}
+		if svc := GetService[*serviceInstance4](); svc != nil {
+			t.Error("service should be nil")
+			return
+		}
+		invoked := false
+		Inject(func(s2 service2, s3 service3, s4 *serviceInstance4) {
+			invoked = true
+			if s2 != nil || s3 != nil || s4 != nil {
+				t.Error("param should be nil")
+			}
+		})
+		if !invoked {
+			t.Error("function after inject should be invoked")
+			return
+		}
+		c := client{F5: &serviceInstance4{}, F6: &serviceInstance4{}}
+		Inject(&c)
+		if c.F6 != nil {
+			t.Error("field should be injected with nil")
+			return
+		}
+	})
+
 	t.Run("invalid service should fail", func(t *testing.T) {
 		globalContainer = New()
 		func() {
@@ -223,10 +379,172 @@ func TestGetService(t *testing.T) {
 
 		// replace exists service
 		AddSingleton[*serviceInstance7](&serviceInstance7{name: "new-instance7"})
+		if GetService[*serviceInstance7]().name != "new-instance7" {
+			t.Error("should replace exists service success")
+			return
+		}
+	})
 
+	t.Run("replace dependency of singleton of parent in child should not change the singleton", func(t *testing.T) {
+		globalContainer = New()
+		anotherC := New()
+		SetParent(anotherC)
+
+		AddSingletonToC[*serviceInstance7](anotherC, &serviceInstance7{name: "instance7"})
+		AddSingletonToC[*serviceInstance8](anotherC, &serviceInstance8{})
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "new-instance7"})
+
+		// singleton of parent is initialized with services of parent, not the ones replaced in child
 		svc8 := GetService[*serviceInstance8]()
-		if svc8.GetS7Name() != "new-instance7" {
-			t.Error("should replace exists service success")
+		if svc8.GetS7Name() != "instance7" {
+			t.Error("singleton of parent should be initialized with services of parent")
+			return
+		}
+	})
+
+	t.Run("replace dependency of singleton of parent by overriding should success", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+
+		child, err := globalContainer.(ChildOverrider).NewChildOverriding(OverrideWith[*serviceInstance7](&serviceInstance7{name: "new-instance7"}))
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if GetServiceFromC[*serviceInstance8](child).GetS7Name() != "new-instance7" {
+			t.Error("singleton of parent resolved through overriding child should be initialized with the override")
+			return
+		}
+		if GetService[*serviceInstance8]().GetS7Name() != "instance7" {
+			t.Error("singleton of parent should not be changed by overriding child")
+			return
+		}
+	})
+
+	t.Run("resolve singleton of parent from child first should not keep services of child", func(t *testing.T) {
+		globalContainer = New()
+		child := New()
+		child.SetParent(globalContainer)
+
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		AddSingletonToC[*serviceInstance7](child, &serviceInstance7{name: "child-instance7"})
+
+		if GetServiceFromC[*serviceInstance8](child).GetS7Name() != "instance7" {
+			t.Error("should initialize singleton with services of parent")
+			return
+		}
+		if GetService[*serviceInstance8]().GetS7Name() != "instance7" {
+			t.Error("should initialize singleton with services of parent")
+			return
+		}
+	})
+
+	t.Run("concurrent get singleton service should initialize once", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		svc13 := &serviceInstance13{}
+		AddSingleton[*serviceInstance13](svc13)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if GetService[*serviceInstance13]() != svc13 {
+					t.Error("service should be singleton")
+				}
+			}()
+		}
+		wg.Wait()
+		if atomic.LoadInt32(&svc13.initializedTimes) != 1 {
+			t.Error("func 'Initialize()' should be invoked once")
+			return
+		}
+	})
+
+	t.Run("concurrent get interdependent singleton services should not deadlock", func(t *testing.T) {
+		globalContainer = New()
+		var arrived sync.WaitGroup
+		arrived.Add(2)
+		// both are initializing before resolving each other
+		AddTransient[*initGate](func() *initGate {
+			arrived.Done()
+			arrived.Wait()
+			return &initGate{}
+		})
+		a := &interdependentA{}
+		b := &interdependentB{}
+		AddSingleton[*interdependentA](a)
+		AddSingleton[*interdependentB](b)
+
+		finished := make(chan struct{})
+		go func() {
+			var wg sync.WaitGroup
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				GetService[*interdependentA]()
+			}()
+			go func() {
+				defer wg.Done()
+				GetService[*interdependentB]()
+			}()
+			wg.Wait()
+			close(finished)
+		}()
+		select {
+		case <-finished:
+		case <-time.After(5 * time.Second):
+			t.Error("resolve interdependent singleton services should not deadlock")
+			return
+		}
+		if a.b != b || b.a != a {
+			t.Error("interdependent singleton services should be injected each other")
+			return
+		}
+		if GetService[*interdependentA]() != a || GetService[*interdependentB]() != b {
+			t.Error("service should be singleton")
+			return
+		}
+	})
+
+	t.Run("get singleton service by factory of its dependency should fail with cycle reference", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*factoryDependent](&factoryDependent{})
+		AddTransient[*dependentFactoryResult](func() *dependentFactoryResult {
+			return &dependentFactoryResult{owner: GetService[*factoryDependent]()}
+		})
+
+		errs := make(chan error, 1)
+		go func() {
+			defer func() {
+				if r := recover(); r != nil {
+					errs <- fmt.Errorf("%v", r)
+				}
+			}()
+			_, err := GetServiceE[*factoryDependent]()
+			errs <- err
+		}()
+		select {
+		case err := <-errs:
+			if err == nil || !strings.Contains(err.Error(), "cycle reference: *ioc.factoryDependent -> *ioc.dependentFactoryResult -> *ioc.factoryDependent") {
+				t.Errorf("resolve singleton service by factory of its dependency should fail with cycle reference, but got %v", err)
+				return
+			}
+		case <-time.After(5 * time.Second):
+			t.Error("resolve singleton service by factory of its dependency should not deadlock")
+			return
+		}
+	})
+
+	t.Run("get singleton service depends on itself should fail with cycle reference", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*selfDependent](&selfDependent{})
+		_, err := GetServiceE[*selfDependent]()
+		if err == nil || !strings.Contains(err.Error(), "cycle reference: *ioc.selfDependent -> *ioc.selfDependent") {
+			t.Errorf("service depends on itself should fail with cycle reference, but got %v", err)
 			return
 		}
 	})
@@ -251,6 +569,40 @@ func TestGetService(t *testing.T) {
 	})
 }
 
+func TestSafeResolve(t *testing.T) {
+	t.Run("resolve service should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		val, err := SafeResolve(globalContainer, reflect.TypeOf((*service1)(nil)).Elem())
+		if err != nil || !val.IsValid() || val.Interface() != svc1 {
+			t.Error("resolve service should success")
+			return
+		}
+	})
+
+	t.Run("panic in resolving should convert into error", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { panic("factory fail") })
+		val, err := SafeResolve(globalContainer, reflect.TypeOf((*serviceInstance1)(nil)))
+		if err == nil || val.IsValid() {
+			t.Error("panic should convert into error")
+			return
+		}
+		if !strings.Contains(err.Error(), "factory fail") || !strings.Contains(err.Error(), "serviceInstance1") {
+			t.Errorf("error should include recovered value and service type, but got '%v'", err)
+			return
+		}
+
+		factoryErr := errors.New("factory error")
+		AddTransient[*serviceInstance2](func() *serviceInstance2 { panic(factoryErr) })
+		if _, err = SafeResolve(globalContainer, reflect.TypeOf((*serviceInstance2)(nil))); !errors.Is(err, factoryErr) {
+			t.Error("recovered error should be wrapped")
+			return
+		}
+	})
+}
+
 func TestInject(t *testing.T) {
 	t.Run("inject to func should success", func(t *testing.T) {
 		globalContainer = New()
@@ -323,6 +675,22 @@ func TestInject(t *testing.T) {
 		}
 	})
 
+	t.Run("inject to impletementation of ioc.SelfInjector should inject by itself", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance4](&serviceInstance4{name: "instance4"})
+
+		var c selfInjectorClient
+		Inject(&c)
+		if c.resolver != globalContainer {
+			t.Error("method 'InjectDeps()' should be invoked with the container")
+			return
+		}
+		if c.F6 != nil {
+			t.Error("should not inject to field by reflection")
+			return
+		}
+	})
+
 	t.Run("inject to impletementation of ioc.Resolve should ignore", func(t *testing.T) {
 		globalContainer = New()
 		c := &defaultContainer{}
@@ -492,6 +860,184 @@ func TestSetParent(t *testing.T) {
 	})
 }
 
+func TestParentChain(t *testing.T) {
+	t.Run("inspect parent chain should success", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		grandparent := New()
+		if globalContainer.(Hierarchical).Parent() != nil || len(globalContainer.(Hierarchical).Ancestors()) != 0 {
+			t.Error("parent should be null")
+			return
+		}
+		SetParent(parent)
+		SetParent(grandparent)
+		if globalContainer.(Hierarchical).Parent() != parent || parent.(Hierarchical).Parent() != grandparent {
+			t.Error("last parent should be parent of the last ancestor")
+			return
+		}
+		ancestors := globalContainer.(Hierarchical).Ancestors()
+		if len(ancestors) != 2 || ancestors[0] != parent || ancestors[1] != grandparent {
+			t.Error("ancestors should be from immediate parent to root")
+			return
+		}
+		SetParent(grandparent)
+		if len(globalContainer.(Hierarchical).Ancestors()) != 2 {
+			t.Error("set parent already in chain should ignore")
+			return
+		}
+	})
+
+	t.Run("cycle in parent chain should fail", func(t *testing.T) {
+		globalContainer = New()
+		a := New()
+		b := New()
+		c := New()
+		a.SetParent(b)
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("cycle reference should fail")
+				} else {
+					fmt.Printf("panic: %v\n", r)
+				}
+			}()
+			b.SetParent(a)
+		}()
+		c.SetParent(c)
+		if c.(Hierarchical).Parent() != nil {
+			t.Error("set parent to self should ignore")
+			return
+		}
+		c.SetParent(b)
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("cycle reference by ancestor should fail")
+				}
+			}()
+			a.SetParent(c)
+		}()
+		if b.(Hierarchical).Parent() != nil || len(a.(Hierarchical).Ancestors()) != 1 {
+			t.Error("parent chain should not change if fail")
+			return
+		}
+		if a.Resolve(reflect.TypeOf((*service1)(nil)).Elem()).IsValid() {
+			t.Error("service should not be found")
+			return
+		}
+	})
+}
+
+func TestInjectFromParent(t *testing.T) {
+	t.Run("inject from parent should bypass current", func(t *testing.T) {
+		globalContainer = New()
+		base := &serviceInstance1{name: "base"}
+		AddSingleton[service1](base)
+		child, _ := globalContainer.(ChildOverrider).NewChildOverriding(OverrideWith[service1](&serviceInstance1{name: "override"}))
+
+		c := &fromParentClient{}
+		InjectFromC(child, c)
+		if c.Base != base {
+			t.Error("field with 'from=parent' should be injected from parent")
+			return
+		}
+		if c.Current == nil || c.Current.GetName() != "override" {
+			t.Error("field without 'from=parent' should be injected from current")
+			return
+		}
+	})
+
+	t.Run("inject from parent without parent should be zero", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		c := &fromParentClient{}
+		Inject(c)
+		if c.Base != nil {
+			t.Error("field with 'from=parent' should be zero if no parent")
+			return
+		}
+		if c.Current == nil {
+			t.Error("field without 'from=parent' should be injected")
+			return
+		}
+	})
+}
+
+func TestInjectReflectValue(t *testing.T) {
+	t.Run("inject to various forms of reflect.Value should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+
+		// interface-wrapped pointer
+		var wrapped any = &fromParentClient{}
+		holder := reflect.ValueOf(&wrapped).Elem()
+		InjectFromC(globalContainer, holder)
+		if wrapped.(*fromParentClient).Current != svc1 {
+			t.Error("pointer wrapped in interface should be injected")
+			return
+		}
+
+		// addressable struct
+		var target fromParentClient
+		InjectFromC(globalContainer, reflect.ValueOf(&target).Elem())
+		if target.Current != svc1 {
+			t.Error("addressable struct should be injected")
+			return
+		}
+
+		// unaddressable struct is ignored
+		InjectFromC(globalContainer, reflect.ValueOf(fromParentClient{}))
+	})
+}
+
+type fromParentClient struct {
+	Base    service1 `ioc-inject:"from=parent"`
+	Current service1 `ioc-inject:"true"`
+}
+
+func TestInjectOrder(t *testing.T) {
+	t.Run("fields should be injected by ascending order and then declaration order", func(t *testing.T) {
+		globalContainer = New()
+		var injected []string
+		AddTransient[service1](func() service1 {
+			injected = append(injected, "A")
+			return &serviceInstance1{}
+		})
+		AddTransient[service2](func() service2 {
+			injected = append(injected, "B")
+			return &serviceInstance2{}
+		})
+		AddTransient[*serviceInstance1](func() *serviceInstance1 {
+			injected = append(injected, "C")
+			return &serviceInstance1{}
+		})
+		AddTransient[*serviceInstance2](func() *serviceInstance2 {
+			injected = append(injected, "D")
+			return &serviceInstance2{}
+		})
+
+		client := &orderedClient{}
+		Inject(client)
+		if client.A == nil || client.B == nil || client.C == nil || client.D == nil {
+			t.Error("all fields should be injected")
+			return
+		}
+		if strings.Join(injected, ",") != "C,B,D,A" {
+			t.Errorf("fields should be injected by order, but %v", injected)
+			return
+		}
+	})
+}
+
+type orderedClient struct {
+	A service1          `ioc-inject:"order=2"`
+	B service2          `ioc-inject:"true"`
+	C *serviceInstance1 `ioc-inject:"order=-1"`
+	D *serviceInstance2 `ioc-inject:"true,order=0"`
+}
+
 func TestContainerAddSingleton(t *testing.T) {
 	t.Run("null service type should fail", func(t *testing.T) {
 		globalContainer = New()
@@ -515,6 +1061,41 @@ func TestContainerAddSingleton(t *testing.T) {
 		}
 	})
 
+	t.Run("nil forms of service instance should fail with distinct errors", func(t *testing.T) {
+		globalContainer = New()
+
+		c := New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		err := c.AddSingleton(serviceType, nil)
+		if err == nil || err.Error() != "param 'instance' is null" {
+			t.Errorf("literal nil should fail as null: %v", err)
+			return
+		}
+		var nilPtr *serviceInstance1
+		err = c.AddSingleton(serviceType, nilPtr)
+		if err == nil || !strings.Contains(err.Error(), "nil '*ioc.serviceInstance1'") {
+			t.Errorf("nil pointer should fail with it's type: %v", err)
+			return
+		}
+		var nilService service1 = nilPtr
+		err = c.AddSingleton(serviceType, nilService)
+		if err == nil || !strings.Contains(err.Error(), "nil '*ioc.serviceInstance1'") {
+			t.Errorf("interface holding nil pointer should fail with it's dynamic type: %v", err)
+			return
+		}
+		err = c.AddSingleton(serviceType, serviceInstance1{})
+		if err == nil || !strings.Contains(err.Error(), "zero value of 'ioc.serviceInstance1'") {
+			t.Errorf("zero value should fail with it's type: %v", err)
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil || !strings.Contains(fmt.Sprint(r), "nil '*ioc.serviceInstance1'") {
+				t.Errorf("generic nil pointer should panic with it's type: %v", r)
+			}
+		}()
+		AddSingleton[*serviceInstance1](nilPtr)
+	})
+
 	t.Run("service instance should impletement service", func(t *testing.T) {
 		globalContainer = New()
 
@@ -551,6 +1132,150 @@ func TestContainerAddTransient(t *testing.T) {
 	})
 }
 
+func TestGetOrAddSingleton(t *testing.T) {
+	t.Run("get or add singleton concurrently should create once", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		var count int32
+		var wg sync.WaitGroup
+		instances := make([]*serviceInstance8, 10)
+		for i := range instances {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				instances[i] = GetOrAddSingleton[*serviceInstance8](func() *serviceInstance8 {
+					atomic.AddInt32(&count, 1)
+					return &serviceInstance8{}
+				})
+			}(i)
+		}
+		wg.Wait()
+		if atomic.LoadInt32(&count) != 1 {
+			t.Error("factory should run once")
+			return
+		}
+		for _, instance := range instances {
+			if instance == nil || instance != instances[0] {
+				t.Error("all callers should get the same instance")
+				return
+			}
+		}
+		if instances[0].GetS7Name() != "instance7" {
+			t.Error("created instance should be initialized")
+			return
+		}
+	})
+
+	t.Run("get exists service should not create", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		if GetOrAddSingleton[service1](func() service1 {
+			t.Error("factory should not run if service exists")
+			return &serviceInstance1{}
+		}) != svc1 {
+			t.Error("exists service should be returned")
+			return
+		}
+		if _, err := globalContainer.(GetOrAdder).GetOrAddSingleton(reflect.TypeOf((*service2)(nil)).Elem(), func() any { return nil }); err == nil {
+			t.Error("null instance should fail")
+			return
+		}
+		if _, err := globalContainer.(GetOrAdder).GetOrAddSingleton(reflect.TypeOf((*service2)(nil)).Elem(), nil); err == nil {
+			t.Error("null factory should fail")
+			return
+		}
+	})
+}
+
+func TestSetStrictResolve(t *testing.T) {
+	t.Run("resolve missing in strict mode should panic", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service1](parent, &serviceInstance1{name: "instance1"})
+		SetParent(parent)
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+
+		if GetService[service1]() == nil {
+			t.Error("service from parent should be resolved")
+			return
+		}
+		c := &strictClient{}
+		Inject(c)
+		if c.S1 == nil || c.S2 != nil {
+			t.Error("optional field should be left zero in strict mode")
+			return
+		}
+		defer func() {
+			r := recover()
+			if err, ok := r.(error); !ok || !strings.Contains(err.Error(), "ioc.service2") {
+				t.Errorf("panic should name the missing type, but %v", r)
+			}
+		}()
+		GetService[service2]()
+	})
+
+	t.Run("resolve missing in lenient mode should be zero", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		globalContainer.(StrictResolver).SetStrictResolve(false)
+		if GetService[service2]() != nil {
+			t.Error("missing service should be zero")
+			return
+		}
+	})
+}
+
+type strictClient struct {
+	S1 service1 `ioc-inject:"true"`
+	S2 service2 `ioc-inject:"true,optional"`
+}
+
+func TestTypeOf(t *testing.T) {
+	t.Run("type of interface and *struct should be the same as reflection", func(t *testing.T) {
+		if TypeOf[service1]() != reflect.TypeOf((*service1)(nil)).Elem() || TypeOf[service1]().Kind() != reflect.Interface {
+			t.Error("type of interface should be the interface itself")
+			return
+		}
+		if TypeOf[*serviceInstance1]() != reflect.TypeOf(&serviceInstance1{}) {
+			t.Error("type of *struct should be the *struct")
+			return
+		}
+		if TypeOf[any]() != reflect.TypeOf((*any)(nil)).Elem() {
+			t.Error("type of 'any' should be the empty interface")
+			return
+		}
+
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		if err := globalContainer.AddSingleton(TypeOf[service1](), svc1); err != nil {
+			t.Errorf("add singleton by type fail: %v", err)
+			return
+		}
+		if GetService[service1]() != svc1 {
+			t.Error("service added by type should be resolved by generic")
+			return
+		}
+	})
+
+	t.Run("types of several types should be in order", func(t *testing.T) {
+		types := TypesOf4[service1, *serviceInstance1, any, int]()
+		if len(types) != 4 || types[0] != TypeOf[service1]() || types[1] != TypeOf[*serviceInstance1]() ||
+			types[2] != TypeOf[any]() || types[3] != TypeOf[int]() {
+			t.Errorf("types should be the same as type of each, but got %v", types)
+			return
+		}
+		if types := TypesOf2[service1, service2](); len(types) != 2 || types[1] != TypeOf[service2]() {
+			t.Errorf("types of 2 types should be in order, but got %v", types)
+			return
+		}
+		if types := TypesOf3[service1, service2, *serviceInstance1](); len(types) != 3 || types[2] != TypeOf[*serviceInstance1]() {
+			t.Errorf("types of 3 types should be in order, but got %v", types)
+			return
+		}
+	})
+}
+
 type service1 interface {
 	GetName() string
 }
@@ -652,6 +1377,15 @@ func (c *client) Func1(p1 service3, p2 *serviceInstance3, p3 service4, p4 *servi
 	c.F4 = p4
 }
 
+type selfInjectorClient struct {
+	F6       *serviceInstance4 `ioc-inject:"true"`
+	resolver Resolver
+}
+
+func (c *selfInjectorClient) InjectDeps(resolver Resolver) {
+	c.resolver = resolver
+}
+
 type serviceInstance7 struct {
 	name string
 }
@@ -722,3 +1456,43 @@ type serviceInstance12 struct {
 func (instance *serviceInstance12) GetName() string {
 	return instance.name
 }
+
+type serviceInstance13 struct {
+	s7               *serviceInstance7
+	initializedTimes int32
+}
+
+func (instance *serviceInstance13) Initialize(s7 *serviceInstance7) {
+	instance.s7 = s7
+	atomic.AddInt32(&instance.initializedTimes, 1)
+}
+
+type initGate struct{}
+
+type interdependentA struct {
+	b *interdependentB
+}
+
+func (instance *interdependentA) Initialize(_ *initGate, b *interdependentB) {
+	instance.b = b
+}
+
+type interdependentB struct {
+	a *interdependentA
+}
+
+func (instance *interdependentB) Initialize(_ *initGate, a *interdependentA) {
+	instance.a = a
+}
+
+type factoryDependent struct {
+	Result *dependentFactoryResult `ioc-inject:"true"`
+}
+
+type dependentFactoryResult struct {
+	owner *factoryDependent
+}
+
+type selfDependent struct {
+	Self *selfDependent `ioc-inject:"true"`
+}
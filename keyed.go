@@ -0,0 +1,237 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// bindingKey is the key of keyed binding.
+type bindingKey struct {
+	ServiceType reflect.Type
+	Key         any
+}
+
+// AddKeyed to add singleton instance with a type-safe comparable key to global container.
+// The same service can be keyed by different key types, and keys of different types are different, such as 'int(1)' and 'int64(1)'.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+//
+//	type Region string
+//	ioc.AddKeyed[Store](Region("eu"), &euStore{})
+//	store := ioc.GetKeyed[Store](Region("eu"))
+func AddKeyed[TService any, TKey comparable](key TKey, instance TService) {
+	AddKeyedToC[TService, TKey](globalContainer, key, instance)
+}
+
+// AddKeyedToC to add singleton instance with a type-safe comparable key to container.
+//
+// It will panic if 'TService' or 'instance' is invalid.
+func AddKeyedToC[TService any, TKey comparable](container Container, key TKey, instance TService) {
+	if err := container.(KeyedContainer).AddKeyedSingleton(key, TypeOf[TService](), instance); err != nil {
+		panic(err)
+	}
+}
+
+// GetKeyed to get keyed service by a type-safe comparable key from global container, returns zero value if not found.
+func GetKeyed[TService any, TKey comparable](key TKey) TService {
+	return GetKeyedFromC[TService, TKey](globalContainer, key)
+}
+
+// GetKeyedFromC to get keyed service by a type-safe comparable key from container, returns zero value if not found.
+func GetKeyedFromC[TService any, TKey comparable](container Container, key TKey) TService {
+	var instance TService
+	if val := container.(KeyedContainer).ResolveKeyed(key, TypeOf[TService]()); val.IsValid() {
+		instance, _ = val.Interface().(TService)
+	}
+	return instance
+}
+
+// KeyedContainer is implemented by container to add and resolve services by key.
+type KeyedContainer interface {
+	// AddKeyedSingleton to add singleton instance with a comparable key, multiple instances of the same service can be added by different keys.
+	// Keyed service can't be resolved by 'Resolve', but by 'ResolveKeyed'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.KeyedContainer).AddKeyedSingleton("Primary", TypeOf[DB](), &MySQL{})
+	//  err = container.(ioc.KeyedContainer).AddKeyedSingleton("Replica", TypeOf[DB](), &MySQL{})
+	AddKeyedSingleton(key any, serviceType reflect.Type, instance any) error
+
+	// AddKeyedTransient to add transient by instance factory with a comparable key.
+	AddKeyedTransient(key any, serviceType reflect.Type, instanceFactory func() any) error
+
+	// ResolveKeyed to get service with key.
+	// It will resolve from parent if not found in current.
+	//
+	//  var container ioc.Container
+	//  db := container.(ioc.KeyedContainer).ResolveKeyed("Primary", TypeOf[DB]())
+	ResolveKeyed(key any, serviceType reflect.Type) reflect.Value
+
+	// ResolveKeyedMap to get all keyed services of the service type, keyed services in current container shadow parent's ones.
+	//
+	//  var container ioc.Container
+	//  dbs := container.(ioc.KeyedContainer).ResolveKeyedMap(TypeOf[DB]())
+	ResolveKeyedMap(serviceType reflect.Type) map[any]reflect.Value
+
+	// SetFieldNameAsKey to use field name as key when injecting to field without explicit key, default is false.
+	//
+	// Precedence of injecting to field: explicit key by 'ioc-inject:"key=XXX"' > field name > type-only.
+	// Field with explicit key won't fall back to others, but field name will fall back to type-only if no keyed service found.
+	//
+	//  type Client struct {
+	//      // inject service 'DB' with key "Primary" if found, or service 'DB' without key
+	//      Primary DB `ioc-inject:"true"`
+	//  }
+	SetFieldNameAsKey(enabled bool)
+}
+
+var _ KeyedContainer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddKeyedSingleton(key any, serviceType reflect.Type, instance any) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if err := checkInstance(instance); err != nil {
+		return err
+	}
+	binding, err := newSingletonBinding(serviceType, instance)
+	if err != nil {
+		return err
+	}
+	return c.addKeyedBinding(key, binding)
+}
+
+func (c *defaultContainer) AddKeyedTransient(key any, serviceType reflect.Type, instanceFactory func() any) error {
+	if err := checkKey(key); err != nil {
+		return err
+	}
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	return c.addKeyedBinding(key, &serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory})
+}
+
+func (c *defaultContainer) ResolveKeyed(key any, serviceType reflect.Type) reflect.Value {
+	return c.resolveKeyed(key, serviceType, c, nil)
+}
+
+func (c *defaultContainer) resolveKeyed(key any, serviceType reflect.Type, origin *defaultContainer, path *resolvePath) reflect.Value {
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return reflect.Value{}
+	}
+	if binding := c.getKeyedBinding(key, serviceType); binding != nil {
+		if binding.NotInherited && origin != c {
+			return reflect.Value{}
+		}
+		if recorder := path.recorderOf(); recorder != nil {
+			recorder.found(c, binding.lifetime())
+		}
+		return binding.resolve(c, origin, path)
+	}
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		return parent.resolveKeyed(key, serviceType, origin, path)
+	case KeyedContainer:
+		return parent.ResolveKeyed(key, serviceType)
+	}
+	return reflect.Value{}
+}
+
+func (c *defaultContainer) ResolveKeyedMap(serviceType reflect.Type) map[any]reflect.Value {
+	instances := make(map[any]reflect.Value)
+	c.resolveKeyedMap(serviceType, c, instances, nil)
+	return instances
+}
+
+func (c *defaultContainer) resolveKeyedMap(serviceType reflect.Type, origin *defaultContainer, instances map[any]reflect.Value, path *resolvePath) {
+	c.keyedBindings.Range(func(key, val any) bool {
+		bk := key.(bindingKey)
+		binding := val.(*serviceBinding)
+		if binding.NotInherited && origin != c {
+			return true
+		}
+		if _, exists := instances[bk.Key]; bk.ServiceType == serviceType && !exists {
+			instances[bk.Key] = binding.resolve(c, origin, path)
+		}
+		return true
+	})
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		parent.resolveKeyedMap(serviceType, origin, instances, path)
+	case KeyedContainer:
+		for key, instance := range parent.ResolveKeyedMap(serviceType) {
+			if _, exists := instances[key]; !exists {
+				instances[key] = instance
+			}
+		}
+	}
+}
+
+func (c *defaultContainer) SetFieldNameAsKey(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&c.fieldNameAsKey, 1)
+	} else {
+		atomic.StoreInt32(&c.fieldNameAsKey, 0)
+	}
+}
+
+func (c *defaultContainer) isFieldNameAsKey() bool {
+	return atomic.LoadInt32(&c.fieldNameAsKey) == 1
+}
+
+func (c *defaultContainer) addKeyedBinding(key any, binding *serviceBinding) error {
+	if err := validateBinding(binding); err != nil {
+		return err
+	}
+	_, err := c.storeBinding(&c.keyedBindings, bindingKey{ServiceType: binding.ServiceType, Key: key}, binding)
+	return err
+}
+
+func (c *defaultContainer) getKeyedBinding(key any, serviceType reflect.Type) *serviceBinding {
+	if bindingVal, ok := c.keyedBindings.Load(bindingKey{ServiceType: serviceType, Key: key}); ok {
+		binding := bindingVal.(*serviceBinding)
+		if binding.Feature != nil && !binding.Feature.isEnabled() {
+			return nil
+		}
+		return binding
+	}
+	return nil
+}
+
+func checkKey(key any) error {
+	if key == nil {
+		return errors.New("param 'key' is null")
+	}
+	if keyType := reflect.TypeOf(key); !keyType.Comparable() {
+		return fmt.Errorf("type of key '%v' should be comparable", keyType)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyed(t *testing.T) {
+	t.Run("resolve keyed service should success", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		primary := &serviceInstance1{name: "primary"}
+		if err := globalContainer.(KeyedContainer).AddKeyedSingleton("Primary", serviceType, primary); err != nil {
+			t.Errorf("add keyed singleton fail: %v", err)
+			return
+		}
+		if err := globalContainer.(KeyedContainer).AddKeyedTransient(2, serviceType, func() any { return &serviceInstance1{name: "2"} }); err != nil {
+			t.Errorf("add keyed transient fail: %v", err)
+			return
+		}
+		if val := globalContainer.(KeyedContainer).ResolveKeyed("Primary", serviceType); !val.IsValid() || val.Interface() != primary {
+			t.Error("keyed singleton should be resolved")
+			return
+		}
+		first := globalContainer.(KeyedContainer).ResolveKeyed(2, serviceType)
+		if !first.IsValid() || first.Interface() == globalContainer.(KeyedContainer).ResolveKeyed(2, serviceType).Interface() {
+			t.Error("keyed transient should be resolved as transient")
+			return
+		}
+		if globalContainer.Resolve(serviceType).IsValid() {
+			t.Error("keyed service should not be resolved without key")
+			return
+		}
+		if globalContainer.(KeyedContainer).ResolveKeyed("Replica", serviceType).IsValid() {
+			t.Error("keyed service not found should be invalid")
+			return
+		}
+	})
+
+	t.Run("resolve keyed service from parent if not found in current", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		primary := &serviceInstance1{name: "primary"}
+		parent.(KeyedContainer).AddKeyedSingleton("Primary", serviceType, primary)
+		SetParent(parent)
+		if val := globalContainer.(KeyedContainer).ResolveKeyed("Primary", serviceType); !val.IsValid() || val.Interface() != primary {
+			t.Error("keyed service should be resolved from parent")
+			return
+		}
+	})
+
+	t.Run("invalid key should fail", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(KeyedContainer).AddKeyedSingleton(nil, serviceType, &serviceInstance1{}); err == nil {
+			t.Error("null key should fail")
+			return
+		}
+		if err := globalContainer.(KeyedContainer).AddKeyedSingleton([]string{"a"}, serviceType, &serviceInstance1{}); err == nil {
+			t.Error("uncomparable key should fail")
+			return
+		}
+	})
+}
+
+type regionKey struct {
+	Region string
+	Zone   int
+}
+
+func TestAddKeyed(t *testing.T) {
+	t.Run("keys of different types should be type-safe and distinct", func(t *testing.T) {
+		globalContainer = New()
+		AddKeyed[service1](1, &serviceInstance1{name: "int"})
+		AddKeyed[service1]("1", &serviceInstance1{name: "string"})
+		AddKeyed[service1](regionKey{Region: "eu", Zone: 1}, &serviceInstance1{name: "struct"})
+		AddKeyed[service1](int64(1), &serviceInstance1{name: "int64"})
+
+		expected := map[string]service1{
+			"int":    GetKeyed[service1](1),
+			"string": GetKeyed[service1]("1"),
+			"struct": GetKeyed[service1](regionKey{Region: "eu", Zone: 1}),
+			"int64":  GetKeyed[service1](int64(1)),
+		}
+		for name, svc := range expected {
+			if svc == nil || svc.GetName() != name {
+				t.Errorf("service keyed by %s should be resolved", name)
+				return
+			}
+		}
+		if GetKeyed[service1](regionKey{Region: "eu", Zone: 2}) != nil || GetKeyed[service1](2) != nil {
+			t.Error("service with key not added should be zero value")
+			return
+		}
+		if GetKeyed[service2](1) != nil {
+			t.Error("key of other service should not be resolved")
+			return
+		}
+		if len(globalContainer.(KeyedContainer).ResolveKeyedMap(TypeOf[service1]())) != 4 {
+			t.Error("all keyed services should be in keyed map")
+			return
+		}
+	})
+}
+
+func TestSetFieldNameAsKey(t *testing.T) {
+	serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+	setup := func() (primary, replica, plain *serviceInstance1) {
+		globalContainer = New()
+		primary = &serviceInstance1{name: "primary"}
+		replica = &serviceInstance1{name: "replica"}
+		plain = &serviceInstance1{name: "plain"}
+		globalContainer.(KeyedContainer).AddKeyedSingleton("Primary", serviceType, primary)
+		globalContainer.(KeyedContainer).AddKeyedSingleton("replica", serviceType, replica)
+		AddSingleton[service1](plain)
+		return
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, replica, plain := setup()
+		var c keyedClient
+		Inject(&c)
+		if c.Primary != plain || c.Secondary != plain {
+			t.Error("field name should not be used as key by default")
+			return
+		}
+		if c.Explicit != replica {
+			t.Error("explicit key should be used")
+			return
+		}
+	})
+
+	t.Run("field name as key and fall back to type-only", func(t *testing.T) {
+		primary, replica, plain := setup()
+		globalContainer.(KeyedContainer).SetFieldNameAsKey(true)
+		var c keyedClient
+		Inject(&c)
+		if c.Primary != primary {
+			t.Error("field name should be used as key")
+			return
+		}
+		if c.Secondary != plain {
+			t.Error("should fall back to type-only if no keyed service found")
+			return
+		}
+		if c.Explicit != replica {
+			t.Error("explicit key should take precedence over field name")
+			return
+		}
+		if c.Missing != nil {
+			t.Error("explicit key should not fall back")
+			return
+		}
+	})
+}
+
+type keyedClient struct {
+	Primary   service1 `ioc-inject:"true"`
+	Secondary service1 `ioc-inject:"true"`
+	Explicit  service1 `ioc-inject:"key=replica"`
+	Missing   service1 `ioc-inject:"true,key=missing"`
+}
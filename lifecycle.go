@@ -0,0 +1,135 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// Runnable is a service that can be started by 'LifecycleRunner.StartAll'.
+type Runnable interface {
+	// Run to start the service, it should return after started, and run long-running work in goroutines.
+	Run(ctx context.Context) error
+}
+
+// Stoppable is a service that can be stopped by 'LifecycleRunner.StopAll'.
+type Stoppable interface {
+	// Stop to stop the service.
+	Stop(ctx context.Context) error
+}
+
+var runnableType reflect.Type = TypeOf[Runnable]()
+
+// LifecycleRunner is implemented by container to start and stop singletons.
+type LifecycleRunner interface {
+	// StartAll to resolve singletons in current container that implement 'ioc.Runnable' in registration order,
+	// and invoke their method 'Run(ctx)'. It won't stop on error, but invoke all and returns the aggregated errors.
+	// Each singleton will be started once even if 'StartAll' is invoked again.
+	StartAll(ctx context.Context) error
+
+	// StopAll to invoke method 'Stop(ctx)' of started singletons that implement 'ioc.Stoppable' in reverse order,
+	// and returns the aggregated errors.
+	StopAll(ctx context.Context) error
+}
+
+var _ LifecycleRunner = (*defaultContainer)(nil)
+
+func (c *defaultContainer) StartAll(ctx context.Context) error {
+	var errs []error
+	for _, binding := range c.singletonBindings() {
+		if !binding.Instance.Type().Implements(runnableType) {
+			continue
+		}
+		instance := binding.resolve(c, c, nil)
+		runnable, ok := instance.Interface().(Runnable)
+		if !ok || c.isStarted(instance) {
+			continue
+		}
+		if err := runnable.Run(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("start service '%v' fail: %w", binding.ServiceType, err))
+			continue
+		}
+		c.locker.Lock()
+		c.started = append(c.started, instance)
+		c.locker.Unlock()
+	}
+	return aggregateErrors(errs)
+}
+
+func (c *defaultContainer) StopAll(ctx context.Context) error {
+	c.locker.Lock()
+	started := c.started
+	c.started = nil
+	c.locker.Unlock()
+
+	var errs []error
+	for i := len(started) - 1; i >= 0; i-- {
+		if stoppable, ok := started[i].Interface().(Stoppable); ok {
+			if err := stoppable.Stop(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("stop service '%v' fail: %w", started[i].Type(), err))
+			}
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+func (c *defaultContainer) isStarted(instance reflect.Value) bool {
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if !instance.Type().Comparable() {
+		return false
+	}
+	for _, started := range c.started {
+		if started.Type() == instance.Type() && started.Interface() == instance.Interface() {
+			return true
+		}
+	}
+	return false
+}
+
+// singletonBindings to get singleton bindings in current container, includes keyed ones, in registration order.
+func (c *defaultContainer) singletonBindings() []*serviceBinding {
+	var bindings []*serviceBinding
+	collect := func(key, val any) bool {
+		if binding := val.(*serviceBinding); binding.Instance.IsValid() && binding.ServiceType != resolverType {
+			bindings = append(bindings, binding)
+		}
+		return true
+	}
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		return collect(serviceType, binding)
+	})
+	c.keyedBindings.Range(func(key, val any) bool {
+		// keyed primary is also stored without key
+		if val.(*serviceBinding).Primary {
+			return true
+		}
+		return collect(key, val)
+	})
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Seq < bindings[j].Seq
+	})
+	return bindings
+}
@@ -0,0 +1,103 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestStartAll(t *testing.T) {
+	t.Run("start and stop all runnables in order", func(t *testing.T) {
+		globalContainer = New()
+		var events []string
+		runnable1 := &runnableInstance{name: "r1", events: &events}
+		runnable2 := &runnableInstance{name: "r2", events: &events}
+		AddSingleton[*runnableInstance](runnable1)
+		AddSingleton[*serviceInstance1](&serviceInstance1{name: "instance1"})
+		AddSingleton[Runnable](runnable2)
+		AddSingleton[Stoppable](runnable1) // same instance should start once
+
+		if err := globalContainer.(LifecycleRunner).StartAll(context.Background()); err != nil {
+			t.Errorf("start all fail: %v", err)
+			return
+		}
+		if err := globalContainer.(LifecycleRunner).StartAll(context.Background()); err != nil {
+			t.Errorf("start all fail: %v", err)
+			return
+		}
+		if err := globalContainer.(LifecycleRunner).StopAll(context.Background()); err != nil {
+			t.Errorf("stop all fail: %v", err)
+			return
+		}
+		expected := []string{"run r1", "run r2", "stop r2", "stop r1"}
+		if len(events) != len(expected) {
+			t.Errorf("events should be %v, but got %v", expected, events)
+			return
+		}
+		for i := range expected {
+			if events[i] != expected[i] {
+				t.Errorf("events should be %v, but got %v", expected, events)
+				return
+			}
+		}
+	})
+
+	t.Run("collect all errors", func(t *testing.T) {
+		globalContainer = New()
+		var events []string
+		runErr := errors.New("run fail")
+		AddSingleton[*runnableInstance](&runnableInstance{name: "r1", events: &events, runErr: runErr})
+		AddSingleton[Runnable](&runnableInstance{name: "r2", events: &events})
+		err := globalContainer.(LifecycleRunner).StartAll(context.Background())
+		if !errors.Is(err, runErr) {
+			t.Errorf("error should be aggregated, but got %v", err)
+			return
+		}
+		if len(events) != 2 {
+			t.Error("should not stop on error")
+			return
+		}
+		globalContainer.(LifecycleRunner).StopAll(context.Background())
+		if len(events) != 3 || events[2] != "stop r2" {
+			t.Error("only started runnables should be stopped")
+			return
+		}
+	})
+}
+
+type runnableInstance struct {
+	name   string
+	events *[]string
+	runErr error
+}
+
+func (r *runnableInstance) Run(ctx context.Context) error {
+	*r.events = append(*r.events, "run "+r.name)
+	return r.runErr
+}
+
+func (r *runnableInstance) Stop(ctx context.Context) error {
+	*r.events = append(*r.events, "stop "+r.name)
+	return nil
+}
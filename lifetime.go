@@ -0,0 +1,126 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// Lifetime of service binding.
+type Lifetime int32
+
+const (
+	// LifetimeUnknown is for service resolved from parent resolver which is not 'ioc.Container'.
+	LifetimeUnknown Lifetime = iota
+	// LifetimeSingleton is for service shared by all resolving.
+	LifetimeSingleton
+	// LifetimeTransient is for service created for each resolving.
+	LifetimeTransient
+	// LifetimeScoped is for service shared in a scope.
+	LifetimeScoped
+	// LifetimeGoroutine is for service shared in a goroutine, see 'GoroutineScopeContainer.AddGoroutineScoped'.
+	LifetimeGoroutine
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case LifetimeSingleton:
+		return "Singleton"
+	case LifetimeTransient:
+		return "Transient"
+	case LifetimeScoped:
+		return "Scoped"
+	case LifetimeGoroutine:
+		return "Goroutine"
+	default:
+		return "Unknown"
+	}
+}
+
+// LifetimeResolver is implemented by container to resolve service with it's lifetime.
+type LifetimeResolver interface {
+	// ResolveWithInfo to get service with it's lifetime, and whether it's found.
+	// It will resolve from parent if not found in current, and the lifetime is reported by the container that actually resolved it.
+	//
+	//  val, lifetime, found := container.(ioc.LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*Service1)(nil)).Elem())
+	//  if found && lifetime == ioc.LifetimeSingleton {
+	//      // it's shared, so cache it
+	//  }
+	ResolveWithInfo(serviceType reflect.Type) (reflect.Value, Lifetime, bool)
+}
+
+var _ LifetimeResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveWithInfo(serviceType reflect.Type) (reflect.Value, Lifetime, bool) {
+	return c.resolveWithInfo(serviceType, c)
+}
+
+func (c *defaultContainer) resolveWithInfo(serviceType reflect.Type, origin *defaultContainer) (reflect.Value, Lifetime, bool) {
+	binding := c.getBinding(serviceType)
+	if binding == nil && len(c.overrides) > 0 {
+		binding = c.getOverridingBinding(serviceType)
+	}
+	if binding != nil {
+		if binding.NotInherited && origin != c {
+			return reflect.Value{}, LifetimeUnknown, false
+		}
+		return binding.resolve(c, origin, nil), binding.lifetime(), true
+	}
+	if val, ok := c.matchResolver(serviceType); ok {
+		return val, LifetimeUnknown, true
+	}
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		return parent.resolveWithInfo(serviceType, origin)
+	case LifetimeResolver:
+		return parent.ResolveWithInfo(serviceType)
+	case nil:
+		return reflect.Value{}, LifetimeUnknown, false
+	default:
+		val := parent.Resolve(serviceType)
+		return val, LifetimeUnknown, val.IsValid()
+	}
+}
+
+// lifetime of binding, it's transient if has factory or selector, except lazy singleton.
+func (b *serviceBinding) lifetime() Lifetime {
+	if b.Target != nil {
+		return b.Target.lifetime()
+	}
+	if b.AliasOf != nil {
+		// it depends on the target resolved
+		return LifetimeUnknown
+	}
+	if b.Scoped {
+		return LifetimeScoped
+	}
+	if b.GoroutineScoped {
+		return LifetimeGoroutine
+	}
+	if b.Lazy {
+		return LifetimeSingleton
+	}
+	if b.InstanceFactory != nil || b.Selector != nil {
+		return LifetimeTransient
+	}
+	return LifetimeSingleton
+}
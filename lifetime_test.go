@@ -0,0 +1,83 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveWithInfo(t *testing.T) {
+	t.Run("resolve with lifetime should success", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingletonToC[service1](parent, svc1)
+		AddTransient[service2](func() service2 { return &serviceInstance2{name: "instance2"} })
+		SetParent(parent)
+
+		val, lifetime, found := globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service1)(nil)).Elem())
+		if !found || lifetime != LifetimeSingleton || val.Interface() != svc1 {
+			t.Error("singleton should be resolved from parent")
+			return
+		}
+		val, lifetime, found = globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service2)(nil)).Elem())
+		if !found || lifetime != LifetimeTransient || !val.IsValid() {
+			t.Error("transient should be resolved")
+			return
+		}
+		if lifetime.String() != "Transient" {
+			t.Error("string of lifetime should be 'Transient'")
+			return
+		}
+		if _, lifetime, found = globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service3)(nil)).Elem()); found || lifetime != LifetimeUnknown {
+			t.Error("service not found should fail")
+			return
+		}
+	})
+
+	t.Run("resolve from resolver which is not container should be unknown lifetime", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		SetParent(plainResolver{svc1})
+
+		val, lifetime, found := globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service1)(nil)).Elem())
+		if !found || lifetime != LifetimeUnknown || val.Interface() != svc1 {
+			t.Error("service should be resolved with unknown lifetime")
+			return
+		}
+	})
+}
+
+// plainResolver is a Resolver which is not Container.
+type plainResolver struct {
+	svc1 service1
+}
+
+func (r plainResolver) SetParent(parent Resolver) {}
+
+func (r plainResolver) Resolve(serviceType reflect.Type) reflect.Value {
+	if serviceType == reflect.TypeOf((*service1)(nil)).Elem() {
+		return reflect.ValueOf(r.svc1)
+	}
+	return reflect.Value{}
+}
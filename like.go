@@ -0,0 +1,41 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+// ResolveLike to get service from global container, whose type is inferred from static type of 'sample',
+// and the value of 'sample' is ignored. It's the same as 'GetService[TService]()', but without specifying type parameter.
+//
+//	func fill[T any](target *T) {
+//	    *target = ioc.ResolveLike(*target)
+//	}
+//
+//	var svc Service1
+//	fill(&svc)
+func ResolveLike[TService any](sample TService) TService {
+	return GetServiceFromC[TService](globalContainer)
+}
+
+// ResolveLikeFromC to get service from container, whose type is inferred from static type of 'sample',
+// and the value of 'sample' is ignored. It's the same as 'GetServiceFromC[TService](container)'.
+func ResolveLikeFromC[TService any](container Container, sample TService) TService {
+	return GetServiceFromC[TService](container)
+}
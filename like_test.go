@@ -0,0 +1,69 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+// resolveInto to resolve service of the type of target in generic code.
+func resolveInto[T any](target *T) {
+	*target = ResolveLike(*target)
+}
+
+func TestResolveLike(t *testing.T) {
+	t.Run("type should be inferred from interface sample", func(t *testing.T) {
+		globalContainer = New()
+		instance := &serviceInstance1{name: "s1"}
+		AddSingleton[service1](instance)
+		var svc service1
+		resolveInto(&svc)
+		if svc != service1(instance) {
+			t.Error("service should be resolved by type of sample")
+			return
+		}
+		if ResolveLikeFromC(globalContainer, svc) != GetService[service1]() {
+			t.Error("it should be the same as 'GetService'")
+			return
+		}
+	})
+
+	t.Run("type should be inferred from pointer-struct sample", func(t *testing.T) {
+		globalContainer = New()
+		instance := &serviceInstance1{name: "s1"}
+		AddSingleton[*serviceInstance1](instance)
+		sample := &serviceInstance1{name: "sample"}
+		resolveInto(&sample)
+		if sample != instance {
+			t.Error("service should be resolved by type of sample, and value of sample should be ignored")
+			return
+		}
+	})
+
+	t.Run("service not found should be zero value", func(t *testing.T) {
+		globalContainer = New()
+		if ResolveLike[service2](&serviceInstance2{}) != nil {
+			t.Error("service not found should be zero value")
+			return
+		}
+	})
+}
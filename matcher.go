@@ -0,0 +1,73 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// ResolverAdder is implemented by container to add custom resolution functions.
+type ResolverAdder interface {
+	// AddResolver to add custom resolution function, which is consulted when no binding of service in current container,
+	// before resolving from parent. Multiple matchers are tried in order, and the first matched one wins,
+	// matched value which is invalid or can't be assigned to service type is skipped.
+	//
+	// Precedence: binding > custom matchers > parent.
+	//
+	//  container.(ioc.ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+	//      if serviceType.Kind() == reflect.Interface && strings.HasSuffix(serviceType.Name(), "Client") {
+	//          return newClientProxy(serviceType), true
+	//      }
+	//      return reflect.Value{}, false
+	//  })
+	AddResolver(matcher func(serviceType reflect.Type) (reflect.Value, bool))
+}
+
+var _ ResolverAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddResolver(matcher func(serviceType reflect.Type) (reflect.Value, bool)) {
+	if matcher == nil {
+		return
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	matchers, _ := c.matchers.Load().([]func(serviceType reflect.Type) (reflect.Value, bool))
+	// copy on write, so matchers can be read without lock
+	newMatchers := make([]func(serviceType reflect.Type) (reflect.Value, bool), 0, len(matchers)+1)
+	newMatchers = append(newMatchers, matchers...)
+	newMatchers = append(newMatchers, matcher)
+	c.matchers.Store(newMatchers)
+}
+
+// matchResolver to resolve by custom matchers in order, skip matched value which is invalid or can't be assigned to service type.
+func (c *defaultContainer) matchResolver(serviceType reflect.Type) (reflect.Value, bool) {
+	matchers, _ := c.matchers.Load().([]func(serviceType reflect.Type) (reflect.Value, bool))
+	if len(matchers) == 0 || serviceType == nil {
+		return reflect.Value{}, false
+	}
+	for _, matcher := range matchers {
+		if val, ok := matcher(serviceType); ok && val.IsValid() && val.Type().AssignableTo(serviceType) {
+			return val, true
+		}
+	}
+	return reflect.Value{}, false
+}
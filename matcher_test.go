@@ -0,0 +1,70 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAddResolver(t *testing.T) {
+	t.Run("resolve by custom matcher should success", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "parent"})
+		AddSingletonToC[service2](parent, &serviceInstance2{name: "parent"})
+		SetParent(parent)
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		globalContainer.(ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+			// invalid matched value should be skipped
+			return reflect.ValueOf("invalid"), true
+		})
+		globalContainer.(ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+			if strings.HasPrefix(serviceType.String(), "ioc.service") {
+				return reflect.ValueOf(&serviceInstance2{name: "synthesized " + serviceType.String()}), true
+			}
+			return reflect.Value{}, false
+		})
+		globalContainer.(ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+			return reflect.ValueOf(&serviceInstance1{name: "never"}), true
+		})
+
+		if GetService[service1]() != svc1 {
+			t.Error("binding should have higher precedence than matcher")
+			return
+		}
+		if svc := GetService[service3](); svc == nil || svc.GetName() != "synthesized ioc.service3" {
+			t.Error("service should be synthesized by the first matched matcher")
+			return
+		}
+		if svc := GetService[service2](); svc == nil || svc.GetName() != "synthesized ioc.service2" {
+			t.Error("matcher should have higher precedence than parent")
+			return
+		}
+		if GetService[*serviceInstance7]().name != "parent" {
+			t.Error("service not matched should be resolved from parent")
+			return
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sync/atomic"
+)
+
+// unlimitedFields is the value of 'maxFields' set to unlimited, while 0 is not set and inherited from parent.
+const unlimitedFields int32 = -1
+
+// InjectedFieldsLimiter is implemented by container to limit count of fields injected to each struct.
+type InjectedFieldsLimiter interface {
+	// SetMaxInjectedFields to limit count of fields to inject for each struct, as guardrail of generated or untrusted types,
+	// and 0 for unlimited. It's inherited from parent if not set, and unlimited by default.
+	// Injecting to struct exceeding the limit will panic, and it's error of 'ResolveE' and 'GetServiceE'.
+	SetMaxInjectedFields(n int)
+}
+
+var _ InjectedFieldsLimiter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetMaxInjectedFields(n int) {
+	if n <= 0 {
+		atomic.StoreInt32(&c.maxFields, unlimitedFields)
+		return
+	}
+	if n > math.MaxInt32 {
+		n = math.MaxInt32
+	}
+	atomic.StoreInt32(&c.maxFields, int32(n))
+}
+
+// maxInjectedFields to get limit of fields to inject set by current or the nearest parent, 0 for unlimited.
+func (c *defaultContainer) maxInjectedFields() int {
+	for current := c; current != nil; {
+		if limit := atomic.LoadInt32(&current.maxFields); limit != 0 {
+			if limit == unlimitedFields {
+				return 0
+			}
+			return int(limit)
+		}
+		parent, ok := current.parent.(*defaultContainer)
+		if !ok {
+			break
+		}
+		current = parent
+	}
+	return 0
+}
+
+// fieldsToInject to get fields to inject of struct, and panic if its count exceeds the limit before walking all of them.
+func (c *defaultContainer) fieldsToInject(structType reflect.Type) []structField {
+	limit := c.maxInjectedFields()
+	fields, ok := walkFieldsToInject(structType, limit)
+	if !ok {
+		panic(fmt.Errorf("count of fields to inject of struct '%v' exceeds the limit %d", structType, limit))
+	}
+	return fields
+}
@@ -0,0 +1,102 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+type manyFieldsClient struct {
+	S1 service1 `ioc-inject:"true"`
+	S2 service2 `ioc-inject:"true"`
+	S3 service3 `ioc-inject:"true"`
+}
+
+func TestSetMaxInjectedFields(t *testing.T) {
+	t.Run("struct exceeding the limit should fail", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(InjectedFieldsLimiter).SetMaxInjectedFields(2)
+		AddSingleton[*manyFieldsClient](&manyFieldsClient{})
+		_, err := GetServiceE[*manyFieldsClient]()
+		if err == nil || !strings.Contains(err.Error(), "exceeds the limit 2") {
+			t.Errorf("should return error for exceeding the limit, but '%v'", err)
+			return
+		}
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("injecting should panic for exceeding the limit")
+				}
+			}()
+			Inject(&manyFieldsClient{})
+		}()
+	})
+
+	t.Run("struct within the limit should be injected", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(InjectedFieldsLimiter).SetMaxInjectedFields(3)
+		AddSingleton[service1](&serviceInstance1{})
+		client := &manyFieldsClient{}
+		Inject(client)
+		if client.S1 == nil {
+			t.Error("struct within the limit should be injected")
+			return
+		}
+	})
+
+	t.Run("child should inherit the limit of parent", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(InjectedFieldsLimiter).SetMaxInjectedFields(2)
+		child := globalContainer.(ScopeContainer).NewScope()
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("injecting by child should panic for exceeding the limit of parent")
+				}
+			}()
+			InjectFromC(child, &manyFieldsClient{})
+		}()
+
+		child.(InjectedFieldsLimiter).SetMaxInjectedFields(0)
+		AddSingleton[service1](&serviceInstance1{})
+		client := &manyFieldsClient{}
+		InjectFromC(child, client)
+		if client.S1 == nil {
+			t.Error("struct should be injected if child is unlimited")
+			return
+		}
+	})
+
+	t.Run("zero should be unlimited", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(InjectedFieldsLimiter).SetMaxInjectedFields(1)
+		globalContainer.(InjectedFieldsLimiter).SetMaxInjectedFields(0)
+		AddSingleton[service1](&serviceInstance1{})
+		client := &manyFieldsClient{}
+		Inject(client)
+		if client.S1 == nil {
+			t.Error("struct should be injected if unlimited")
+			return
+		}
+	})
+}
@@ -0,0 +1,147 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// MemoizeCapacity is the max count of instances cached by 'Memoizer.Memoize' for each service.
+const MemoizeCapacity = 256
+
+// Memoize to cache instances of transient service 'TService' in global container by key returned from 'keyFn'.
+//
+// It will panic if 'TService' is not transient in global container, or 'keyFn' is null.
+func Memoize[TService any](keyFn func() any) {
+	MemoizeToC[TService](globalContainer, keyFn)
+}
+
+// MemoizeToC to cache instances of transient service 'TService' in container by key returned from 'keyFn'.
+//
+// It will panic if 'TService' is not transient in container, or 'keyFn' is null.
+func MemoizeToC[TService any](container Container, keyFn func() any) {
+	if err := container.(Memoizer).Memoize(TypeOf[TService](), keyFn); err != nil {
+		panic(err)
+	}
+}
+
+// Memoizer is implemented by container to cache instances of transient service by key.
+type Memoizer interface {
+	// Memoize to cache instances of transient service in current container by key returned from 'keyFn' when resolving,
+	// so identical keys return the cached instance while distinct keys create new ones.
+	// At most 'ioc.MemoizeCapacity' instances are cached, and the least recently used one is evicted when it's full.
+	//
+	//  err := container.(ioc.Memoizer).Memoize(reflect.TypeOf((*TenantService)(nil)).Elem(), func() any {
+	//      return currentTenantID()
+	//  })
+	Memoize(serviceType reflect.Type, keyFn func() any) error
+}
+
+var _ Memoizer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Memoize(serviceType reflect.Type, keyFn func() any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if keyFn == nil {
+		return errors.New("param 'keyFn' is null")
+	}
+	binding := c.getBinding(serviceType)
+	if binding == nil || binding.InstanceFactory == nil || binding.Scoped || binding.Lazy || binding.GoroutineScoped || binding.TTL != nil {
+		return fmt.Errorf("service '%v' should be transient in current container", serviceType)
+	}
+	binding.Memo.Store(&memoCache{keyFn: keyFn, capacity: MemoizeCapacity, entries: make(map[any]*list.Element), lru: list.New()})
+	return nil
+}
+
+// memoCache is LRU cache of instances by key.
+type memoCache struct {
+	keyFn    func() any
+	capacity int
+
+	locker  sync.Mutex
+	entries map[any]*list.Element // key -> element of *memoEntry
+	lru     *list.List            // most recently used at front
+}
+
+// memoEntry is instance cached by key, which is created once.
+type memoEntry struct {
+	key      any
+	once     sync.Once
+	instance reflect.Value
+}
+
+// get instance by key, and create it if not cached. Instance is created without cache if key is not comparable.
+func (m *memoCache) get(create func() reflect.Value) reflect.Value {
+	key := m.keyFn()
+	if key == nil || !reflect.TypeOf(key).Comparable() {
+		return create()
+	}
+
+	m.locker.Lock()
+	var entry *memoEntry
+	if elem, ok := m.entries[key]; ok {
+		m.lru.MoveToFront(elem)
+		entry = elem.Value.(*memoEntry)
+	} else {
+		entry = &memoEntry{key: key}
+		m.entries[key] = m.lru.PushFront(entry)
+		if m.lru.Len() > m.capacity {
+			oldest := m.lru.Back()
+			m.lru.Remove(oldest)
+			delete(m.entries, oldest.Value.(*memoEntry).key)
+		}
+	}
+	m.locker.Unlock()
+
+	// create outside of lock, so factory can resolve other services
+	created := false
+	defer func() {
+		if !created {
+			// not cache panic of factory, so it's created again on next resolving
+			m.remove(key, entry)
+		}
+	}()
+	entry.once.Do(func() {
+		entry.instance = create()
+	})
+	created = true
+	if !entry.instance.IsValid() {
+		// not cache failure, such as recovered panic of factory
+		m.remove(key, entry)
+	}
+	return entry.instance
+}
+
+// remove to remove entry of key if it's not replaced.
+func (m *memoCache) remove(key any, entry *memoEntry) {
+	m.locker.Lock()
+	if elem, ok := m.entries[key]; ok && elem.Value == entry {
+		m.lru.Remove(elem)
+		delete(m.entries, key)
+	}
+	m.locker.Unlock()
+}
@@ -0,0 +1,116 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMemoize(t *testing.T) {
+	t.Run("memoized transient should be cached by key", func(t *testing.T) {
+		globalContainer = New()
+		tenant := "a"
+		var created int32
+		AddTransient[service1](func() service1 {
+			atomic.AddInt32(&created, 1)
+			return &serviceInstance1{name: tenant}
+		})
+		Memoize[service1](func() any { return tenant })
+
+		a := GetService[service1]()
+		if a != GetService[service1]() {
+			t.Error("identical key should return cached instance")
+			return
+		}
+		tenant = "b"
+		b := GetService[service1]()
+		if b == a || b.GetName() != "b" {
+			t.Error("distinct key should create new instance")
+			return
+		}
+		tenant = "a"
+		if GetService[service1]() != a || atomic.LoadInt32(&created) != 2 {
+			t.Error("instance of key 'a' should still be cached")
+			return
+		}
+	})
+
+	t.Run("least recently used should be evicted", func(t *testing.T) {
+		globalContainer = New()
+		key := 0
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		Memoize[service1](func() any { return key })
+
+		first := GetService[service1]()
+		for key = 1; key <= MemoizeCapacity; key++ {
+			GetService[service1]()
+		}
+		key = 0
+		if GetService[service1]() == first {
+			t.Error("least recently used instance should be evicted")
+			return
+		}
+	})
+
+	t.Run("concurrent resolving should create once for each key", func(t *testing.T) {
+		globalContainer = New()
+		var created int32
+		AddTransient[service1](func() service1 {
+			atomic.AddInt32(&created, 1)
+			return &serviceInstance1{}
+		})
+		Memoize[service1](func() any { return "tenant" })
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				GetService[service1]()
+			}()
+		}
+		wg.Wait()
+		if atomic.LoadInt32(&created) != 1 {
+			t.Error("instance should be created once")
+			return
+		}
+	})
+
+	t.Run("memoize not transient should fail", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{})
+		if err := globalContainer.(Memoizer).Memoize(TypeOf[service1](), func() any { return 1 }); err == nil {
+			t.Error("memoize singleton should fail")
+			return
+		}
+		if err := globalContainer.(Memoizer).Memoize(TypeOf[service2](), func() any { return 1 }); err == nil {
+			t.Error("memoize service not found should fail")
+			return
+		}
+		if err := globalContainer.(Memoizer).Memoize(TypeOf[service1](), nil); err == nil {
+			t.Error("null key func should fail")
+			return
+		}
+	})
+}
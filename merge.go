@@ -0,0 +1,183 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// mergeItem is registration of source to merge.
+type mergeItem struct {
+	binding *serviceBinding
+	reg     registration
+	element bool // element of collection, see 'AddElementFactory'
+}
+
+// Merger is implemented by container to copy registrations into another container.
+type Merger interface {
+	// MergeInto to copy registrations of current container into 'dst' created by 'ioc.New', and conflicts are resolved by
+	// duplicate policy of 'dst', see 'SetDuplicatePolicy'. Unlike 'SetParent' and 'Union', 'dst' is self-contained after merging,
+	// such as the final container assembled from modules at startup, and registrations added to current later are not merged.
+	// If 'shareSingletons' is true, registrations are shared with current, so singletons are initialized once for both.
+	// Otherwise registrations with factory are copied without instances cached, so singletons are created again by 'dst',
+	// while the ones can't be created again are still shared, that is singleton registered by instance and services added by
+	// 'AddSingletonAsync', 'AddSwappable' and 'AddContextScoped'.
+	// It returns aggregated error of registrations failed to merge, and the others are still merged.
+	//
+	//  app := ioc.New()
+	//  app.(ioc.DuplicatePolicySetter).SetDuplicatePolicy(ioc.DuplicateError)
+	//  err := billingModule.(ioc.Merger).MergeInto(app, false)
+	MergeInto(dst Container, shareSingletons bool) error
+}
+
+var _ Merger = (*defaultContainer)(nil)
+
+func (c *defaultContainer) MergeInto(dst Container, shareSingletons bool) error {
+	if dst == nil {
+		return errors.New("param 'dst' is null")
+	}
+	target, ok := dst.(*defaultContainer)
+	if !ok {
+		return errors.New("param 'dst' should be created by 'ioc.New'")
+	}
+	if target == c {
+		return errors.New("can't merge container into itself")
+	}
+
+	copies := make(map[*serviceBinding]*serviceBinding)
+	var errs []error
+	for _, item := range c.mergeItems() {
+		var binding *serviceBinding
+		if shareSingletons {
+			binding = shareBinding(item.binding)
+		} else {
+			binding = copyBinding(item.binding, copies)
+		}
+		var err error
+		switch {
+		case item.element:
+			err = target.addElement(binding)
+		case len(item.reg.Profiles) > 0:
+			err = target.addProfiled(&item.reg, binding)
+		default:
+			err = target.addRegistration(&item.reg, binding)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service '%v': %w", item.binding.ServiceType, err))
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// mergeItems to get registrations in current container, the ones by type and key in registration order,
+// and then groups by name, elements of collections and the ones with profiles.
+func (c *defaultContainer) mergeItems() []mergeItem {
+	var items []mergeItem
+	keyed := make(map[*serviceBinding]bool)
+	c.keyedBindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		keyed[binding] = true
+		items = append(items, mergeItem{binding: binding, reg: registration{Key: key.(bindingKey).Key, HasKey: true, Primary: binding.Primary}})
+		return true
+	})
+	c.bindings.Range(func(key, val any) bool {
+		if binding := val.(*serviceBinding); binding.ServiceType != resolverType && !keyed[binding] {
+			items = append(items, mergeItem{binding: binding, reg: registration{Primary: binding.Primary}})
+		}
+		return true
+	})
+	var elements []mergeItem
+	c.elements.Range(func(key, val any) bool {
+		for _, binding := range val.([]*serviceBinding) {
+			elements = append(elements, mergeItem{binding: binding, element: true})
+		}
+		return true
+	})
+	items = append(items, elements...)
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].binding.Seq < items[j].binding.Seq
+	})
+
+	c.locker.Lock()
+	defer c.locker.Unlock()
+	groupNames := make([]string, 0, len(c.groups))
+	for groupName := range c.groups {
+		groupNames = append(groupNames, groupName)
+	}
+	sort.Strings(groupNames)
+	for _, groupName := range groupNames {
+		for _, binding := range c.groups[groupName] {
+			items = append(items, mergeItem{binding: binding, reg: registration{Group: groupName}})
+		}
+	}
+	var profiled []mergeItem
+	for _, bindings := range c.profiled {
+		for _, binding := range bindings {
+			profiled = append(profiled, mergeItem{binding: binding, reg: registration{Profiles: binding.Profiles, Primary: binding.Primary}})
+		}
+	}
+	sort.Slice(profiled, func(i, j int) bool {
+		return profiled[i].binding.Seq < profiled[j].binding.Seq
+	})
+	return append(items, profiled...)
+}
+
+// shareBinding to create binding which shares resolving and instances with binding of source.
+func shareBinding(b *serviceBinding) *serviceBinding {
+	shared := &serviceBinding{
+		ServiceType:  b.ServiceType,
+		Order:        b.Order,
+		DisposeOrder: b.DisposeOrder,
+		NotInherited: b.NotInherited,
+		Feature:      b.Feature,
+	}
+	if b.AliasOf != nil {
+		// target of alias is resolved in destination
+		shared.AliasOf = b.AliasOf
+	} else {
+		shared.Target = b.implementation()
+	}
+	return shared
+}
+
+// copyBinding to copy binding of source without instances initialized or cached, 'copies' is the copied ones by source.
+// Binding which can't be created again, such as singleton registered by instance, is shared instead.
+func copyBinding(b *serviceBinding, copies map[*serviceBinding]*serviceBinding) *serviceBinding {
+	if copied, ok := copies[b]; ok {
+		return copied
+	}
+	if impl := b.implementation(); impl.Instance.IsValid() || impl.Async != nil || impl.Swappable != nil || impl.ContextScoped != nil {
+		// not inject to and initialize the instance again
+		shared := shareBinding(b)
+		copies[b] = shared
+		return shared
+	}
+	copied := b.clone()
+	copied.cloneLifetime(b)
+	copies[b] = copied
+	if b.Target != nil {
+		copied.Target = copyBinding(b.Target, copies)
+	}
+	return copied
+}
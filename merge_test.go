@@ -0,0 +1,177 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+type mergedService struct {
+	Dep   service2 `ioc-inject:"true"`
+	inits int32
+}
+
+func (s *mergedService) Initialize() {
+	atomic.AddInt32(&s.inits, 1)
+}
+
+func TestMergeInto(t *testing.T) {
+	t.Run("registrations should be merged without conflicts", func(t *testing.T) {
+		src, dst := New(), New()
+		AddSingletonToC[service1](src, &serviceInstance1{name: "singleton"})
+		AddTransientToC[service2](src, func() service2 { return &serviceInstance2{} })
+		AddKeyedToC[service3](src, "primary", service3(&serviceInstance2{}))
+		AddToGroupToC[service4](src, "handlers", &serviceInstance2{})
+		AddSingletonToC[service5](dst, &serviceInstance2{})
+		if err := src.(Merger).MergeInto(dst, false); err != nil {
+			t.Error(err)
+			return
+		}
+		if svc := GetServiceFromC[service1](dst); svc == nil || svc.GetName() != "singleton" {
+			t.Error("singleton should be merged")
+			return
+		}
+		if first, second := GetServiceFromC[service2](dst), GetServiceFromC[service2](dst); first == nil || first == second {
+			t.Error("transient should be merged")
+			return
+		}
+		if GetKeyedFromC[service3](dst, "primary") == nil {
+			t.Error("keyed service should be merged")
+			return
+		}
+		if len(ResolveGroupFromC[service4](dst, "handlers")) != 1 {
+			t.Error("group should be merged")
+			return
+		}
+		if GetServiceFromC[service5](dst) == nil {
+			t.Error("registration of destination should be kept")
+			return
+		}
+		AddSingletonToC[service6](src, &serviceInstance2{})
+		if GetServiceFromC[service6](dst) != nil {
+			t.Error("registration added to source after merging should not be merged")
+			return
+		}
+	})
+
+	t.Run("conflicts should follow duplicate policy of destination", func(t *testing.T) {
+		policies := []DuplicatePolicy{DuplicateIgnore, DuplicateError, DuplicateReplace}
+		for _, policy := range policies {
+			src, dst := New(), New()
+			dst.(DuplicatePolicySetter).SetDuplicatePolicy(policy)
+			AddSingletonToC[service1](src, &serviceInstance1{name: "src"})
+			AddSingletonToC[service1](dst, &serviceInstance1{name: "dst"})
+			AddSingletonToC[service2](src, &serviceInstance2{})
+			err := src.(Merger).MergeInto(dst, false)
+			name := GetServiceFromC[service1](dst).GetName()
+			switch policy {
+			case DuplicateIgnore:
+				if err != nil || name != "dst" {
+					t.Errorf("existing service should be kept with '%v', but '%s', %v", policy, name, err)
+					return
+				}
+			case DuplicateError:
+				if err == nil || name != "dst" {
+					t.Errorf("conflict should be error with '%v', but '%s', %v", policy, name, err)
+					return
+				}
+			case DuplicateReplace:
+				if err != nil || name != "src" {
+					t.Errorf("existing service should be replaced with '%v', but '%s', %v", policy, name, err)
+					return
+				}
+			}
+			if GetServiceFromC[service2](dst) == nil {
+				t.Errorf("service without conflict should be merged with '%v'", policy)
+				return
+			}
+		}
+	})
+
+	t.Run("singletons should be shared", func(t *testing.T) {
+		src, dst := New(), New()
+		AddSingletonToC[service2](src, &serviceInstance2{})
+		svc := &mergedService{}
+		AddSingletonToC[*mergedService](src, svc)
+		if err := src.(Merger).MergeInto(dst, true); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetServiceFromC[*mergedService](src) != svc || GetServiceFromC[*mergedService](dst) != svc {
+			t.Error("singleton should be shared")
+			return
+		}
+		if inits := atomic.LoadInt32(&svc.inits); inits != 1 {
+			t.Errorf("shared singleton should be initialized once, but %d", inits)
+			return
+		}
+	})
+
+	t.Run("singletons with factory should be created again if not shared", func(t *testing.T) {
+		src, dst := New(), New()
+		AddSingletonToC[service2](src, &serviceInstance2{})
+		dep := &serviceInstance2{}
+		AddSingletonToC[service2](dst, dep)
+		svc := &mergedService{}
+		AddSingletonToC[*mergedService](src, svc)
+		lazyCount := 0
+		if err := src.(FactoryForAdder).AddFactoryFor(TypeOf[service1](), func(r Resolver) (any, error) {
+			lazyCount++
+			return &serviceInstance1{}, nil
+		}, AsSingleton()); err != nil {
+			t.Error(err)
+			return
+		}
+		GetServiceFromC[*mergedService](src)
+		GetServiceFromC[service1](src)
+		if err := src.(Merger).MergeInto(dst, false); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetServiceFromC[*mergedService](dst) != svc || svc.Dep == service2(dep) {
+			t.Error("singleton instance should be shared instead of injected again by destination")
+			return
+		}
+		if inits := atomic.LoadInt32(&svc.inits); inits != 1 {
+			t.Errorf("singleton instance should not be initialized again, but %d", inits)
+			return
+		}
+		first, second := GetServiceFromC[service1](dst), GetServiceFromC[service1](dst)
+		if lazyCount != 2 || first != second {
+			t.Errorf("lazy singleton should be created once again by destination, but %d", lazyCount)
+			return
+		}
+	})
+
+	t.Run("invalid destination should be rejected", func(t *testing.T) {
+		src := New()
+		if err := src.(Merger).MergeInto(nil, false); err == nil {
+			t.Error("null destination should be rejected")
+			return
+		}
+		if err := src.(Merger).MergeInto(src, false); err == nil {
+			t.Error("merging into itself should be rejected")
+			return
+		}
+	})
+}
@@ -0,0 +1,158 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// MissingDependencies to get dependencies of service 'TService' in global container which can't be resolved currently.
+func MissingDependencies[TService any]() []reflect.Type {
+	return MissingDependenciesFromC[TService](globalContainer)
+}
+
+// MissingDependenciesFromC to get dependencies of service 'TService' in container which can't be resolved currently.
+func MissingDependenciesFromC[TService any](container Container) []reflect.Type {
+	return container.(MissingChecker).MissingDependencies(TypeOf[TService]())
+}
+
+// MissingChecker is implemented by container to find dependencies of singleton which can't be resolved.
+type MissingChecker interface {
+	// MissingDependencies to get dependencies of registered singleton which can't be resolved currently,
+	// includes injectable fields and params of initialize method, but not transitively.
+	//
+	//  for _, missing := range container.(ioc.MissingChecker).MissingDependencies(reflect.TypeOf((*Service1)(nil)).Elem()) {
+	//      log.Printf("missing dependency '%v'", missing)
+	//  }
+	MissingDependencies(serviceType reflect.Type) []reflect.Type
+}
+
+var _ MissingChecker = (*defaultContainer)(nil)
+
+func (c *defaultContainer) MissingDependencies(serviceType reflect.Type) []reflect.Type {
+	if serviceType == nil {
+		return nil
+	}
+	binding := c.lookupBinding(serviceType)
+	if binding == nil {
+		return nil
+	}
+	return c.missingDependencies(binding)
+}
+
+// missingDependencies to get dependencies of singleton binding which can't be resolved from current container.
+func (c *defaultContainer) missingDependencies(binding *serviceBinding) []reflect.Type {
+	binding = binding.implementation()
+	if !binding.Instance.IsValid() {
+		return nil
+	}
+	var missing []reflect.Type
+	found := make(map[reflect.Type]bool)
+	addMissing := func(depType reflect.Type) {
+		if !found[depType] {
+			found[depType] = true
+			missing = append(missing, depType)
+		}
+	}
+	for _, field := range getFieldsToInject(binding.Instance.Type()) {
+		if !c.canResolveField(field) {
+			addMissing(field.FieldType)
+		}
+	}
+	if binding.InstanceInitializer.IsValid() {
+		methodType := binding.InstanceInitializer.Type()
+		for i := 0; i < methodType.NumIn(); i++ {
+			if !c.canResolve(methodType.In(i)) {
+				addMissing(methodType.In(i))
+			}
+		}
+	}
+	return missing
+}
+
+// canResolveField to check whether field can be resolved, with the same rule as 'resolveField', but without resolving in current container.
+func (c *defaultContainer) canResolveField(field structField) bool {
+	if field.HasConfig {
+		// config value is not a dependency
+		return true
+	}
+	if field.FromParent {
+		switch parent := c.parent.(type) {
+		case *defaultContainer:
+			field.FromParent = false
+			return parent.canResolveField(field)
+		case nil:
+			return false
+		default:
+			return resolveField(c, field, nil).IsValid()
+		}
+	}
+	switch {
+	case field.OptionalOf != nil, field.FieldType.Kind() == reflect.Slice, field.FieldType.Kind() == reflect.Map:
+		// empty is allowed
+		return true
+	case field.ProviderOf != nil:
+		return c.canResolveProvider(field)
+	case field.HasKey:
+		return c.canResolveKeyed(field.Key, field.FieldType)
+	}
+	if c.isFieldNameAsKey() && c.canResolveKeyed(field.FieldName, field.FieldType) {
+		return true
+	}
+	return c.canResolve(field.FieldType)
+}
+
+// canResolve to check whether service can be resolved from current container or parent chain, without resolving in current container.
+func (c *defaultContainer) canResolve(serviceType reflect.Type) bool {
+	if c.getBinding(serviceType) != nil {
+		return true
+	}
+	if _, ok := c.matchResolver(serviceType); ok {
+		return true
+	}
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		return parent.canResolve(serviceType)
+	case DryResolver:
+		// plan without creating instance in the parent
+		_, err := parent.DryResolve(serviceType)
+		return err == nil
+	case nil:
+		return false
+	default:
+		return parent.Resolve(serviceType).IsValid()
+	}
+}
+
+// canResolveKeyed to check whether keyed service can be resolved from current container or parent chain, without resolving in current container.
+func (c *defaultContainer) canResolveKeyed(key any, serviceType reflect.Type) bool {
+	if c.getKeyedBinding(key, serviceType) != nil {
+		return true
+	}
+	switch parent := c.parent.(type) {
+	case *defaultContainer:
+		return parent.canResolveKeyed(key, serviceType)
+	case KeyedContainer:
+		return parent.ResolveKeyed(key, serviceType).IsValid()
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingDependencies(t *testing.T) {
+	t.Run("missing dependencies should be returned", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		AddSingleton[*missingClient](&missingClient{})
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		missing := MissingDependencies[*serviceInstance8]()
+		if len(missing) != 1 || missing[0] != reflect.TypeOf((*serviceInstance7)(nil)) {
+			t.Error("param of initialize method should be missing")
+			return
+		}
+		missing = MissingDependencies[*missingClient]()
+		if len(missing) != 2 || missing[0] != reflect.TypeOf((*service2)(nil)).Elem() || missing[1] != reflect.TypeOf((*service1)(nil)).Elem() {
+			t.Errorf("unresolvable fields should be missing, but %v", missing)
+			return
+		}
+		if GetService[*missingClient]() == nil {
+			t.Error("service should still be resolvable")
+			return
+		}
+	})
+
+	t.Run("dependencies resolvable from parent should not be missing", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "instance7"})
+		AddSingletonToC[*serviceInstance8](parent, &serviceInstance8{})
+		SetParent(parent)
+
+		if missing := MissingDependencies[*serviceInstance8](); len(missing) != 0 {
+			t.Errorf("no dependencies should be missing, but %v", missing)
+			return
+		}
+		if missing := MissingDependencies[*serviceInstance1](); missing != nil {
+			t.Error("service not registered should have no missing dependencies")
+			return
+		}
+	})
+}
+
+type missingClient struct {
+	Found    service1            `ioc-inject:"true"`
+	NotFound service2            `ioc-inject:"true"`
+	Keyed    service1            `ioc-inject:"key=abc"`
+	Group    []service1          `ioc-inject:"group=group1"`
+	Map      map[string]service1 `ioc-inject:"true"`
+}
@@ -0,0 +1,54 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+// Get2 to get 2 services from global container, missing service is zero value as 'GetService'.
+//
+//	service1, service2 := ioc.Get2[Service1, *ServiceImplementation2]()
+func Get2[A, B any]() (A, B) {
+	return Get2FromC[A, B](globalContainer)
+}
+
+// Get2FromC to get 2 services from container, missing service is zero value as 'GetServiceFromC'.
+func Get2FromC[A, B any](container Container) (A, B) {
+	return GetServiceFromC[A](container), GetServiceFromC[B](container)
+}
+
+// Get3 to get 3 services from global container, missing service is zero value as 'GetService'.
+func Get3[A, B, C any]() (A, B, C) {
+	return Get3FromC[A, B, C](globalContainer)
+}
+
+// Get3FromC to get 3 services from container, missing service is zero value as 'GetServiceFromC'.
+func Get3FromC[A, B, C any](container Container) (A, B, C) {
+	return GetServiceFromC[A](container), GetServiceFromC[B](container), GetServiceFromC[C](container)
+}
+
+// Get4 to get 4 services from global container, missing service is zero value as 'GetService'.
+func Get4[A, B, C, D any]() (A, B, C, D) {
+	return Get4FromC[A, B, C, D](globalContainer)
+}
+
+// Get4FromC to get 4 services from container, missing service is zero value as 'GetServiceFromC'.
+func Get4FromC[A, B, C, D any](container Container) (A, B, C, D) {
+	return GetServiceFromC[A](container), GetServiceFromC[B](container), GetServiceFromC[C](container), GetServiceFromC[D](container)
+}
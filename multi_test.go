@@ -0,0 +1,53 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestGetMulti(t *testing.T) {
+	t.Run("get multiple services should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		svc7 := &serviceInstance7{name: "instance7"}
+		AddSingleton[service1](svc1)
+		AddSingleton[*serviceInstance7](svc7)
+		AddTransient[service2](func() service2 { return &serviceInstance2{name: "instance2"} })
+
+		s1, s7 := Get2[service1, *serviceInstance7]()
+		if s1 != svc1 || s7 != svc7 {
+			t.Error("2 services should be resolved")
+			return
+		}
+		s1, s7, s2 := Get3[service1, *serviceInstance7, service2]()
+		if s1 != svc1 || s7 != svc7 || s2 == nil {
+			t.Error("3 services should be resolved")
+			return
+		}
+		s1, s7, s2, s3 := Get4[service1, *serviceInstance7, service2, service3]()
+		if s1 != svc1 || s7 != svc7 || s2 == nil || s3 != nil {
+			t.Error("4 services should be resolved, and missing one should be zero")
+			return
+		}
+	})
+}
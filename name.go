@@ -0,0 +1,119 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// NameResolver is implemented by container to resolve service by name of service type.
+type NameResolver interface {
+	// ResolveByName to get service by name of service type, for wiring by config file or plugin.
+	// It will resolve from parent if not found in current.
+	//
+	// Name can be the full form with package path, such as "*github.com/xxx/yyy.Service1" or "github.com/xxx/yyy.Service1",
+	// or the short form as reflect.Type.String(), such as "*yyy.Service1" or "yyy.Service1",
+	// but the short form will be ambiguous and returns false if types from different packages have the same short form.
+	//
+	// Names are indexed when registering, which adds a small registration cost.
+	ResolveByName(typeName string) (reflect.Value, bool)
+}
+
+var _ NameResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveByName(typeName string) (reflect.Value, bool) {
+	c.locker.Lock()
+	serviceTypes := c.typeNames[typeName]
+	c.locker.Unlock()
+	switch len(serviceTypes) {
+	case 0:
+		if val, ok := c.resolveAdoptedByName(typeName); ok {
+			return val, true
+		}
+		if parent, ok := c.parent.(NameResolver); ok {
+			return parent.ResolveByName(typeName)
+		}
+		return reflect.Value{}, false
+	case 1:
+		val := c.Resolve(serviceTypes[0])
+		return val, val.IsValid()
+	default:
+		// ambiguous
+		return reflect.Value{}, false
+	}
+}
+
+// indexTypeName to index service type by it's full name and short name, should be invoked with lock.
+func (c *defaultContainer) indexTypeName(serviceType reflect.Type) {
+	if c.typeNames == nil {
+		c.typeNames = make(map[string][]reflect.Type)
+	}
+	for _, name := range typeNamesOf(serviceType) {
+		exists := false
+		for _, t := range c.typeNames[name] {
+			if t == serviceType {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			c.typeNames[name] = append(c.typeNames[name], serviceType)
+		}
+	}
+}
+
+// unindexTypeName to remove service type from name index, should be invoked with lock.
+func (c *defaultContainer) unindexTypeName(serviceType reflect.Type) {
+	for _, name := range typeNamesOf(serviceType) {
+		types := c.typeNames[name]
+		for i, t := range types {
+			if t == serviceType {
+				types = append(types[:i:i], types[i+1:]...)
+				break
+			}
+		}
+		if len(types) == 0 {
+			delete(c.typeNames, name)
+		} else {
+			c.typeNames[name] = types
+		}
+	}
+}
+
+// typeNamesOf to get the full name with package path, and the short name of type.
+func typeNamesOf(t reflect.Type) []string {
+	shortName := t.String()
+	if fullName := typeFullName(t); fullName != shortName {
+		return []string{fullName, shortName}
+	}
+	return []string{shortName}
+}
+
+func typeFullName(t reflect.Type) string {
+	if t.Kind() == reflect.Pointer {
+		return "*" + typeFullName(t.Elem())
+	}
+	if t.Name() != "" && t.PkgPath() != "" {
+		return t.PkgPath() + "." + t.Name()
+	}
+	return t.String()
+}
@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	htmltemplate "html/template"
+	"testing"
+	texttemplate "text/template"
+)
+
+func TestResolveByName(t *testing.T) {
+	t.Run("resolve by full name or short name should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		AddSingleton[*serviceInstance1](svc1)
+
+		for _, name := range []string{"gopkg.berkaroad.top/ioc.service1", "ioc.service1", "*gopkg.berkaroad.top/ioc.serviceInstance1", "*ioc.serviceInstance1"} {
+			val, ok := globalContainer.(NameResolver).ResolveByName(name)
+			if !ok || val.Interface() != svc1 {
+				t.Errorf("resolve by name '%s' should success", name)
+				return
+			}
+		}
+		if _, ok := globalContainer.(NameResolver).ResolveByName("ioc.service2"); ok {
+			t.Error("resolve by name not registered should fail")
+			return
+		}
+	})
+
+	t.Run("resolve by ambiguous short name should fail", func(t *testing.T) {
+		globalContainer = New()
+		textTmpl := texttemplate.New("text")
+		htmlTmpl := htmltemplate.New("html")
+		AddSingleton[*texttemplate.Template](textTmpl)
+		AddSingleton[*htmltemplate.Template](htmlTmpl)
+
+		if _, ok := globalContainer.(NameResolver).ResolveByName("*template.Template"); ok {
+			t.Error("resolve by ambiguous short name should fail")
+			return
+		}
+		if val, ok := globalContainer.(NameResolver).ResolveByName("*text/template.Template"); !ok || val.Interface() != textTmpl {
+			t.Error("resolve by full name should success")
+			return
+		}
+		if val, ok := globalContainer.(NameResolver).ResolveByName("*html/template.Template"); !ok || val.Interface() != htmlTmpl {
+			t.Error("resolve by full name should success")
+			return
+		}
+	})
+
+	t.Run("resolve by name from parent and temporary singleton", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingletonToC[service1](parent, svc1)
+		SetParent(parent)
+		if val, ok := globalContainer.(NameResolver).ResolveByName("ioc.service1"); !ok || val.Interface() != svc1 {
+			t.Error("resolve by name from parent should success")
+			return
+		}
+
+		tempSvc1 := &serviceInstance1{name: "temp"}
+		WithSingleton[service1](tempSvc1, func() {
+			if val, ok := globalContainer.(NameResolver).ResolveByName("ioc.service1"); !ok || val.Interface() != tempSvc1 {
+				t.Error("resolve by name of temporary singleton should success")
+			}
+		})
+		if val, ok := globalContainer.(NameResolver).ResolveByName("ioc.service1"); !ok || val.Interface() != svc1 {
+			t.Error("name of temporary singleton should be removed")
+			return
+		}
+	})
+}
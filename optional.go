@@ -0,0 +1,89 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// Optional dependency, which is used as type of field tagged with 'ioc-inject', and never fails injection.
+//
+// Different from field of interface which is nil both if not registered and if resolved to nil,
+// 'Value' returns true if it's resolved even if it's nil, and false if not registered.
+//
+//	type Client struct {
+//	    Cache ioc.Optional[Cache] `ioc-inject:"true"`
+//	}
+//	if cache, ok := client.Cache.Value(); ok {
+//	    // use cache
+//	}
+type Optional[T any] struct {
+	value T
+	ok    bool
+}
+
+// Value of the optional dependency, and whether it's resolved.
+func (o Optional[T]) Value() (T, bool) {
+	return o.value, o.ok
+}
+
+// optionalSetter is implemented by *Optional[T], for injection.
+type optionalSetter interface {
+	optionalType() reflect.Type
+	set(val reflect.Value)
+}
+
+func (o *Optional[T]) optionalType() reflect.Type {
+	return TypeOf[T]()
+}
+
+func (o *Optional[T]) set(val reflect.Value) {
+	if val.IsValid() {
+		if !val.IsZero() {
+			o.value, _ = val.Interface().(T)
+		}
+		o.ok = true
+	}
+}
+
+var optionalSetterType reflect.Type = TypeOf[optionalSetter]()
+
+// optionalTypeOf to get type of service if it's 'ioc.Optional[T]', otherwise nil.
+func optionalTypeOf(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() != reflect.Struct || !reflect.PtrTo(fieldType).Implements(optionalSetterType) {
+		return nil
+	}
+	return reflect.New(fieldType).Interface().(optionalSetter).optionalType()
+}
+
+// resolveOptional to resolve field of 'ioc.Optional[T]' without panic in strict mode.
+func resolveOptional(container Container, field structField, path *resolvePath) reflect.Value {
+	inner := field
+	inner.FieldType = field.OptionalOf
+	inner.OptionalOf = nil
+	inner.Optional = true
+	// parent has been applied
+	inner.FromParent = false
+	optional := reflect.New(field.FieldType)
+	optional.Interface().(optionalSetter).set(resolveField(container, inner, path))
+	return optional.Elem()
+}
@@ -0,0 +1,62 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestOptional(t *testing.T) {
+	t.Run("inject optional should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](svc1)
+		AddTransient[service3](func() service3 { return nil })
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+
+		c := &optionalClient{}
+		Inject(c)
+		if val, ok := c.Present.Value(); !ok || val != svc1 {
+			t.Error("present dependency should be resolved")
+			return
+		}
+		if val, ok := c.Absent.Value(); ok || val != nil {
+			t.Error("absent dependency should be empty without panic in strict mode")
+			return
+		}
+		if val, ok := c.Nil.Value(); !ok || val != nil {
+			t.Error("dependency resolved to nil should be distinguished from not registered")
+			return
+		}
+		AddSingleton[*optionalClient](&optionalClient{})
+		if missing := MissingDependenciesFromC[*optionalClient](globalContainer); len(missing) != 0 {
+			t.Error("optional dependency should not be missing")
+			return
+		}
+	})
+}
+
+type optionalClient struct {
+	Present Optional[service1] `ioc-inject:"true"`
+	Absent  Optional[service2] `ioc-inject:"true"`
+	Nil     Optional[service3] `ioc-inject:"true"`
+}
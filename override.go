@@ -0,0 +1,165 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// Override is a service to override in child container, see 'ChildOverrider.NewChildOverriding'.
+type Override struct {
+	ServiceType reflect.Type
+	Instance    any
+}
+
+// OverrideWith to create an override of service 'TService'.
+//
+//	child, err := container.(ioc.ChildOverrider).NewChildOverriding(ioc.OverrideWith[Logger](&FileLogger{}))
+func OverrideWith[TService any](instance TService) Override {
+	return Override{
+		ServiceType: TypeOf[TService](),
+		Instance:    instance,
+	}
+}
+
+// ChildOverrider is implemented by container to create child container with overridden services.
+type ChildOverrider interface {
+	// NewChildOverriding to create a child container whose parent is current one, and add 'overrides' to the child as singleton.
+	//
+	// Resolving through the child, a singleton registered in the parent chain, that depends on any overridden service
+	// directly or transitively (by injectable fields or params of it's initialize method), will be copied and initialized in the child,
+	// so the overrides take effect only for services resolved through the child, and the parent's instance is untouched.
+	// Others are shared with the parent as usual.
+	//
+	// Only singleton whose instance is a *struct can be copied, others are always shared with the parent.
+	//
+	//  child, err := container.(ioc.ChildOverrider).NewChildOverriding(ioc.OverrideWith[Logger](&FileLogger{}))
+	//  // 'Service1' in parent depends on 'Logger', but resolved through child with '*FileLogger'
+	//  service1 := ioc.GetServiceFromC[Service1](child)
+	NewChildOverriding(overrides ...Override) (Container, error)
+}
+
+var _ ChildOverrider = (*defaultContainer)(nil)
+
+func (c *defaultContainer) NewChildOverriding(overrides ...Override) (Container, error) {
+	child := &defaultContainer{overrides: make(map[reflect.Type]struct{}, len(overrides))}
+	if err := child.AddSingleton(resolverType, child); err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		if override.ServiceType == nil {
+			return nil, errors.New("param 'overrides' has null service type")
+		}
+		if err := child.AddSingleton(override.ServiceType, override.Instance); err != nil {
+			return nil, err
+		}
+		child.overrides[override.ServiceType] = struct{}{}
+	}
+	child.SetParent(c)
+	return child, nil
+}
+
+// getOverridingBinding to get the copy of singleton from parent chain, which depends on overridden services.
+func (c *defaultContainer) getOverridingBinding(serviceType reflect.Type) *serviceBinding {
+	parentBinding := c.lookupBindingFromParent(serviceType)
+	if parentBinding == nil || !parentBinding.Instance.IsValid() || parentBinding.NotInherited {
+		return nil
+	}
+	instanceType := parentBinding.Instance.Type()
+	if instanceType.Kind() != reflect.Pointer || instanceType.Elem().Kind() != reflect.Struct || parentBinding.Instance.IsNil() {
+		return nil
+	}
+	if !c.dependsOnOverrides(parentBinding, map[reflect.Type]bool{serviceType: true}) {
+		return nil
+	}
+
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if binding := c.getBinding(serviceType); binding != nil {
+		return binding
+	}
+	instance := reflect.New(instanceType.Elem())
+	instance.Elem().Set(parentBinding.Instance.Elem())
+	binding := &serviceBinding{ServiceType: serviceType, Instance: instance, InitCallback: parentBinding.InitCallback}
+	if parentBinding.InstanceInitializerName != "" {
+		binding.InstanceInitializer = instance.MethodByName(parentBinding.InstanceInitializerName)
+		binding.InstanceInitializerName = parentBinding.InstanceInitializerName
+	}
+	c.bindings.Store(serviceType, binding)
+	c.indexTypeName(serviceType)
+	c.bindingsChanged()
+	return binding
+}
+
+// dependsOnOverrides to check whether the singleton depends on overridden services directly or transitively.
+func (c *defaultContainer) dependsOnOverrides(binding *serviceBinding, visited map[reflect.Type]bool) bool {
+	for _, depType := range binding.dependencies() {
+		if _, ok := c.overrides[depType]; ok {
+			return true
+		}
+		if visited[depType] {
+			continue
+		}
+		visited[depType] = true
+		if depBinding := c.lookupBinding(depType); depBinding != nil && c.dependsOnOverrides(depBinding, visited) {
+			return true
+		}
+	}
+	return false
+}
+
+// lookupBinding to get binding from current container and then the parent chain.
+func (c *defaultContainer) lookupBinding(serviceType reflect.Type) *serviceBinding {
+	if binding := c.getBinding(serviceType); binding != nil {
+		return binding
+	}
+	return c.lookupBindingFromParent(serviceType)
+}
+
+// lookupBindingFromParent to get binding from the parent chain.
+func (c *defaultContainer) lookupBindingFromParent(serviceType reflect.Type) *serviceBinding {
+	if parentC, ok := c.parent.(*defaultContainer); ok {
+		return parentC.lookupBinding(serviceType)
+	}
+	return nil
+}
+
+// dependencies of singleton, includes injectable fields and params of initialize method.
+func (b *serviceBinding) dependencies() []reflect.Type {
+	if !b.Instance.IsValid() {
+		return nil
+	}
+	var deps []reflect.Type
+	for _, field := range getFieldsToInject(b.Instance.Type()) {
+		if !field.HasConfig {
+			deps = append(deps, field.FieldType)
+		}
+	}
+	if b.InstanceInitializer.IsValid() {
+		methodType := b.InstanceInitializer.Type()
+		for i := 0; i < methodType.NumIn(); i++ {
+			deps = append(deps, methodType.In(i))
+		}
+	}
+	return deps
+}
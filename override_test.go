@@ -0,0 +1,114 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestNewChildOverriding(t *testing.T) {
+	t.Run("singleton in parent depends on overridden service should use the override", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[logger](parent, &loggerInstance{name: "parent"})
+		AddSingletonToC[*loggingService](parent, &loggingService{})
+		AddSingletonToC[*loggingClient](parent, &loggingClient{})
+
+		child, err := parent.(ChildOverrider).NewChildOverriding(OverrideWith[logger](&loggerInstance{name: "child"}))
+		if err != nil {
+			t.Errorf("create child fail: %v", err)
+			return
+		}
+		svcFromChild := GetServiceFromC[*loggingService](child)
+		if svcFromChild == nil || svcFromChild.Logger.GetName() != "child" {
+			t.Error("service resolved through child should use overridden dependency")
+			return
+		}
+		clientFromChild := GetServiceFromC[*loggingClient](child)
+		if clientFromChild == nil || clientFromChild.svc != svcFromChild {
+			t.Error("service depends on overridden service transitively should be copied in child")
+			return
+		}
+		svcFromParent := GetServiceFromC[*loggingService](parent)
+		if svcFromParent == nil || svcFromParent == svcFromChild || svcFromParent.Logger.GetName() != "parent" {
+			t.Error("service resolved through parent should not be affected")
+			return
+		}
+		if GetServiceFromC[*loggingService](child) != svcFromChild {
+			t.Error("copied service should be singleton in child")
+			return
+		}
+	})
+
+	t.Run("singleton in parent not depends on overridden service should be shared", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[logger](parent, &loggerInstance{name: "parent"})
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "instance7"})
+
+		child, err := parent.(ChildOverrider).NewChildOverriding(OverrideWith[logger](&loggerInstance{name: "child"}))
+		if err != nil {
+			t.Errorf("create child fail: %v", err)
+			return
+		}
+		if GetServiceFromC[*serviceInstance7](child) != GetServiceFromC[*serviceInstance7](parent) {
+			t.Error("service should be shared with parent")
+			return
+		}
+	})
+
+	t.Run("invalid override should fail", func(t *testing.T) {
+		globalContainer = New()
+		if _, err := New().(ChildOverrider).NewChildOverriding(Override{}); err == nil {
+			t.Error("override with null service type should fail")
+			return
+		}
+		if _, err := New().(ChildOverrider).NewChildOverriding(OverrideWith[logger](nil)); err == nil {
+			t.Error("override with null instance should fail")
+			return
+		}
+	})
+}
+
+type logger interface {
+	GetName() string
+}
+
+type loggerInstance struct {
+	name string
+}
+
+func (instance *loggerInstance) GetName() string {
+	return instance.name
+}
+
+type loggingService struct {
+	Logger logger `ioc-inject:"true"`
+}
+
+type loggingClient struct {
+	svc *loggingService
+}
+
+func (instance *loggingClient) Initialize(svc *loggingService) {
+	instance.svc = svc
+}
@@ -0,0 +1,108 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// PartialInject to fill params of 'fn' resolvable from global container, and returns func 'T' takes the remaining params.
+//
+// It will panic if 'fn' is not func, or signature of 'T' doesn't match the remaining params and results of 'fn'.
+//
+//	getUser := ioc.PartialInject[func(id int) User](func(db *DB, id int) User {
+//	    return db.GetUser(id)
+//	})
+//	user := getUser(1)
+func PartialInject[T any](fn any) T {
+	return PartialInjectFromC[T](globalContainer, fn)
+}
+
+// PartialInjectFromC to fill params of 'fn' resolvable from container, and returns func 'T' takes the remaining params.
+// Params are resolved once when 'PartialInjectFromC' is invoked, and the resolvable ones are filled,
+// so the remaining params in order, with results of 'fn', should be the same as signature of 'T'.
+//
+// It will panic if 'fn' is not func, or signature of 'T' doesn't match the remaining params and results of 'fn'.
+func PartialInjectFromC[T any](container Container, fn any) T {
+	fnVal := reflect.ValueOf(fn)
+	if fn == nil || fnVal.Kind() != reflect.Func || fnVal.IsNil() {
+		panic(fmt.Errorf("param 'fn' should be func, but '%T'", fn))
+	}
+	targetType := TypeOf[T]()
+	if targetType.Kind() != reflect.Func {
+		panic(fmt.Errorf("'%v' should be func", targetType))
+	}
+
+	fnType := fnVal.Type()
+	filled := make([]reflect.Value, fnType.NumIn())
+	var remaining []reflect.Type
+	for i := 0; i < fnType.NumIn(); i++ {
+		paramType := fnType.In(i)
+		if fnType.IsVariadic() && i == fnType.NumIn()-1 {
+			// variadic param is always left to caller
+			remaining = append(remaining, paramType)
+			continue
+		}
+		if val, err := SafeResolve(container, paramType); err == nil && val.IsValid() {
+			filled[i] = val
+		} else {
+			remaining = append(remaining, paramType)
+		}
+	}
+	if err := checkPartialSignature(targetType, fnType, remaining); err != nil {
+		panic(err)
+	}
+
+	partial := reflect.MakeFunc(targetType, func(args []reflect.Value) []reflect.Value {
+		in := make([]reflect.Value, len(filled))
+		for i, j := 0, 0; i < len(filled); i++ {
+			if filled[i].IsValid() {
+				in[i] = filled[i]
+			} else {
+				in[i] = args[j]
+				j++
+			}
+		}
+		if fnType.IsVariadic() {
+			return fnVal.CallSlice(in)
+		}
+		return fnVal.Call(in)
+	})
+	return partial.Interface().(T)
+}
+
+// checkPartialSignature to check whether signature of target func is the remaining params and results of 'fn'.
+func checkPartialSignature(targetType reflect.Type, fnType reflect.Type, remaining []reflect.Type) error {
+	matched := targetType.NumIn() == len(remaining) && targetType.NumOut() == fnType.NumOut() &&
+		targetType.IsVariadic() == fnType.IsVariadic()
+	for i := 0; matched && i < len(remaining); i++ {
+		matched = targetType.In(i) == remaining[i]
+	}
+	for i := 0; matched && i < fnType.NumOut(); i++ {
+		matched = targetType.Out(i) == fnType.Out(i)
+	}
+	if !matched {
+		return fmt.Errorf("'%v' should take the remaining params %v and return results of '%v'", targetType, remaining, fnType)
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPartialInject(t *testing.T) {
+	t.Run("resolvable params should be filled", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+		AddSingleton[service2](&serviceInstance2{name: "s2"})
+
+		greet := PartialInject[func(greeting string) string](func(s1 service1, greeting string) string {
+			return greeting + " " + s1.GetName()
+		})
+		if greet("hello") != "hello s1" {
+			t.Error("resolvable param should be filled")
+			return
+		}
+
+		join := PartialInject[func(prefix string, suffix int) (string, error)](func(prefix string, s1 service1, suffix int, s2 service2) (string, error) {
+			return strings.Join([]string{prefix, s1.GetName(), s2.GetName()}, "-") + strings.Repeat("!", suffix), nil
+		})
+		if result, err := join("p", 2); err != nil || result != "p-s1-s2!!" {
+			t.Errorf("params should be filled in order, but '%s'", result)
+			return
+		}
+
+		all := PartialInject[func() string](func(s1 service1, s2 service2) string {
+			return s1.GetName() + s2.GetName()
+		})
+		if all() != "s1s2" {
+			t.Error("all params should be filled")
+			return
+		}
+
+		variadic := PartialInject[func(names ...string) int](func(s1 service1, names ...string) int {
+			return len(names)
+		})
+		if variadic("a", "b") != 2 {
+			t.Error("variadic param should be left to caller")
+			return
+		}
+	})
+
+	t.Run("signature not matched should panic", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+		invalids := []func(){
+			func() { PartialInject[func(string) string]("not func") },
+			func() { PartialInject[string](func(s1 service1) string { return "" }) },
+			func() { PartialInject[func(service1) string](func(s1 service1) string { return "" }) },
+			func() { PartialInject[func(id int)](func(s1 service1, id int) string { return "" }) },
+		}
+		for i, invalid := range invalids {
+			if !func() (panicked bool) {
+				defer func() {
+					panicked = recover() != nil
+				}()
+				invalid()
+				return
+			}() {
+				t.Errorf("invalid[%d] should panic", i)
+				return
+			}
+		}
+	})
+}
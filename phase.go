@@ -0,0 +1,80 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+)
+
+// PhaseRunner is implemented by container to run hooks by named phase.
+type PhaseRunner interface {
+	// RegisterLifecycleHook to register hook to run by 'RunPhase' of named phase, such as "migrate", "warm" and "serve",
+	// which orders startup and shutdown work decoupled from initialization of services. Hooks of the same phase are all kept.
+	//
+	//  container.(ioc.PhaseRunner).RegisterLifecycleHook("migrate", func(r ioc.Resolver) {
+	//      ioc.GetServiceFromC[*Migrator](r.(ioc.Container)).Migrate()
+	//  })
+	RegisterLifecycleHook(phase string, fn func(r Resolver))
+
+	// RunPhase to run all hooks registered for phase in registration order with current container as resolver.
+	// It won't stop if a hook panics, but run all and returns the aggregated errors of panics.
+	RunPhase(phase string) error
+}
+
+var _ PhaseRunner = (*defaultContainer)(nil)
+
+func (c *defaultContainer) RegisterLifecycleHook(phase string, fn func(r Resolver)) {
+	if fn == nil {
+		return
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if c.phaseHooks == nil {
+		c.phaseHooks = make(map[string][]func(r Resolver))
+	}
+	c.phaseHooks[phase] = append(c.phaseHooks[phase], fn)
+}
+
+func (c *defaultContainer) RunPhase(phase string) error {
+	c.locker.Lock()
+	hooks := append([]func(r Resolver){}, c.phaseHooks[phase]...)
+	c.locker.Unlock()
+
+	var errs []error
+	for i, hook := range hooks {
+		if err := runHook(hook, c); err != nil {
+			errs = append(errs, fmt.Errorf("hook[%d] of phase '%s' fail: %w", i, phase, err))
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// runHook to run hook, and recover panic as error.
+func runHook(hook func(r Resolver), resolver Resolver) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = panicError("hook panic", r)
+		}
+	}()
+	hook(resolver)
+	return nil
+}
@@ -0,0 +1,77 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRunPhase(t *testing.T) {
+	t.Run("hooks should run by phase in registration order", func(t *testing.T) {
+		globalContainer = New()
+		var log []string
+		hook := func(name string) func(r Resolver) {
+			return func(r Resolver) {
+				if r != globalContainer {
+					t.Error("resolver should be the container")
+				}
+				log = append(log, name)
+			}
+		}
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("migrate", hook("migrate1"))
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("warm", hook("warm1"))
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("migrate", hook("migrate2"))
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("serve", hook("serve1"))
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("warm", nil)
+		for _, phase := range []string{"migrate", "warm", "serve", "unknown"} {
+			if err := globalContainer.(PhaseRunner).RunPhase(phase); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		if expected := []string{"migrate1", "migrate2", "warm1", "serve1"}; !reflect.DeepEqual(log, expected) {
+			t.Errorf("expected %v, but got %v", expected, log)
+			return
+		}
+	})
+
+	t.Run("panics of hooks should be aggregated", func(t *testing.T) {
+		globalContainer = New()
+		hookErr := errors.New("migrate fail")
+		ran := false
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("migrate", func(r Resolver) { panic(hookErr) })
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("migrate", func(r Resolver) { panic("other fail") })
+		globalContainer.(PhaseRunner).RegisterLifecycleHook("migrate", func(r Resolver) { ran = true })
+		err := globalContainer.(PhaseRunner).RunPhase("migrate")
+		var aggregateErr *AggregateError
+		if !errors.As(err, &aggregateErr) || len(aggregateErr.Errors) != 2 || !errors.Is(err, hookErr) {
+			t.Errorf("panics of hooks should be aggregated, but got '%v'", err)
+			return
+		}
+		if !ran {
+			t.Error("hooks after panic should run")
+			return
+		}
+	})
+}
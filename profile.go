@@ -0,0 +1,185 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// profileSet is the snapshot of active profiles, with the binding resolved for each service registered with profiles.
+type profileSet struct {
+	active   []string
+	bindings map[reflect.Type]*serviceBinding
+}
+
+// isActive to check whether any of profiles of binding is active.
+func (s *profileSet) isActive(binding *serviceBinding) bool {
+	for _, profile := range binding.Profiles {
+		for _, active := range s.active {
+			if profile == active {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ProfileActivator is implemented by container to activate profiles of services.
+type ProfileActivator interface {
+	// SetActiveProfiles to activate profiles such as "prod" and "eu", which replaces the active ones, and no profiles to deactivate all.
+	// Service registered with 'ioc.ForProfiles' is resolved instead of the one without profiles, only if any of it's profiles
+	// is active. If multiple ones of a service are active, the one registered with 'ioc.AsPrimary' is resolved.
+	// It returns error without changing active profiles if multiple ones of a service are active and none or more than one is primary.
+	//
+	//  err := container.(ioc.ProfileActivator).SetActiveProfiles(strings.Split(os.Getenv("APP_PROFILES"), ",")...)
+	SetActiveProfiles(profiles ...string) error
+
+	// ActiveProfiles to get profiles activated by 'SetActiveProfiles'.
+	ActiveProfiles() []string
+}
+
+var _ ProfileActivator = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetActiveProfiles(profiles ...string) error {
+	active := make([]string, 0, len(profiles))
+	for _, profile := range profiles {
+		if profile != "" {
+			active = append(active, profile)
+		}
+	}
+	c.locker.Lock()
+	set, err := c.chooseProfiled(active)
+	if err == nil {
+		c.profiles.Store(set)
+	}
+	c.locker.Unlock()
+	if err != nil {
+		return err
+	}
+	c.bindingsChanged()
+	return nil
+}
+
+func (c *defaultContainer) ActiveProfiles() []string {
+	if set := c.loadProfiles(); set != nil {
+		return append([]string(nil), set.active...)
+	}
+	return nil
+}
+
+// loadProfiles to get snapshot of active profiles, nil if never set and no service registered with profiles.
+func (c *defaultContainer) loadProfiles() *profileSet {
+	set, _ := c.profiles.Load().(*profileSet)
+	return set
+}
+
+// getProfiledBinding to get binding of service registered with active profiles, nil if not found.
+func (c *defaultContainer) getProfiledBinding(serviceType reflect.Type) *serviceBinding {
+	set := c.loadProfiles()
+	if set == nil {
+		return nil
+	}
+	binding := set.bindings[serviceType]
+	if binding == nil || binding.Feature != nil && !binding.Feature.isEnabled() {
+		return nil
+	}
+	return binding
+}
+
+// addProfiled to add binding registered with profiles, which is rejected if it makes the service ambiguous in active profiles.
+func (c *defaultContainer) addProfiled(reg *registration, binding *serviceBinding) error {
+	if reg.HasKey || reg.Group != "" {
+		return errors.New("profiles can't be specified for keyed service or group")
+	}
+	if err := validateBinding(binding); err != nil {
+		return err
+	}
+	binding.Profiles = reg.Profiles
+	binding.Primary = reg.Primary
+	binding.RegisteredAt = registrationSite()
+	binding.Seq = atomic.AddUint64(&c.seq, 1)
+
+	c.locker.Lock()
+	var active []string
+	if set := c.loadProfiles(); set != nil {
+		active = set.active
+	}
+	if c.profiled == nil {
+		c.profiled = make(map[reflect.Type][]*serviceBinding)
+	}
+	bindings := c.profiled[binding.ServiceType]
+	c.profiled[binding.ServiceType] = append(bindings[:len(bindings):len(bindings)], binding)
+	set, err := c.chooseProfiled(active)
+	if err != nil {
+		c.profiled[binding.ServiceType] = bindings
+	} else {
+		c.profiles.Store(set)
+	}
+	c.locker.Unlock()
+	if err != nil {
+		return err
+	}
+	c.bindingsChanged()
+	return nil
+}
+
+// chooseProfiled to choose binding of each service registered with profiles in active profiles.
+// The lock should be held.
+func (c *defaultContainer) chooseProfiled(active []string) (*profileSet, error) {
+	set := &profileSet{active: active, bindings: make(map[reflect.Type]*serviceBinding)}
+	serviceTypes := make([]reflect.Type, 0, len(c.profiled))
+	for serviceType := range c.profiled {
+		serviceTypes = append(serviceTypes, serviceType)
+	}
+	sort.Slice(serviceTypes, func(i, j int) bool {
+		return serviceTypes[i].String() < serviceTypes[j].String()
+	})
+	var errs []error
+	for _, serviceType := range serviceTypes {
+		var matched, primaries []*serviceBinding
+		for _, binding := range c.profiled[serviceType] {
+			if set.isActive(binding) {
+				matched = append(matched, binding)
+				if binding.Primary {
+					primaries = append(primaries, binding)
+				}
+			}
+		}
+		switch {
+		case len(matched) == 1:
+			set.bindings[serviceType] = matched[0]
+		case len(primaries) == 1:
+			set.bindings[serviceType] = primaries[0]
+		case len(matched) > 1:
+			errs = append(errs, fmt.Errorf("service '%v' has %d registrations in active profiles %v, and %d of them is primary",
+				serviceType, len(matched), active, len(primaries)))
+		}
+	}
+	if err := aggregateErrors(errs); err != nil {
+		return nil, err
+	}
+	return set, nil
+}
@@ -0,0 +1,173 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestProfiles(t *testing.T) {
+	t.Run("resolved implementation should change with active profiles", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "default"})
+		Register(&serviceInstance1{name: "prod"}, As(TypeOf[service1]()), ForProfiles("prod"))
+		Register(&serviceInstance1{name: "dev"}, As(TypeOf[service1]()), ForProfiles("dev", "test"))
+		if name := GetService[service1]().GetName(); name != "default" {
+			t.Errorf("service without profiles should be resolved if no profile is active, but '%s'", name)
+			return
+		}
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles("prod", "eu"); err != nil {
+			t.Error(err)
+			return
+		}
+		if name := GetService[service1]().GetName(); name != "prod" {
+			t.Errorf("service of active profile should be resolved, but '%s'", name)
+			return
+		}
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles("test"); err != nil {
+			t.Error(err)
+			return
+		}
+		if name := GetService[service1]().GetName(); name != "dev" {
+			t.Errorf("service should be resolved if any of it's profiles is active, but '%s'", name)
+			return
+		}
+		if !reflect.DeepEqual(globalContainer.(ProfileActivator).ActiveProfiles(), []string{"test"}) {
+			t.Errorf("active profiles should be replaced, but %v", globalContainer.(ProfileActivator).ActiveProfiles())
+			return
+		}
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles(); err != nil || GetService[service1]().GetName() != "default" {
+			t.Error("service without profiles should be resolved after deactivated")
+			return
+		}
+	})
+
+	t.Run("service of inactive profiles should not be resolved", func(t *testing.T) {
+		globalContainer = New()
+		Register(&serviceInstance1{name: "prod"}, As(TypeOf[service1]()), ForProfiles("prod"))
+		if GetService[service1]() != nil {
+			t.Error("service of inactive profile should not be resolved")
+			return
+		}
+	})
+
+	t.Run("multiple active ones should resolve primary or fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles("prod", "eu"); err != nil {
+			t.Error(err)
+			return
+		}
+		Register(&serviceInstance1{name: "prod"}, As(TypeOf[service1]()), ForProfiles("prod"))
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{name: "eu"}, As(TypeOf[service1]()), ForProfiles("eu")); err == nil {
+			t.Error("registration making service ambiguous in active profiles should fail")
+			return
+		}
+		if name := GetService[service1]().GetName(); name != "prod" {
+			t.Errorf("registration failed should not take effect, but '%s'", name)
+			return
+		}
+		Register(&serviceInstance1{name: "eu"}, As(TypeOf[service1]()), ForProfiles("eu"), AsPrimary())
+		if name := GetService[service1]().GetName(); name != "eu" {
+			t.Errorf("primary should be resolved if multiple ones are active, but '%s'", name)
+			return
+		}
+
+		globalContainer = New()
+		Register(&serviceInstance1{name: "prod"}, As(TypeOf[service1]()), ForProfiles("prod"))
+		Register(&serviceInstance1{name: "eu"}, As(TypeOf[service1]()), ForProfiles("eu"))
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles("prod", "eu"); err == nil {
+			t.Error("activating profiles making service ambiguous should fail")
+			return
+		}
+		if globalContainer.(ProfileActivator).ActiveProfiles() != nil {
+			t.Error("active profiles should not change if activating failed")
+			return
+		}
+	})
+
+	t.Run("service of active profile should be enumerated", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "default"})
+		Register(&serviceInstance1{name: "prod"}, As(TypeOf[service1]()), ForProfiles("prod"))
+		Register(&serviceInstance2{name: "dev"}, As(TypeOf[service2]()), ForProfiles("dev"))
+		if err := globalContainer.(ProfileActivator).SetActiveProfiles("prod"); err != nil {
+			t.Error(err)
+			return
+		}
+
+		if all := ResolveAll[service1](); len(all) != 1 || all[0].GetName() != "prod" {
+			t.Errorf("'ResolveAll' should include the one of active profile instead of default, but %v", all)
+			return
+		}
+		var client sliceClient
+		Inject(&client)
+		if len(client.All) != 1 || client.All[0].GetName() != "prod" || len(client.Renamers) != 0 {
+			t.Errorf("slice should be injected with the one of active profile, but %v and %v", client.All, client.Renamers)
+			return
+		}
+		if flattened := globalContainer.(Flattener).Flatten(); flattened[TypeOf[service1]()] != LifetimeSingleton {
+			t.Error("'Flatten' should include service of active profile")
+			return
+		} else if _, ok := flattened[TypeOf[service2]()]; ok {
+			t.Error("'Flatten' should not include service of inactive profile")
+			return
+		}
+		var ranged []reflect.Type
+		globalContainer.(Ranger).Range(func(serviceType reflect.Type, descriptor ServiceDescriptor) bool {
+			if descriptor.ImplementationType == TypeOf[*serviceInstance1]() {
+				ranged = append(ranged, serviceType)
+			}
+			return true
+		})
+		if len(ranged) != 1 || ranged[0] != TypeOf[service1]() {
+			t.Errorf("'Range' should visit the one of active profile once, but %v", ranged)
+			return
+		}
+		if specs := globalContainer.(RegistrationPorter).ExportRegistrations(); len(specs) != 1 || specs[0].ServiceType != TypeOf[service1]() {
+			t.Errorf("'ExportRegistrations' should include the one of active profile, but %v", specs)
+			return
+		}
+		if data, err := globalContainer.(JSONExporter).ExportJSON(); err != nil || !strings.Contains(string(data), `"type":"ioc.service1"`) ||
+			strings.Contains(string(data), `"type":"ioc.service2"`) {
+			t.Errorf("'ExportJSON' should include the one of active profile only, but %s", data)
+			return
+		}
+		if val := globalContainer.(Sealer).Seal().Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface().(service1).GetName() != "prod" {
+			t.Error("sealed resolver should resolve the one of active profile")
+			return
+		}
+	})
+
+	t.Run("invalid profiles should be rejected", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, ForProfiles()); err == nil {
+			t.Error("empty profiles should be rejected")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, Keyed("k"), ForProfiles("prod")); err == nil {
+			t.Error("keyed service with profiles should be rejected")
+			return
+		}
+	})
+}
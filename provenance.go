@@ -0,0 +1,118 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// Provenance is where and how the instance is produced by 'ProvenanceResolver.ResolveProvenance'.
+type Provenance struct {
+	// Found is whether the service is found.
+	Found bool
+	// Container is the one that actually resolved the service, it's the parent resolver if it's not 'ioc.Container'.
+	Container Resolver
+	// Depth is the distance from the resolving container to 'Container', 0 for itself, 1 for it's parent, and so on.
+	Depth int
+	// Lifetime is the lifetime reported by 'Container'.
+	Lifetime Lifetime
+	// Cached is whether the instance was constructed before, such as initialized singleton,
+	// and instance cached in scope or by 'Memoizer.Memoize'.
+	Cached bool
+	// Initializer is name of the initialize method invoked by this resolving, empty if not invoked.
+	Initializer string
+}
+
+// ProvenanceResolver is implemented by container to resolve service with where and how it's produced.
+type ProvenanceResolver interface {
+	// ResolveProvenance to get service with where and how it's produced, for debugging resolving in multiple containers.
+	// It doesn't change resolving, and resolves the same as 'ResolveWithInfo'.
+	//
+	//  val, provenance := container.(ioc.ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*Service1)(nil)).Elem())
+	//  fmt.Printf("resolved from depth %d, cached: %v\n", provenance.Depth, provenance.Cached)
+	ResolveProvenance(serviceType reflect.Type) (reflect.Value, Provenance)
+}
+
+var _ ProvenanceResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveProvenance(serviceType reflect.Type) (reflect.Value, Provenance) {
+	if serviceType == nil {
+		return reflect.Value{}, Provenance{}
+	}
+	// provenance is recorded by resolving, so it's the same as 'Resolve'
+	path := newResolvePath()
+	recorder := &provenanceRecorder{}
+	path.call.provenance = recorder
+	val := c.resolve(serviceType, c, path)
+	return val, recorder.result()
+}
+
+// provenanceRecorder records provenance of the service requested while resolving, see 'ResolveProvenance'.
+type provenanceRecorder struct {
+	provenance  Provenance
+	constructed bool // instance is constructed or initialized by the resolving
+}
+
+// provenanceOf to get recorder of provenance in the call of 'path', which is nil if it's not recording
+// or services are being created for the requested one, such as dependencies of singleton.
+func (p *resolvePath) provenanceOf() *provenanceRecorder {
+	if p == nil || p.binding != nil {
+		return nil
+	}
+	return p.call.provenance
+}
+
+// found to record the resolver which resolves the service for 'origin', the first one is kept,
+// such as the alias rather than it's target.
+func (r *provenanceRecorder) found(origin *defaultContainer, from Resolver, lifetime Lifetime) {
+	if r.provenance.Found {
+		return
+	}
+	depth := 0
+	for current := Resolver(origin); current != nil && current != from; depth++ {
+		c, ok := current.(*defaultContainer)
+		if !ok {
+			break
+		}
+		current = c.parent
+	}
+	r.provenance = Provenance{Found: true, Container: from, Depth: depth, Lifetime: lifetime}
+}
+
+// construct to record instance is constructed or initialized, with the initialize method if it's invoked.
+func (r *provenanceRecorder) construct(initializer string) {
+	r.constructed = true
+	if r.provenance.Initializer == "" {
+		r.provenance.Initializer = initializer
+	}
+}
+
+// result to get provenance recorded, and instance is cached if it's not constructed by the resolving
+// and it's lifetime caches instance.
+func (r *provenanceRecorder) result() Provenance {
+	provenance := r.provenance
+	if provenance.Found {
+		lifetime := provenance.Lifetime
+		provenance.Cached = !r.constructed && lifetime != LifetimeTransient && lifetime != LifetimeUnknown
+	}
+	return provenance
+}
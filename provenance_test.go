@@ -0,0 +1,112 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveProvenance(t *testing.T) {
+	t.Run("resolve provenance should report depth and cache status in parent chain", func(t *testing.T) {
+		globalContainer = New()
+		grandparent := New()
+		parent := New()
+		child := New()
+		_ = grandparent.AddSingleton(reflect.TypeOf((*serviceInstance7)(nil)), &serviceInstance7{name: "instance7"})
+		_ = grandparent.AddSingleton(reflect.TypeOf((*serviceInstance8)(nil)), &serviceInstance8{})
+		_ = parent.AddTransient(reflect.TypeOf((*service1)(nil)).Elem(), func() any { return &serviceInstance1{} })
+		parent.SetParent(grandparent)
+		child.SetParent(parent)
+
+		val, provenance := child.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*serviceInstance8)(nil)))
+		if !val.IsValid() || val.Interface().(*serviceInstance8).GetS7Name() != "instance7" {
+			t.Error("resolve provenance should resolve the same as resolve")
+			return
+		}
+		if !provenance.Found || provenance.Container != grandparent || provenance.Depth != 2 {
+			t.Errorf("should be resolved from grandparent at depth 2, but depth %d", provenance.Depth)
+			return
+		}
+		if provenance.Lifetime != LifetimeSingleton || provenance.Cached || provenance.Initializer != DefaultInitializeMethodName {
+			t.Error("singleton should be freshly initialized by the initialize method")
+			return
+		}
+		if _, provenance = child.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*serviceInstance8)(nil))); !provenance.Cached || provenance.Initializer != "" {
+			t.Error("singleton should be cached after initialized")
+			return
+		}
+
+		_, provenance = child.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*service1)(nil)).Elem())
+		if provenance.Container != parent || provenance.Depth != 1 || provenance.Lifetime != LifetimeTransient || provenance.Cached {
+			t.Error("transient should be freshly constructed in parent at depth 1")
+			return
+		}
+		if _, provenance = grandparent.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*serviceInstance7)(nil))); provenance.Depth != 0 || provenance.Container != grandparent {
+			t.Error("service in itself should be at depth 0")
+			return
+		}
+		if val, provenance = child.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*service2)(nil)).Elem()); val.IsValid() || provenance.Found {
+			t.Error("service not registered should not be found")
+			return
+		}
+	})
+
+	t.Run("resolve provenance should report the resolver matched and deferred registration", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		parent.(ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+			if serviceType == TypeOf[service1]() {
+				return reflect.ValueOf(&serviceInstance1{name: "matched"}), true
+			}
+			return reflect.Value{}, false
+		})
+		AddDeferredToC[service2](parent, func(c Container) service2 { return &serviceInstance2{name: "deferred"} })
+		globalContainer.SetParent(parent)
+		if val, provenance := globalContainer.(ProvenanceResolver).ResolveProvenance(TypeOf[service1]()); !val.IsValid() || provenance.Container != parent || provenance.Depth != 1 || provenance.Cached {
+			t.Errorf("service should be matched by resolver of parent, but %+v", provenance)
+			return
+		}
+		if _, provenance := globalContainer.(ProvenanceResolver).ResolveProvenance(TypeOf[service2]()); provenance.Container != parent || provenance.Cached {
+			t.Errorf("deferred service should be constructed for first resolving, but %+v", provenance)
+			return
+		}
+		if _, provenance := globalContainer.(ProvenanceResolver).ResolveProvenance(TypeOf[service2]()); !provenance.Cached {
+			t.Errorf("deferred service should be cached, but %+v", provenance)
+			return
+		}
+	})
+
+	t.Run("resolve provenance should report cache status of scoped service", func(t *testing.T) {
+		globalContainer = New()
+		_ = globalContainer.(ScopeContainer).AddScoped(reflect.TypeOf((*service1)(nil)).Elem(), func() any { return &serviceInstance1{} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		if _, provenance := scope.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*service1)(nil)).Elem()); provenance.Cached || provenance.Lifetime != LifetimeScoped {
+			t.Error("scoped service should be freshly constructed for first resolving in scope")
+			return
+		}
+		if _, provenance := scope.(ProvenanceResolver).ResolveProvenance(reflect.TypeOf((*service1)(nil)).Elem()); !provenance.Cached {
+			t.Error("scoped service should be cached in scope")
+			return
+		}
+	})
+}
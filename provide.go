@@ -0,0 +1,114 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Provide to add service 'TService' to global container by provider, whose form decides how it's registered,
+// see 'ProvideToC'.
+//
+// It will panic if 'TService' or 'provider' is invalid.
+//
+//	ioc.Provide[*Config](&Config{Addr: ":8080"})
+//	ioc.Provide[Clock](func() Clock { return systemClock{} })
+//	ioc.Provide[Handler](func(r ioc.Resolver) Handler { return newHandler(r) })
+//	ioc.Provide[*Server](func(cfg *Config, h Handler) (*Server, error) { return newServer(cfg, h) })
+func Provide[TService any](provider any) {
+	ProvideToC[TService](globalContainer, provider)
+}
+
+// ProvideToC to add service 'TService' to container by provider, whose form decides how it's registered:
+//
+//   - instance of 'TService', which is singleton.
+//   - 'func() TService', which is transient factory.
+//   - 'func(ioc.Resolver) TService', which is transient factory with resolver where resolving started.
+//   - 'func(deps...) TService', which is transient constructor whose params are resolved where resolving started.
+//
+// Result of func can be any type assignable to 'TService', and the func can return '(TService, error)' whose error fails
+// the resolving like 'AddFactoryFor'.
+//
+// It will panic if 'TService' or 'provider' is invalid, or it's ambiguous, such as func implementing 'TService'
+// which is also a factory.
+func ProvideToC[TService any](container Container, provider any) {
+	if err := provide(container, TypeOf[TService](), provider); err != nil {
+		panic(err)
+	}
+}
+
+// provide to add service to container by the form of provider.
+func provide(container Container, serviceType reflect.Type, provider any) error {
+	if container == nil {
+		return errors.New("param 'container' is null")
+	}
+	if provider == nil {
+		return errors.New("param 'provider' is null")
+	}
+	providerVal := reflect.ValueOf(provider)
+	isInstance := providerVal.Type().AssignableTo(serviceType)
+	factory, err := providerFactory(serviceType, providerVal)
+	switch {
+	case isInstance && factory != nil:
+		return fmt.Errorf("provider '%T' of service '%v' is ambiguous, it's both instance and factory", provider, serviceType)
+	case isInstance:
+		return container.AddSingleton(serviceType, provider)
+	case err != nil:
+		return err
+	}
+	return container.(FactoryForAdder).AddFactoryFor(serviceType, factory)
+}
+
+// providerFactory to convert provider func into factory with resolver, returns null if provider is not func.
+func providerFactory(serviceType reflect.Type, providerVal reflect.Value) (func(r Resolver) (any, error), error) {
+	providerType := providerVal.Type()
+	if providerType.Kind() != reflect.Func {
+		return nil, fmt.Errorf("provider '%v' should be instance or func of service '%v'", providerType, serviceType)
+	}
+	if providerVal.IsNil() {
+		return nil, errors.New("param 'provider' is null")
+	}
+	if providerType.IsVariadic() {
+		return nil, fmt.Errorf("provider '%v' of service '%v' can't be variadic", providerType, serviceType)
+	}
+	if providerType.NumOut() == 0 || providerType.NumOut() > 2 || providerType.NumOut() == 2 && providerType.Out(1) != errorType {
+		return nil, fmt.Errorf("provider '%v' should return '%v' or '(%v, error)'", providerType, serviceType, serviceType)
+	}
+	if !providerType.Out(0).AssignableTo(serviceType) {
+		return nil, fmt.Errorf("result '%v' of provider is not assignable to '%v'", providerType.Out(0), serviceType)
+	}
+	withResolver := providerType.NumIn() == 1 && providerType.In(0) == resolverType
+	return func(r Resolver) (any, error) {
+		var outs []reflect.Value
+		if withResolver {
+			outs = providerVal.Call([]reflect.Value{reflect.ValueOf(&r).Elem()})
+		} else {
+			outs = callWithServices(r, providerVal, nil)
+		}
+		if len(outs) == 2 && !outs[1].IsNil() {
+			return nil, outs[1].Interface().(error)
+		}
+		return outs[0].Interface(), nil
+	}, nil
+}
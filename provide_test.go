@@ -0,0 +1,126 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+type providedServer struct {
+	dep  service1
+	name string
+}
+
+// namedService is func type implementing 'service1', which is also a factory of it.
+type namedService func() service1
+
+func (f namedService) GetName() string {
+	return "named"
+}
+
+func TestProvide(t *testing.T) {
+	t.Run("instance should be singleton", func(t *testing.T) {
+		globalContainer = New()
+		instance := &serviceInstance1{name: "instance"}
+		Provide[service1](instance)
+		if GetService[service1]() != service1(instance) {
+			t.Error("instance should be resolved as singleton")
+			return
+		}
+	})
+
+	t.Run("func without params should be transient", func(t *testing.T) {
+		globalContainer = New()
+		Provide[service1](func() service1 { return &serviceInstance1{name: "transient"} })
+		first, second := GetService[service1](), GetService[service1]()
+		if first == nil || first.GetName() != "transient" || first == second {
+			t.Error("factory should be resolved as transient")
+			return
+		}
+	})
+
+	t.Run("func with resolver should get resolver", func(t *testing.T) {
+		globalContainer = New()
+		scope := globalContainer.(ScopeContainer).NewScope()
+		defer scope.(ScopeContainer).Dispose()
+		Provide[service1](func(r Resolver) *serviceInstance1 {
+			if r == scope {
+				return &serviceInstance1{name: "scope"}
+			}
+			return &serviceInstance1{name: "root"}
+		})
+		if svc := GetServiceFromC[service1](scope); svc == nil || svc.GetName() != "scope" {
+			t.Error("factory should get resolver where resolving started")
+			return
+		}
+	})
+
+	t.Run("func with deps should be constructor", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "dep"})
+		Provide[*providedServer](func(dep service1) (*providedServer, error) {
+			return &providedServer{dep: dep, name: "server"}, nil
+		})
+		if server := GetService[*providedServer](); server == nil || server.dep == nil || server.dep.GetName() != "dep" {
+			t.Error("params of constructor should be resolved")
+			return
+		}
+	})
+
+	t.Run("error of constructor should fail resolving", func(t *testing.T) {
+		globalContainer = New()
+		Provide[*providedServer](func(dep service1) (*providedServer, error) {
+			return nil, errFactory
+		})
+		if _, err := GetServiceE[*providedServer](); !errors.Is(err, errFactory) {
+			t.Errorf("error of constructor should be returned, but %v", err)
+			return
+		}
+	})
+
+	t.Run("invalid provider should be rejected", func(t *testing.T) {
+		providers := map[string]any{
+			"null":         nil,
+			"type":         "instance",
+			"result":       func() string { return "" },
+			"variadic":     func(deps ...service2) service1 { return nil },
+			"no result":    func() {},
+			"second":       func() (service1, string) { return nil, "" },
+			"ambiguous":    namedService(func() service1 { return nil }),
+			"null factory": (func() service1)(nil),
+		}
+		for name, provider := range providers {
+			globalContainer = New()
+			func() {
+				defer func() {
+					r := recover()
+					if err, ok := r.(error); !ok || name == "ambiguous" && !strings.Contains(err.Error(), "ambiguous") {
+						t.Errorf("provider '%s' should be rejected, but %v", name, r)
+					}
+				}()
+				Provide[service1](provider)
+			}()
+		}
+	})
+}
@@ -0,0 +1,90 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+)
+
+// ResolveFunc is func to resolve service 'T', which can be stored by components instead of calling 'GetServiceFromC' repeatedly.
+type ResolveFunc[T any] func() T
+
+// ProviderFor to get func resolving service 'T' from container, with service type computed once.
+// It resolves for each call, so it respects the service replaced later.
+//
+// It will panic if 'c' is nil.
+//
+//	type Handler struct {
+//	    getUser ioc.ResolveFunc[*User]
+//	}
+//	handler := &Handler{getUser: ioc.ProviderFor[*User](container)}
+//	user := handler.getUser()
+func ProviderFor[T any](c Container) ResolveFunc[T] {
+	if c == nil {
+		panic("param 'c' is null")
+	}
+	serviceType := TypeOf[T]()
+	return func() T {
+		var instance T
+		instanceVal := c.Resolve(serviceType)
+		if !instanceVal.IsValid() {
+			return instance
+		}
+		if val, ok := instanceVal.Interface().(T); ok {
+			instance = val
+		}
+		return instance
+	}
+}
+
+// providerTypeOf to get type of service if it's 'func() T', otherwise nil.
+func providerTypeOf(fieldType reflect.Type) reflect.Type {
+	if fieldType.Kind() != reflect.Func || fieldType.NumIn() != 0 || fieldType.NumOut() != 1 || fieldType.IsVariadic() {
+		return nil
+	}
+	return fieldType.Out(0)
+}
+
+// resolveProvider to resolve field of 'func() T' as func resolving 'T' from container for each call.
+func resolveProvider(container Container, field structField) reflect.Value {
+	serviceType := field.ProviderOf
+	return reflect.MakeFunc(field.FieldType, func([]reflect.Value) []reflect.Value {
+		var instance reflect.Value
+		if field.HasKey {
+			instance = container.(KeyedContainer).ResolveKeyed(field.Key, serviceType)
+		} else {
+			instance = container.Resolve(serviceType)
+		}
+		if !instance.IsValid() || !instance.Type().AssignableTo(serviceType) {
+			instance = reflect.Zero(serviceType)
+		}
+		return []reflect.Value{instance}
+	})
+}
+
+// canResolveProvider to check whether service of field of 'func() T' can be resolved.
+func (c *defaultContainer) canResolveProvider(field structField) bool {
+	if field.HasKey {
+		return c.canResolveKeyed(field.Key, field.ProviderOf)
+	}
+	return c.canResolve(field.ProviderOf)
+}
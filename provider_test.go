@@ -0,0 +1,122 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestProviderFor(t *testing.T) {
+	t.Run("provider should resolve for each call", func(t *testing.T) {
+		globalContainer = New()
+		provide := ProviderFor[service1](globalContainer)
+		if provide() != nil {
+			t.Error("service not registered should be nil")
+			return
+		}
+		s1 := &serviceInstance1{name: "instance1"}
+		AddSingleton[service1](s1)
+		if provide() != s1 {
+			t.Error("service added later should be resolved")
+			return
+		}
+		replaced := &serviceInstance1{name: "replaced"}
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateReplace)
+		AddSingleton[service1](replaced)
+		if provide() != replaced {
+			t.Error("service replaced should be resolved")
+			return
+		}
+	})
+
+	t.Run("provider of transient should create new instance", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance7](func() *serviceInstance7 { return &serviceInstance7{} })
+		provide := ProviderFor[*serviceInstance7](globalContainer)
+		if provide() == provide() {
+			t.Error("transient should be created for each call")
+			return
+		}
+	})
+
+	t.Run("nil container should panic", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("nil container should panic")
+			}
+		}()
+		ProviderFor[service1](nil)
+	})
+}
+
+type factoryFieldClient struct {
+	NewService1 func() *serviceInstance1 `ioc-inject:"true"`
+	NewKeyed    func() service1          `ioc-inject:"key=primary"`
+}
+
+func TestInjectFactoryField(t *testing.T) {
+	t.Run("each call of factory field should get new transient instance", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { return &serviceInstance1{name: "transient"} })
+		AddSingleton[*factoryFieldClient](&factoryFieldClient{})
+		client := GetService[*factoryFieldClient]()
+		if client.NewService1 == nil {
+			t.Error("factory field should be injected")
+			return
+		}
+		first, second := client.NewService1(), client.NewService1()
+		if first == nil || second == nil || first == second || first.GetName() != "transient" {
+			t.Error("each call of factory field should get new transient instance")
+			return
+		}
+	})
+
+	t.Run("factory field should resolve keyed service", func(t *testing.T) {
+		globalContainer = New()
+		svc := &serviceInstance1{name: "primary"}
+		AddKeyed[service1]("primary", svc)
+		client := &factoryFieldClient{}
+		Inject(client)
+		if client.NewKeyed() != svc {
+			t.Error("factory field should resolve keyed service")
+			return
+		}
+		if client.NewService1() != nil {
+			t.Error("factory field should return nil if service not registered")
+			return
+		}
+	})
+
+	t.Run("factory field of singleton resolved from child should resolve from parent", func(t *testing.T) {
+		globalContainer = New()
+		child := New()
+		child.SetParent(globalContainer)
+		AddTransient[*serviceInstance1](func() *serviceInstance1 { return &serviceInstance1{name: "parent"} })
+		AddTransientToC[*serviceInstance1](child, func() *serviceInstance1 { return &serviceInstance1{name: "child"} })
+		AddSingleton[*factoryFieldClient](&factoryFieldClient{})
+		client := GetServiceFromC[*factoryFieldClient](child)
+		if client.NewService1().GetName() != "parent" {
+			t.Error("factory field of singleton should resolve from parent")
+			return
+		}
+	})
+}
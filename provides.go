@@ -0,0 +1,62 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// AssertProvides to check whether 'TService' can be resolved from global container, without instantiating.
+func AssertProvides[TService any]() error {
+	return AssertProvidesFromC[TService](globalContainer)
+}
+
+// AssertProvidesFromC to check whether 'TService' can be resolved from container, without instantiating.
+func AssertProvidesFromC[TService any](container Container) error {
+	return container.(ProvidesAsserter).AssertProvides(TypeOf[TService]())
+}
+
+// ProvidesAsserter is implemented by container to check services can be resolved without instantiating.
+type ProvidesAsserter interface {
+	// AssertProvides to check whether all of 'types' can be resolved from current container or it's parent chain, without instantiating.
+	// It returns '*ioc.AggregateError' listing every type can't be resolved, for fail-fast at startup or in tests.
+	//
+	//  err := container.(ioc.ProvidesAsserter).AssertProvides(reflect.TypeOf((*DB)(nil)).Elem(), reflect.TypeOf((*Cache)(nil)).Elem())
+	AssertProvides(types ...reflect.Type) error
+}
+
+var _ ProvidesAsserter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AssertProvides(types ...reflect.Type) error {
+	var errs []error
+	for i, serviceType := range types {
+		if serviceType == nil {
+			errs = append(errs, fmt.Errorf("param 'types[%d]' is null", i))
+			continue
+		}
+		if !c.canResolve(serviceType) {
+			errs = append(errs, fmt.Errorf("service '%v' can't be resolved", serviceType))
+		}
+	}
+	return aggregateErrors(errs)
+}
@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAssertProvides(t *testing.T) {
+	t.Run("error should name exactly the missing types", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service2](parent, &serviceInstance2{name: "instance2"})
+		globalContainer.SetParent(parent)
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		err := globalContainer.(ProvidesAsserter).AssertProvides(TypeOf[service1](), TypeOf[*serviceInstance3](), TypeOf[service2](), TypeOf[service4]())
+		var aggregateErr *AggregateError
+		if !errors.As(err, &aggregateErr) || len(aggregateErr.Errors) != 2 {
+			t.Errorf("error should name exactly the missing types, but got '%v'", err)
+			return
+		}
+		if aggregateErr.Error() != "service '*ioc.serviceInstance3' can't be resolved; service 'ioc.service4' can't be resolved" {
+			t.Errorf("unexpected error '%v'", err)
+			return
+		}
+	})
+
+	t.Run("assertion should not instantiate", func(t *testing.T) {
+		globalContainer = New()
+		calls := 0
+		AddTransient[service1](func() service1 {
+			calls++
+			return &serviceInstance1{name: "instance1"}
+		})
+		if err := AssertProvides[service1](); err != nil {
+			t.Error(err)
+			return
+		}
+		if calls != 0 {
+			t.Error("assertion should not instantiate")
+			return
+		}
+		if AssertProvides[service2]() == nil {
+			t.Error("missing service should fail")
+			return
+		}
+	})
+}
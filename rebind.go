@@ -0,0 +1,161 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Rebind to change lifetime of service 'TService' in global container.
+//
+// It will panic if 'TService' can't be changed to the lifetime.
+func Rebind[TService any](lifetime Lifetime) {
+	RebindToC[TService](globalContainer, lifetime)
+}
+
+// RebindToC to change lifetime of service 'TService' in container.
+//
+// It will panic if 'TService' can't be changed to the lifetime.
+func RebindToC[TService any](container Container, lifetime Lifetime) {
+	if err := container.(Rebinder).Rebind(TypeOf[TService](), lifetime); err != nil {
+		panic(err)
+	}
+}
+
+// lazyKey is the only key of lazy singleton in it's memo cache.
+type lazyKey struct{}
+
+// Rebinder is implemented by container to change lifetime of registered service.
+type Rebinder interface {
+	// Rebind to change lifetime of service registered in current container, and reuse it's factory.
+	// Transient and scoped can be changed to each other or singleton, which is created by the factory on first resolving.
+	// It returns error if service is not found in current container or it can't be changed,
+	// such as singleton added with instance has no factory to create instances for transient.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.Rebinder).Rebind(reflect.TypeOf((*Service1)(nil)).Elem(), ioc.LifetimeSingleton)
+	Rebind(serviceType reflect.Type, lifetime Lifetime) error
+}
+
+var _ Rebinder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Rebind(serviceType reflect.Type, lifetime Lifetime) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if lifetime != LifetimeSingleton && lifetime != LifetimeTransient && lifetime != LifetimeScoped {
+		return fmt.Errorf("can't rebind to lifetime '%v'", lifetime)
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	binding := c.getBinding(serviceType)
+	if binding == nil {
+		return fmt.Errorf("service '%v' not found in current container", serviceType)
+	}
+	if binding.lifetime() == lifetime {
+		return nil
+	}
+	if binding.InstanceFactory == nil || binding.Selector != nil {
+		return fmt.Errorf("service '%v' is '%v' without factory, can't rebind to '%v'", serviceType, binding.lifetime(), lifetime)
+	}
+
+	rebound := binding.clone()
+	switch lifetime {
+	case LifetimeSingleton:
+		rebound.Lazy = true
+		rebound.Memo.Store(newLazyMemo())
+	case LifetimeScoped:
+		rebound.Scoped = true
+	}
+	c.bindings.Store(serviceType, rebound)
+	c.keyedBindings.Range(func(key, val any) bool {
+		// keyed primary is also stored without key
+		if val.(*serviceBinding) == binding {
+			c.keyedBindings.Store(key, rebound)
+		}
+		return true
+	})
+	c.bindingsChanged()
+	return nil
+}
+
+// clone to copy binding without lifetime, that is scoped, lazy or cached instances by 'Memo', 'TTL' and 'Weak',
+// see 'cloneLifetime'. State shared by pointer, such as 'Async', 'Swappable' and 'ContextScoped', is shared by the clone.
+func (b *serviceBinding) clone() *serviceBinding {
+	return &serviceBinding{
+		ServiceType:             b.ServiceType,
+		Instance:                b.Instance,
+		InstanceInitializer:     b.InstanceInitializer,
+		InstanceInitializerName: b.InstanceInitializerName,
+		InstanceFactory:         b.InstanceFactory,
+		InitCallback:            b.InitCallback,
+		Selector:                b.Selector,
+		Order:                   b.Order,
+		DisposeOrder:            b.DisposeOrder,
+		NotInherited:            b.NotInherited,
+		Feature:                 b.Feature,
+		Profiles:                b.Profiles,
+		Primary:                 b.Primary,
+		Target:                  b.Target,
+		ResolverFactory:         b.ResolverFactory,
+		AliasOf:                 b.AliasOf,
+		Deferred:                b.Deferred,
+		Async:                   b.Async,
+		Swappable:               b.Swappable,
+		ContextScoped:           b.ContextScoped,
+		RegisteredAt:            b.RegisteredAt,
+		Seq:                     b.Seq,
+	}
+}
+
+// cloneLifetime to copy lifetime of 'src' to binding, with empty caches.
+func (b *serviceBinding) cloneLifetime(src *serviceBinding) {
+	b.Scoped = src.Scoped
+	b.GoroutineScoped = src.GoroutineScoped
+	b.Lazy = src.Lazy
+	if memo, ok := src.Memo.Load().(*memoCache); ok {
+		if src.Lazy {
+			b.Memo.Store(newLazyMemo())
+		} else {
+			b.Memo.Store(&memoCache{keyFn: memo.keyFn, capacity: memo.capacity, entries: make(map[any]*list.Element), lru: list.New()})
+		}
+	}
+	if src.TTL != nil {
+		b.TTL = &ttlCache{ttl: src.TTL.ttl}
+	}
+	if src.Weak != nil {
+		b.Weak = &weakCache{}
+	}
+}
+
+// newLazyMemo to create memo cache of lazy singleton, which caches the only instance created by factory.
+func newLazyMemo() *memoCache {
+	return &memoCache{
+		keyFn:    func() any { return lazyKey{} },
+		capacity: 1,
+		entries:  make(map[any]*list.Element),
+		lru:      list.New(),
+	}
+}
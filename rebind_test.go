@@ -0,0 +1,124 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRebind(t *testing.T) {
+	t.Run("transient rebound to singleton should reuse factory once", func(t *testing.T) {
+		globalContainer = New()
+		created := 0
+		AddTransient[service1](func() service1 {
+			created++
+			return &serviceInstance1{name: "instance1"}
+		})
+		Rebind[service1](LifetimeSingleton)
+		if GetService[service1]() != GetService[service1]() || created != 1 {
+			t.Errorf("singleton should be created once, but created %d times", created)
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service1)(nil)).Elem()); lifetime != LifetimeSingleton {
+			t.Errorf("lifetime should be singleton, but %v", lifetime)
+			return
+		}
+
+		Rebind[service1](LifetimeTransient)
+		if GetService[service1]() == GetService[service1]() {
+			t.Error("singleton rebound to transient should create new instances")
+			return
+		}
+	})
+
+	t.Run("transient rebound to scoped should be shared in scope", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { return &serviceInstance1{name: "instance1"} })
+		Rebind[service1](LifetimeScoped)
+		scope := globalContainer.(ScopeContainer).NewScope()
+		if GetServiceFromC[service1](scope) != GetServiceFromC[service1](scope) {
+			t.Error("scoped should be shared in scope")
+			return
+		}
+		if GetServiceFromC[service1](scope) == GetServiceFromC[service1](globalContainer.(ScopeContainer).NewScope()) {
+			t.Error("scoped should not be shared across scopes")
+			return
+		}
+		if err := globalContainer.(Rebinder).Rebind(reflect.TypeOf((*service1)(nil)).Elem(), LifetimeScoped); err != nil {
+			t.Error("rebind to the same lifetime should do nothing")
+			return
+		}
+	})
+
+	t.Run("rebind should keep factory with resolver and keyed primary", func(t *testing.T) {
+		globalContainer = New()
+		created := 0
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			created++
+			return &serviceInstance1{name: "instance1"}, nil
+		})
+		Rebind[service1](LifetimeSingleton)
+		if GetService[service1]() != GetService[service1]() || created != 1 {
+			t.Errorf("factory with resolver should be kept and created once, but created %d times", created)
+			return
+		}
+
+		globalContainer = New()
+		Register(nil, As(reflect.TypeOf((*service1)(nil)).Elem()), Transient(func() any { return &serviceInstance1{name: "instance1"} }), Keyed("k"), AsPrimary())
+		Rebind[service1](LifetimeSingleton)
+		if GetKeyed[service1]("k") != GetService[service1]() {
+			t.Error("keyed primary should be rebound together")
+			return
+		}
+	})
+
+	t.Run("invalid conversions should fail", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		if err := globalContainer.(Rebinder).Rebind(serviceType, LifetimeTransient); err == nil {
+			t.Error("singleton added with instance should not be rebound to transient")
+			return
+		}
+		if err := globalContainer.(Rebinder).Rebind(serviceType, LifetimeUnknown); err == nil {
+			t.Error("unknown lifetime should fail")
+			return
+		}
+		if err := globalContainer.(Rebinder).Rebind(reflect.TypeOf((*service2)(nil)).Elem(), LifetimeSingleton); err == nil {
+			t.Error("service not found should fail")
+			return
+		}
+		child := New()
+		child.SetParent(globalContainer)
+		if err := child.(Rebinder).Rebind(serviceType, LifetimeSingleton); err == nil {
+			t.Error("service in parent should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("invalid conversion should panic")
+			}
+		}()
+		Rebind[service1](LifetimeScoped)
+	})
+}
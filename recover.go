@@ -0,0 +1,232 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// Logger is used by container to log, such as recovered panics.
+type Logger interface {
+	Printf(format string, v ...any)
+}
+
+// loggerHolder to store logger in atomic.Value, which requires the same concrete type.
+type loggerHolder struct {
+	Logger Logger
+}
+
+// GetServiceE to get service from global container, and returns error if not found or panic during resolving.
+func GetServiceE[TService any]() (TService, error) {
+	return GetServiceEFromC[TService](globalContainer)
+}
+
+// GetServiceEFromC to get service from container, and returns error if not found or panic during resolving.
+func GetServiceEFromC[TService any](container Container) (TService, error) {
+	var instance TService
+	instanceVal, err := container.(ErrorResolver).ResolveE(TypeOf[TService]())
+	if err != nil {
+		return instance, err
+	}
+	if !instanceVal.IsValid() || instanceVal.Interface() == nil {
+		return instance, nil
+	}
+	val, ok := instanceVal.Interface().(TService)
+	if !ok {
+		return instance, assertionError[TService](instanceVal)
+	}
+	return val, nil
+}
+
+// GetServiceOk to get service from global container, and ok is false if it's not found or the instance resolved is not 'TService'.
+func GetServiceOk[TService any]() (TService, bool) {
+	return GetServiceOkFromC[TService](globalContainer)
+}
+
+// GetServiceOkFromC to get service from container, and ok is false if it's not found or the instance resolved is not 'TService',
+// such as transient factory returns instance of wrong type, which is also logged by logger of container.
+// Service found with nil instance is ok, use 'GetServiceEFromC' to get error of failure.
+func GetServiceOkFromC[TService any](container Container) (TService, bool) {
+	var instance TService
+	instanceVal := container.Resolve(TypeOf[TService]())
+	if !instanceVal.IsValid() {
+		return instance, false
+	}
+	if instanceVal.Interface() == nil {
+		return instance, true
+	}
+	val, ok := instanceVal.Interface().(TService)
+	if !ok {
+		if c, isDefault := container.(*defaultContainer); isDefault {
+			c.logf("%v", assertionError[TService](instanceVal))
+		}
+		return instance, false
+	}
+	return val, true
+}
+
+// assertionError to describe instance resolved which is not 'TService'.
+func assertionError[TService any](instanceVal reflect.Value) error {
+	return fmt.Errorf("instance of service '%v' is '%v' which is not assignable to it", TypeOf[TService](), reflect.TypeOf(instanceVal.Interface()))
+}
+
+// ErrorResolver is implemented by container to resolve service with errors instead of panics.
+type ErrorResolver interface {
+	// ResolveE to resolve service, and returns error if not found, or panic during resolving which is converted into error.
+	// The error is '*ResolutionError' with services resolving when it failed, such as dependency not found even if not in strict mode,
+	// while optional fields and untagged fields injected automatically are left zero.
+	//
+	//  val, err := container.(ioc.ErrorResolver).ResolveE(reflect.TypeOf((*Service1)(nil)).Elem())
+	ResolveE(serviceType reflect.Type) (reflect.Value, error)
+
+	// SetRecoverFactoryPanics to recover panics of factories registered in current container, default is false.
+	// Recovered panic is logged by the logger set by 'SetLogger', and the instance is invalid as not found,
+	// while 'ResolveE' returns the panic as cause of error, such as '*FactoryError'. Failure is not cached,
+	// so factory of singleton or scoped service is invoked again on next resolving.
+	// Keep it false to fail fast, and use 'ResolveE' to get the panic as error.
+	SetRecoverFactoryPanics(enabled bool)
+
+	// SetLogger to set logger of container, such as '*log.Logger', default is nil to log nothing.
+	SetLogger(logger Logger)
+}
+
+var _ ErrorResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ResolveE(serviceType reflect.Type) (reflect.Value, error) {
+	if serviceType == nil {
+		return reflect.Value{}, errors.New("param 'serviceType' is null")
+	}
+	path := newResolvePath()
+	return checkResolved(serviceType, path, func() reflect.Value {
+		return c.resolveLenient(serviceType, path)
+	})
+}
+
+// checkResolved to resolve 'serviceType' by 'resolve' in the call of 'path', and returns error if it or a required
+// dependency is not found, or it panics. Failure is recorded in the call by services being created,
+// instead of unwinding goroutine's stack.
+func checkResolved(serviceType reflect.Type, path *resolvePath, resolve func() reflect.Value) (val reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			val, err = reflect.Value{}, path.call.resolutionError(serviceType, r)
+		}
+	}()
+	val = resolve()
+	if path.call.cause != nil {
+		return reflect.Value{}, newResolutionError(serviceType, path.call.failed, path.call.cause)
+	}
+	if path.call.notFound {
+		return reflect.Value{}, newResolutionError(serviceType, path.call.failed, ErrNotFound)
+	}
+	if !val.IsValid() {
+		return val, newResolutionError(serviceType, nil, ErrNotFound)
+	}
+	return val, nil
+}
+
+func (c *defaultContainer) SetRecoverFactoryPanics(enabled bool) {
+	if enabled {
+		atomic.StoreInt32(&c.recoverFactory, 1)
+	} else {
+		atomic.StoreInt32(&c.recoverFactory, 0)
+	}
+}
+
+func (c *defaultContainer) SetLogger(logger Logger) {
+	c.logger.Store(loggerHolder{Logger: logger})
+}
+
+// logf to log by the logger if set.
+func (c *defaultContainer) logf(format string, v ...any) {
+	if holder, ok := c.logger.Load().(loggerHolder); ok && holder.Logger != nil {
+		holder.Logger.Printf(format, v...)
+	}
+}
+
+// callFactory to create instance by factory of binding for 'origin' in the call of 'path', returns false if it panics
+// and recovering is enabled.
+func (c *defaultContainer) callFactory(b *serviceBinding, origin *defaultContainer, path *resolvePath) (instance reflect.Value, ok bool) {
+	factory := b.InstanceFactory
+	if b.ResolverFactory != nil {
+		var resolver Resolver = origin
+		if path.isDry() {
+			// services resolved by factory are dry too
+			resolver = resolvingContainer{defaultContainer: origin, path: path}
+		}
+		factory = func() any {
+			return b.ResolverFactory(resolver)
+		}
+	}
+	return c.invokeFactory(b.ServiceType, factory, path)
+}
+
+// invokeFactory to create instance of 'serviceType' by 'factory' in the call of 'path', with retrying and recovering
+// of current container.
+func (c *defaultContainer) invokeFactory(serviceType reflect.Type, factory func() any, path *resolvePath) (instance reflect.Value, ok bool) {
+	if path != nil {
+		// services resolved by the factory in new calls are linked to the call, see 'waitGraph.wait'
+		call := path.call
+		invoking, invoked := call.invoking, call.invoked
+		call.invoking, call.invoked = path, serviceType
+		defer func() { call.invoking, call.invoked = invoking, invoked }()
+	}
+	if retry, ok := c.retry.Load().(resolveRetry); ok {
+		for attempt := 1; attempt < retry.attempts; attempt++ {
+			attempted, err := tryFactory(factory)
+			if err == nil {
+				return attempted, true
+			}
+			c.logf("attempt %d of factory of service '%v' fail: %v", attempt, serviceType, err)
+			time.Sleep(retry.backoff)
+		}
+	}
+	if atomic.LoadInt32(&c.recoverFactory) == 0 {
+		return reflect.ValueOf(factory()), true
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			factoryErr, isFactoryErr := r.(*FactoryError)
+			var err error = factoryErr
+			if !isFactoryErr {
+				err = panicError(fmt.Sprintf("factory of service '%v' panic", serviceType), r)
+			}
+			c.logf("%v", err)
+			if path != nil {
+				path.call.recovered(append(path.types(), serviceType), err)
+			}
+			instance, ok = reflect.Value{}, false
+		}
+	}()
+	return reflect.ValueOf(factory()), true
+}
+
+// panicError to convert recovered value into error with message, and wrap it if it's error.
+func panicError(message string, r any) error {
+	if err, ok := r.(error); ok {
+		return fmt.Errorf("%s: %w", message, err)
+	}
+	return fmt.Errorf("%s: %v", message, r)
+}
@@ -0,0 +1,179 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type recordLogger struct {
+	messages []string
+}
+
+func (l *recordLogger) Printf(format string, v ...any) {
+	l.messages = append(l.messages, fmt.Sprintf(format, v...))
+}
+
+var errFactory = errors.New("factory fail")
+
+func TestRecoverFactoryPanics(t *testing.T) {
+	t.Run("factory panic should propagate by default", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { panic(errFactory) })
+		func() {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("factory panic should propagate")
+				}
+			}()
+			GetService[service1]()
+		}()
+		if _, err := GetServiceE[service1](); !errors.Is(err, errFactory) {
+			t.Errorf("factory panic should be surfaced as error, but %v", err)
+			return
+		}
+	})
+
+	t.Run("factory panic should be recovered if enabled", func(t *testing.T) {
+		globalContainer = New()
+		logger := &recordLogger{}
+		globalContainer.(ErrorResolver).SetLogger(logger)
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		calls := 0
+		AddTransient[service1](func() service1 {
+			if calls++; calls == 1 {
+				panic("boom")
+			}
+			return &serviceInstance1{name: "instance1"}
+		})
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		_ = globalContainer.(Rebinder).Rebind(serviceType, LifetimeSingleton)
+		if globalContainer.Resolve(serviceType).IsValid() {
+			t.Error("instance of panicking factory should be invalid")
+			return
+		}
+		if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "boom") {
+			t.Errorf("recovered panic should be logged, but %v", logger.messages)
+			return
+		}
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "instance1" {
+			t.Error("failure should not be cached by singleton")
+			return
+		}
+	})
+
+	t.Run("resolve E should return recovered panic as cause", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		AddTransient[service1](func() service1 { panic("boom") })
+		AddFactoryFor[service2](func(r Resolver) (service2, error) {
+			return nil, errors.New("unavailable")
+		})
+		if _, err := GetServiceE[service1](); err == nil || errors.Is(err, ErrNotFound) || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("recovered panic should be the cause, but %v", err)
+			return
+		}
+		var factoryErr *FactoryError
+		if _, err := GetServiceE[service2](); !errors.As(err, &factoryErr) || factoryErr.Err.Error() != "unavailable" {
+			t.Errorf("factory error should be the cause, but %v", err)
+			return
+		}
+	})
+
+	t.Run("scoped factory panic should be recovered and not cached", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		calls := 0
+		AddScoped[service1](func() service1 {
+			if calls++; calls == 1 {
+				panic("boom")
+			}
+			return &serviceInstance1{name: "instance1"}
+		})
+		scope := globalContainer.(ScopeContainer).NewScope()
+		if _, err := GetServiceEFromC[service1](scope); err == nil || !strings.Contains(err.Error(), "boom") {
+			t.Errorf("scoped factory panic should be recovered, but %v", err)
+			return
+		}
+		if svc := GetServiceFromC[service1](scope); svc == nil || svc != GetServiceFromC[service1](scope) {
+			t.Error("scoped instance should be created again and cached")
+			return
+		}
+	})
+
+	t.Run("resolve E should report not found", func(t *testing.T) {
+		globalContainer = New()
+		if _, err := GetServiceE[service1](); err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("service not found should fail, but %v", err)
+			return
+		}
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		if svc, err := GetServiceE[service1](); err != nil || svc.GetName() != "instance1" {
+			t.Error("service should be resolved")
+			return
+		}
+	})
+}
+
+func TestGetServiceOk(t *testing.T) {
+	t.Run("factory returning wrong type should be surfaced", func(t *testing.T) {
+		globalContainer = New()
+		logger := &recordLogger{}
+		globalContainer.(ErrorResolver).SetLogger(logger)
+		_ = globalContainer.AddTransient(TypeOf[service1](), func() any { return &serviceInstance8{} })
+
+		if svc, ok := GetServiceOk[service1](); ok || svc != nil {
+			t.Error("instance of wrong type should not be ok")
+			return
+		}
+		if len(logger.messages) != 1 || !strings.Contains(logger.messages[0], "'*ioc.serviceInstance8'") {
+			t.Errorf("dynamic type should be logged, but %v", logger.messages)
+			return
+		}
+		if _, err := GetServiceE[service1](); err == nil || !strings.Contains(err.Error(), "'*ioc.serviceInstance8'") {
+			t.Errorf("dynamic type should be in error, but %v", err)
+			return
+		}
+	})
+
+	t.Run("found should be ok and not found should not", func(t *testing.T) {
+		globalContainer = New()
+		if svc, ok := GetServiceOk[service1](); ok || svc != nil {
+			t.Error("not found should be zero value and not ok")
+			return
+		}
+		AddTransient[service2](func() service2 { return nil })
+		if svc, ok := GetServiceOk[service2](); !ok || svc != nil {
+			t.Error("nil instance found should be ok")
+			return
+		}
+		AddSingleton[service1](&serviceInstance1{name: "s1"})
+		if svc, ok := GetServiceOk[service1](); !ok || svc == nil || svc.GetName() != "s1" {
+			t.Error("instance found should be ok")
+			return
+		}
+	})
+}
@@ -0,0 +1,348 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+)
+
+// RegisterOption is option of 'Registerer.Register'.
+type RegisterOption func(reg *registration) error
+
+// registration is the options of 'Registerer.Register'.
+type registration struct {
+	ServiceType     reflect.Type
+	InstanceFactory func() any
+	Key             any
+	HasKey          bool
+	Group           string
+	Order           int
+	NotInherited    bool
+	Primary         bool
+	DisposeOrder    int
+	InitializerName string
+	CacheTTL        time.Duration
+	Weak            bool
+	Feature         string
+	Profiles        []string
+}
+
+// As to specify service type, default is the dynamic type of instance.
+func As(serviceType reflect.Type) RegisterOption {
+	return func(reg *registration) error {
+		if serviceType == nil {
+			return errors.New("param 'serviceType' is null")
+		}
+		reg.ServiceType = serviceType
+		return nil
+	}
+}
+
+// Transient to register as transient with the factory, and instance of 'Registerer.Register' should be nil.
+func Transient(instanceFactory func() any) RegisterOption {
+	return func(reg *registration) error {
+		if instanceFactory == nil {
+			return errors.New("param 'instanceFactory' is null")
+		}
+		reg.InstanceFactory = instanceFactory
+		return nil
+	}
+}
+
+// Keyed to register as keyed service, see 'KeyedContainer.AddKeyedSingleton' and 'KeyedContainer.AddKeyedTransient'.
+func Keyed(key any) RegisterOption {
+	return func(reg *registration) error {
+		if err := checkKey(key); err != nil {
+			return err
+		}
+		reg.Key = key
+		reg.HasKey = true
+		return nil
+	}
+}
+
+// InGroup to add singleton to group instead of registering as service, see 'Grouper.AddToGroup'.
+func InGroup(groupName string) RegisterOption {
+	return func(reg *registration) error {
+		if groupName == "" {
+			return errors.New("param 'groupName' is empty")
+		}
+		reg.Group = groupName
+		return nil
+	}
+}
+
+// Order to specify order of service in 'Grouper.ResolveGroup' and 'AllResolver.ResolveAll', sorted by ascending order,
+// and then by registration order. Default is 0.
+func Order(order int) RegisterOption {
+	return func(reg *registration) error {
+		reg.Order = order
+		return nil
+	}
+}
+
+// NotInherited to make service not resolved by child containers, which miss it unless they register their own.
+func NotInherited() RegisterOption {
+	return func(reg *registration) error {
+		reg.NotInherited = true
+		return nil
+	}
+}
+
+// AsPrimary to make service as the primary one, which is resolved by 'Resolve' without key, even if it's keyed.
+// It takes place of the existing one without key, and at most one primary for each service in a container.
+func AsPrimary() RegisterOption {
+	return func(reg *registration) error {
+		reg.Primary = true
+		return nil
+	}
+}
+
+// DisposeOrder to specify order of disposing instance of service by 'ScopeContainer.Dispose', lower one is disposed first.
+// Default is 0, and instances with the same order are disposed in reverse initialization order.
+//
+//	// flush cache before closing database, whatever the initialization order is
+//	ioc.Register(cache, ioc.DisposeOrder(-1))
+//	ioc.Register(db, ioc.DisposeOrder(1))
+func DisposeOrder(order int) RegisterOption {
+	return func(reg *registration) error {
+		reg.DisposeOrder = order
+		return nil
+	}
+}
+
+// WithInitializer to invoke method 'name' to initialize singleton instead of 'Initialize' or the one of 'ioc.CustomInitializer',
+// such as 'Setup' of type not owned. The method should exist, and it's params are resolved the same as 'Initialize'.
+//
+//	ioc.Register(&thirdparty.Client{}, ioc.WithInitializer("Setup"))
+func WithInitializer(name string) RegisterOption {
+	return func(reg *registration) error {
+		if name == "" {
+			return errors.New("param 'name' is empty")
+		}
+		reg.InitializerName = name
+		return nil
+	}
+}
+
+// CacheFor to cache instance of transient for duration 'd', and then create a new one when resolving after it expires,
+// for expensive but idempotent services, such as config fetched from remote. It's rejected for singleton.
+//
+//	ioc.Register(nil, ioc.As(ioc.TypeOf[*RemoteConfig]()), ioc.Transient(fetchConfig), ioc.CacheFor(30*time.Second))
+func CacheFor(d time.Duration) RegisterOption {
+	return func(reg *registration) error {
+		if d <= 0 {
+			return errors.New("param 'd' should be positive")
+		}
+		reg.CacheTTL = d
+		return nil
+	}
+}
+
+// Weak to register factory as singleton held by weak reference, which can be garbage collected when it's not referenced
+// outside the container, and it's created by factory again on next resolving. It's for large sets of rarely used singletons.
+// When it's collected is up to the garbage collector and not deterministic, so the instance should be stateless or
+// it's state can be rebuilt, and it shouldn't be compared by identity across resolving. Instance not a pointer isn't cached at all.
+// It requires go1.24 for weak pointer, and instance is cached as lazy singleton before go1.24.
+//
+//	ioc.Register(nil, ioc.As(ioc.TypeOf[*GeoIndex]()), ioc.Transient(loadGeoIndex), ioc.Weak())
+func Weak() RegisterOption {
+	return func(reg *registration) error {
+		reg.Weak = true
+		return nil
+	}
+}
+
+// InFeature to register service in feature group, which is added by 'FeatureToggler.AddFeature' and can be disabled at runtime.
+func InFeature(name string) RegisterOption {
+	return func(reg *registration) error {
+		if name == "" {
+			return errors.New("param 'name' is empty")
+		}
+		reg.Feature = name
+		return nil
+	}
+}
+
+// ForProfiles to register service resolved only if any of 'profiles' is active, see 'ProfileActivator.SetActiveProfiles'.
+// It takes place of the one without profiles when active, and it can't be keyed or added to group.
+//
+//	ioc.Register(&smtpMailer{}, ioc.As(ioc.TypeOf[Mailer]()), ioc.ForProfiles("prod"))
+//	ioc.Register(&fakeMailer{}, ioc.As(ioc.TypeOf[Mailer]()), ioc.ForProfiles("dev", "test"))
+func ForProfiles(profiles ...string) RegisterOption {
+	return func(reg *registration) error {
+		if len(profiles) == 0 {
+			return errors.New("param 'profiles' is empty")
+		}
+		for _, profile := range profiles {
+			if profile == "" {
+				return errors.New("profile should not be empty")
+			}
+		}
+		reg.Profiles = append([]string(nil), profiles...)
+		return nil
+	}
+}
+
+// Register to add service with options to global container.
+//
+// It will panic if 'instance' or 'opts' is invalid.
+func Register(instance any, opts ...RegisterOption) {
+	if err := globalContainer.(Registerer).Register(instance, opts...); err != nil {
+		panic(err)
+	}
+}
+
+// Registerer is implemented by container to add service with options.
+type Registerer interface {
+	// Register to add service with options, the service type is inferred from the dynamic type of instance if not specified by 'ioc.As'.
+	// It's singleton by default, and transient by 'ioc.Transient', and keyed by 'ioc.Keyed'.
+	//
+	//  var container ioc.Container
+	//  // *ServiceImplementation1 as service, register as singleton
+	//  err := container.(ioc.Registerer).Register(&ServiceImplementation1{Field1: "abc"})
+	//  // interface as service, register as keyed singleton
+	//  err = container.(ioc.Registerer).Register(&ServiceImplementation1{Field1: "abc"}, ioc.As(reflect.TypeOf((*Service1)(nil)).Elem()), ioc.Keyed("abc"))
+	//  // interface as service, register as transient
+	//  err = container.(ioc.Registerer).Register(nil, ioc.As(reflect.TypeOf((*Service1)(nil)).Elem()), ioc.Transient(func() any {
+	//      return &ServiceImplementation1{Field1: "abc"}
+	//  }))
+	Register(instance any, opts ...RegisterOption) error
+}
+
+var _ Registerer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Register(instance any, opts ...RegisterOption) error {
+	reg := &registration{}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(reg); err != nil {
+			return err
+		}
+	}
+	binding, err := reg.newBinding(instance)
+	if err != nil {
+		return err
+	}
+	if reg.Feature != "" {
+		if binding.Feature = c.getFeature(reg.Feature); binding.Feature == nil {
+			return fmt.Errorf("feature group '%s' not found", reg.Feature)
+		}
+	}
+	if len(reg.Profiles) > 0 {
+		return c.addProfiled(reg, binding)
+	}
+	return c.addRegistration(reg, binding)
+}
+
+// addRegistration to store binding by group, key and primary of registration.
+func (c *defaultContainer) addRegistration(reg *registration, binding *serviceBinding) (err error) {
+	switch {
+	case reg.Group != "":
+		if reg.HasKey || binding.InstanceFactory != nil || reg.Primary {
+			return errors.New("only singleton without key can be added to group, and it can't be primary")
+		}
+		return c.addToGroup(reg.Group, binding)
+	case reg.HasKey:
+		if err = c.addKeyedBinding(reg.Key, binding); err != nil || !reg.Primary {
+			return err
+		}
+		return c.setPrimary(binding)
+	case reg.Primary:
+		if err = validateBinding(binding); err != nil {
+			return err
+		}
+		binding.RegisteredAt = registrationSite()
+		binding.Seq = atomic.AddUint64(&c.seq, 1)
+		return c.setPrimary(binding)
+	}
+	return c.addBinding(binding)
+}
+
+// newBinding to create binding of registration for instance.
+func (reg *registration) newBinding(instance any) (*serviceBinding, error) {
+	var binding *serviceBinding
+	if reg.InstanceFactory != nil {
+		if instance != nil {
+			return nil, errors.New("can't register as both singleton instance and transient factory")
+		}
+		if reg.ServiceType == nil {
+			return nil, errors.New("service type should be specified by 'ioc.As' when registering transient")
+		}
+		if reg.InitializerName != "" {
+			return nil, errors.New("initializer can only be specified for singleton")
+		}
+		binding = &serviceBinding{ServiceType: reg.ServiceType, InstanceFactory: reg.InstanceFactory}
+		if reg.CacheTTL > 0 && reg.Weak {
+			return nil, errors.New("can't specify both cache duration and weak reference")
+		}
+		if reg.CacheTTL > 0 {
+			binding.TTL = &ttlCache{ttl: reg.CacheTTL}
+		}
+		if reg.Weak {
+			binding.Lazy = true
+			binding.Weak = &weakCache{}
+		}
+	} else {
+		if reg.CacheTTL > 0 {
+			return nil, errors.New("cache duration can only be specified for transient")
+		}
+		if reg.Weak {
+			return nil, errors.New("weak reference can only be specified with factory")
+		}
+		if err := checkInstance(instance); err != nil {
+			return nil, err
+		}
+		serviceType := reg.ServiceType
+		if serviceType == nil {
+			serviceType = reflect.TypeOf(instance)
+		}
+		var err error
+		if binding, err = newSingletonBindingWithInitializer(serviceType, instance, reg.InitializerName); err != nil {
+			return nil, err
+		}
+	}
+	binding.Order = reg.Order
+	binding.NotInherited = reg.NotInherited
+	binding.DisposeOrder = reg.DisposeOrder
+	return binding, nil
+}
+
+// setPrimary to store binding as the primary one for resolving without key.
+func (c *defaultContainer) setPrimary(binding *serviceBinding) error {
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	if existing := c.getBinding(binding.ServiceType); existing != nil && existing.Primary {
+		return fmt.Errorf("primary of service '%v' already exists, registered at '%s'", binding.ServiceType, existing.RegisteredAt)
+	}
+	binding.Primary = true
+	c.bindings.Store(binding.ServiceType, binding)
+	c.bindingsChanged()
+	c.indexTypeName(binding.ServiceType)
+	return nil
+}
@@ -0,0 +1,274 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("register with dynamic type of instance should success", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "instance1"}
+		Register(svc1)
+		if GetService[*serviceInstance1]() != svc1 {
+			t.Error("service type should be inferred from instance")
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf(svc1)); lifetime != LifetimeSingleton {
+			t.Error("lifetime should be singleton by default")
+			return
+		}
+	})
+
+	t.Run("register with options should success", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		svc1 := &serviceInstance1{name: "instance1"}
+		if err := globalContainer.(Registerer).Register(svc1, As(serviceType)); err != nil {
+			t.Errorf("register singleton fail: %v", err)
+			return
+		}
+		if GetService[service1]() != svc1 {
+			t.Error("service type should be specified by 'As'")
+			return
+		}
+
+		keyed := &serviceInstance1{name: "keyed"}
+		if err := globalContainer.(Registerer).Register(keyed, As(serviceType), Keyed("abc")); err != nil {
+			t.Errorf("register keyed singleton fail: %v", err)
+			return
+		}
+		if val := globalContainer.(KeyedContainer).ResolveKeyed("abc", serviceType); !val.IsValid() || val.Interface() != keyed {
+			t.Error("keyed singleton should be resolved")
+			return
+		}
+
+		factory := func() any { return &serviceInstance2{name: "instance2"} }
+		if err := globalContainer.(Registerer).Register(nil, As(reflect.TypeOf((*service2)(nil)).Elem()), Transient(factory)); err != nil {
+			t.Errorf("register transient fail: %v", err)
+			return
+		}
+		if _, lifetime, found := globalContainer.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf((*service2)(nil)).Elem()); !found || lifetime != LifetimeTransient {
+			t.Error("transient should be resolved")
+			return
+		}
+
+		if err := globalContainer.(Registerer).Register(nil, Keyed(1), As(serviceType), Transient(func() any { return &serviceInstance1{} })); err != nil {
+			t.Errorf("register keyed transient fail: %v", err)
+			return
+		}
+		first := globalContainer.(KeyedContainer).ResolveKeyed(1, serviceType)
+		if !first.IsValid() || first.Interface() == globalContainer.(KeyedContainer).ResolveKeyed(1, serviceType).Interface() {
+			t.Error("keyed transient should be resolved as transient")
+			return
+		}
+	})
+
+	t.Run("register with invalid options should fail", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		factory := func() any { return &serviceInstance1{} }
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, As(serviceType), Transient(factory)); err == nil {
+			t.Error("both singleton instance and transient factory should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(nil, Transient(factory)); err == nil {
+			t.Error("transient without service type should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(nil); err == nil {
+			t.Error("null instance should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, As(nil)); err == nil {
+			t.Error("null service type should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(nil, As(serviceType), Transient(nil)); err == nil {
+			t.Error("null factory should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, Keyed([]string{})); err == nil {
+			t.Error("key not comparable should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&emailSender{}, As(serviceType)); err == nil {
+			t.Error("instance not implement service should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("null instance should panic")
+			}
+		}()
+		Register(nil)
+	})
+}
+
+func TestNotInherited(t *testing.T) {
+	t.Run("not inherited service should not be resolved by child", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := reflect.TypeOf((*service1)(nil)).Elem()
+		svc1 := &serviceInstance1{name: "parent"}
+		Register(svc1, As(serviceType), NotInherited())
+		Register(&serviceInstance1{name: "keyed"}, As(serviceType), Keyed("abc"), NotInherited())
+		Register(&serviceInstance2{name: "inherited"}, As(reflect.TypeOf((*service2)(nil)).Elem()))
+		child := globalContainer.(ScopeContainer).NewScope()
+
+		if GetService[service1]() != svc1 {
+			t.Error("not inherited service should be resolved by the container registered")
+			return
+		}
+		if GetServiceFromC[service1](child) != nil {
+			t.Error("not inherited service should not be resolved by child")
+			return
+		}
+		// only the inherited one which implements service1
+		if child.(KeyedContainer).ResolveKeyed("abc", serviceType).IsValid() || len(child.(AllResolver).ResolveAll(serviceType)) != 1 {
+			t.Error("not inherited keyed service should not be resolved by child")
+			return
+		}
+		if GetServiceFromC[service2](child) == nil {
+			t.Error("inherited service should be resolved by child")
+			return
+		}
+
+		own := &serviceInstance1{name: "child"}
+		AddSingletonToC[service1](child, own)
+		if GetServiceFromC[service1](child) != own {
+			t.Error("child's own service should be resolved")
+			return
+		}
+	})
+
+	t.Run("singleton depends on not inherited service resolved by child first should be injected", func(t *testing.T) {
+		globalContainer = New()
+		Register(&serviceInstance7{name: "instance7"}, NotInherited())
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		child := globalContainer.(ScopeContainer).NewScope()
+
+		if GetServiceFromC[*serviceInstance8](child).GetS7Name() != "instance7" {
+			t.Error("singleton should be injected with not inherited service of the container registered")
+			return
+		}
+		if GetService[*serviceInstance8]().GetS7Name() != "instance7" {
+			t.Error("singleton should be injected with not inherited service of the container registered")
+			return
+		}
+	})
+}
+
+func TestAsPrimary(t *testing.T) {
+	t.Run("primary should be resolved without key", func(t *testing.T) {
+		globalContainer = New()
+		serviceType := TypeOf[service1]()
+		Register(&serviceInstance1{name: "default"}, As(serviceType))
+		Register(&serviceInstance1{name: "a"}, As(serviceType), Keyed("a"))
+		primary := &serviceInstance1{name: "b"}
+		Register(primary, As(serviceType), Keyed("b"), AsPrimary())
+		globalContainer.(DuplicatePolicySetter).SetDuplicatePolicy(DuplicateReplace)
+		Register(&serviceInstance1{name: "c"}, As(serviceType))
+
+		if GetService[service1]() != primary {
+			t.Error("primary should be resolved without key")
+			return
+		}
+		if val := globalContainer.(KeyedContainer).ResolveKeyed("a", serviceType); !val.IsValid() || val.Interface().(service1).GetName() != "a" {
+			t.Error("others should be resolved by key")
+			return
+		}
+		if val := globalContainer.(KeyedContainer).ResolveKeyed("b", serviceType); !val.IsValid() || val.Interface() != primary {
+			t.Error("primary should also be resolved by key")
+			return
+		}
+		if len(ResolveAll[service1]()) != 2 {
+			t.Error("primary should be resolved once by 'ResolveAll'")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{name: "d"}, As(serviceType), AsPrimary()); err == nil {
+			t.Error("second primary should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{name: "e"}, As(serviceType), InGroup("group1"), AsPrimary()); err == nil {
+			t.Error("primary in group should fail")
+			return
+		}
+	})
+}
+
+// thirdPartyClient is like a type not owned, which is initialized by 'Setup'.
+type thirdPartyClient struct {
+	s1          service1
+	initialized bool
+}
+
+func (c *thirdPartyClient) Setup(s1 service1) {
+	c.s1 = s1
+}
+
+func (c *thirdPartyClient) Initialize() {
+	c.initialized = true
+}
+
+func (c *thirdPartyClient) Attach(self *thirdPartyClient) {
+}
+
+func TestWithInitializer(t *testing.T) {
+	t.Run("method specified should be invoked to initialize", func(t *testing.T) {
+		globalContainer = New()
+		svc1 := &serviceInstance1{name: "s1"}
+		AddSingleton[service1](svc1)
+		client := &thirdPartyClient{}
+		Register(client, WithInitializer("Setup"))
+		if GetService[*thirdPartyClient]() != client || client.s1 != svc1 {
+			t.Error("method specified should be invoked with params resolved")
+			return
+		}
+		if client.initialized {
+			t.Error("default initialize method should not be invoked")
+			return
+		}
+	})
+
+	t.Run("invalid initializer should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Registerer).Register(&thirdPartyClient{}, WithInitializer("NotExists")); err == nil {
+			t.Error("method not exists should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&thirdPartyClient{}, WithInitializer("Attach")); err == nil {
+			t.Error("cycle reference should fail")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(&thirdPartyClient{}, WithInitializer("")); err == nil {
+			t.Error("empty name should fail")
+			return
+		}
+		factory := func() any { return &thirdPartyClient{} }
+		if err := globalContainer.(Registerer).Register(nil, As(TypeOf[*thirdPartyClient]()), Transient(factory), WithInitializer("Setup")); err == nil {
+			t.Error("initializer of transient should fail")
+			return
+		}
+	})
+}
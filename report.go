@@ -0,0 +1,159 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Report of injection, see 'InjectReport'.
+type Report struct {
+	Items []ReportItem
+}
+
+// ReportItem is the injection result of a field of *struct or a param of func.
+type ReportItem struct {
+	Name     string       // field name, or param index such as "#0"
+	Type     reflect.Type // type of field or param
+	Resolved bool         // whether it's resolved
+	From     Resolver     // the container in parent chain which resolved it, nil if not resolved
+	Lifetime Lifetime     // lifetime of the binding, unknown if not resolved or not resolved by 'ioc.Container'
+	Adapted  bool         // resolved by adapting '*T' for 'T', or 'T' for '*T', see 'SetPointerValueAdapt'
+}
+
+func (r Report) String() string {
+	var sb strings.Builder
+	for _, item := range r.Items {
+		if item.Resolved {
+			sb.WriteString(fmt.Sprintf("%s %v: resolved, %v\n", item.Name, item.Type, item.Lifetime))
+		} else {
+			sb.WriteString(fmt.Sprintf("%s %v: not resolved\n", item.Name, item.Type))
+		}
+	}
+	return sb.String()
+}
+
+// InjectReport to inject to function or *struct with services from global container, and report the result for debugging.
+func InjectReport(target any) Report {
+	return InjectReportFromC(globalContainer, target)
+}
+
+// InjectReportFromC to inject to function or *struct with services from container, and report the result for debugging.
+// It injects the same as 'InjectFromC', and records where each field or param is resolved from while resolving,
+// including services matched by 'AddResolver' and adapted by 'SetPointerValueAdapt'.
+// Fields of '*struct' injected by itself as 'SelfInjector' are not reported.
+func InjectReportFromC(container Container, target any) Report {
+	c, ok := container.(*defaultContainer)
+	if !ok {
+		return injectReportOf(container, target)
+	}
+	path := newResolvePath()
+	recorder := &injectRecorder{}
+	path.call.recorder = recorder
+	injectFrom(c, target, path)
+	return Report{Items: recorder.items}
+}
+
+// injectReportOf to inject with container which is not created by 'ioc.New', and report fields or params by their values,
+// as where they are resolved from is unknown.
+func injectReportOf(container Container, target any) Report {
+	InjectFromC(container, target)
+
+	var report Report
+	targetVal := injectTargetOf(target)
+	if !targetVal.IsValid() || targetVal.IsZero() {
+		return report
+	}
+	targetType := targetVal.Type()
+	if targetType.Kind() == reflect.Pointer && targetType.Elem().Kind() == reflect.Struct && !targetType.Implements(resolverType) {
+		for _, field := range getFieldsToInject(targetType.Elem()) {
+			item := ReportItem{Name: field.FieldName, Type: field.FieldType}
+			if item.Resolved = !targetVal.Elem().Field(field.FieldIndex).IsZero(); item.Resolved {
+				item.From = container
+			}
+			report.Items = append(report.Items, item)
+		}
+	}
+	return report
+}
+
+// injectRecorder records where services of fields or params are resolved from when injecting, see 'InjectReport'.
+// It's only used by the call of injecting for report, so normal resolving pays nothing but a nil check.
+type injectRecorder struct {
+	item  ReportItem // the field or param being resolved
+	items []ReportItem
+}
+
+// recorderOf to get recorder of injection in the call of 'path', which is nil if it's not for report
+// or services are being created for the injection, such as singletons injected with their dependencies.
+func (p *resolvePath) recorderOf() *injectRecorder {
+	if p == nil || p.binding != nil {
+		return nil
+	}
+	return p.call.recorder
+}
+
+// start to record field or param being resolved.
+func (r *injectRecorder) start(name string, serviceType reflect.Type) {
+	r.item = ReportItem{Name: name, Type: serviceType}
+}
+
+// found to record the container which resolves the field or param, the first one is kept until 'retry'.
+func (r *injectRecorder) found(from Resolver, lifetime Lifetime) {
+	if r.item.From == nil {
+		r.item.From, r.item.Lifetime = from, lifetime
+	}
+}
+
+// adapt to resolve the field or param again by adapting '*T' for 'T', or 'T' for '*T'.
+func (r *injectRecorder) adapt() {
+	r.item.From, r.item.Lifetime, r.item.Adapted = nil, LifetimeUnknown, true
+}
+
+// finish to record whether the field or param is resolved, and it's from 'container' if not found in any container,
+// such as slice and map of services.
+func (r *injectRecorder) finish(container Resolver, resolved bool) {
+	item := r.item
+	if item.Resolved = resolved; !resolved {
+		item.From, item.Lifetime, item.Adapted = nil, LifetimeUnknown, false
+	} else if item.From == nil {
+		item.From = container
+	}
+	r.items = append(r.items, item)
+}
+
+// locateConfig to find the container in parent chain which has the config value.
+func locateConfig(resolver Resolver, key string) Resolver {
+	for resolver != nil {
+		c, ok := resolver.(*defaultContainer)
+		if !ok {
+			return resolver
+		}
+		if _, ok = c.configValues.Load(key); ok {
+			return c
+		}
+		resolver = c.Parent()
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInjectReport(t *testing.T) {
+	t.Run("report of *struct should locate fields", func(t *testing.T) {
+		globalContainer = New()
+		parent := New()
+		AddSingletonToC[service1](parent, &serviceInstance1{name: "instance1"})
+		AddTransient[service2](func() service2 { return &serviceInstance2{name: "instance2"} })
+		SetParent(parent)
+
+		target := &reportClient{}
+		report := InjectReport(target)
+		if target.S1 == nil || target.S2 == nil {
+			t.Error("fields should be injected")
+			return
+		}
+		if len(report.Items) != 3 {
+			t.Error("all injectable fields should be reported")
+			return
+		}
+		if item := report.Items[0]; item.Name != "S1" || !item.Resolved || item.From != parent || item.Lifetime != LifetimeSingleton {
+			t.Error("field 'S1' should be resolved from parent as singleton")
+			return
+		}
+		if item := report.Items[1]; item.Name != "S2" || !item.Resolved || item.From != globalContainer || item.Lifetime != LifetimeTransient {
+			t.Error("field 'S2' should be resolved from current as transient")
+			return
+		}
+		if item := report.Items[2]; item.Name != "S3" || item.Resolved || item.From != nil {
+			t.Error("field 'S3' should not be resolved")
+			return
+		}
+		if !strings.Contains(report.String(), "S3 ioc.service3: not resolved") {
+			t.Errorf("string of report is unexpected: %s", report)
+			return
+		}
+	})
+
+	t.Run("report should record services matched or adapted", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(PointerValueAdapter).SetPointerValueAdapt(true)
+		parent := New()
+		parent.(ResolverAdder).AddResolver(func(serviceType reflect.Type) (reflect.Value, bool) {
+			if serviceType == TypeOf[service1]() {
+				return reflect.ValueOf(&serviceInstance1{name: "matched"}), true
+			}
+			return reflect.Value{}, false
+		})
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "instance7"})
+		SetParent(parent)
+
+		target := &reportAdapted{}
+		report := InjectReport(target)
+		if target.S1 == nil || target.S7.name != "instance7" || len(report.Items) != 2 {
+			t.Error("fields should be injected and reported")
+			return
+		}
+		if item := report.Items[0]; !item.Resolved || item.From != parent || item.Lifetime != LifetimeUnknown || item.Adapted {
+			t.Errorf("field 'S1' should be matched by resolver of parent, but %+v", item)
+			return
+		}
+		if item := report.Items[1]; !item.Resolved || item.From != parent || item.Lifetime != LifetimeSingleton || !item.Adapted {
+			t.Errorf("field 'S7' should be adapted from singleton of parent, but %+v", item)
+			return
+		}
+	})
+
+	t.Run("report of func should locate params", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+
+		invoked := false
+		report := InjectReport(func(s1 service1, s2 service2) {
+			invoked = s1 != nil && s2 == nil
+		})
+		if !invoked {
+			t.Error("func should be invoked")
+			return
+		}
+		if len(report.Items) != 2 || !report.Items[0].Resolved || report.Items[0].Name != "#0" || report.Items[1].Resolved {
+			t.Error("params should be reported")
+			return
+		}
+	})
+}
+
+type reportAdapted struct {
+	S1 service1         `ioc-inject:"true"`
+	S7 serviceInstance7 `ioc-inject:"true"`
+}
+
+type reportClient struct {
+	S1 service1 `ioc-inject:"true"`
+	S2 service2 `ioc-inject:"true"`
+	S3 service3 `ioc-inject:"true"`
+}
@@ -0,0 +1,111 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrNotFound is the cause of 'ResolutionError' if service is not found, including dependency not found in strict mode.
+var ErrNotFound = errors.New("not found")
+
+// ResolutionError is the error of 'ResolveE' and 'GetServiceE', with services resolving when it failed.
+//
+//	var resolutionErr *ioc.ResolutionError
+//	if _, err := ioc.GetServiceE[*OrderService](); errors.As(err, &resolutionErr) {
+//	    // resolutionErr.Stack: [*OrderService, *PaymentClient, Config]
+//	    log.Print(resolutionErr) // *OrderService -> *PaymentClient -> Config: not found
+//	}
+type ResolutionError struct {
+	Requested reflect.Type   // service requested
+	Stack     []reflect.Type // services resolving from the requested one down to the failed one
+	Cause     error          // such as 'ErrNotFound', '*FactoryError' or panic during resolving
+}
+
+func (e *ResolutionError) Error() string {
+	var sb strings.Builder
+	for i, serviceType := range e.Stack {
+		if i > 0 {
+			sb.WriteString(" -> ")
+		}
+		sb.WriteString(fmt.Sprint(serviceType))
+	}
+	sb.WriteString(": ")
+	sb.WriteString(e.Cause.Error())
+	return sb.String()
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Cause
+}
+
+// fail to record services being created when the call failed, the failed one last.
+// It's recorded by the innermost one, so services failed by it when unwinding don't change it.
+func (call *resolveCall) fail(stack []reflect.Type, notFound bool) {
+	if call != nil && call.failed == nil {
+		call.failed, call.notFound = stack, notFound
+	}
+}
+
+// recovered to record services being created when panic of factory is recovered, the failed one last,
+// and the panic as cause.
+func (call *resolveCall) recovered(stack []reflect.Type, cause error) {
+	if call != nil && call.failed == nil {
+		call.failed, call.cause = stack, cause
+	}
+}
+
+// notFoundError to create error of service not found in the call of 'path'.
+func notFoundError(path *resolvePath, serviceType reflect.Type) *ResolutionError {
+	stack := append(path.types(), serviceType)
+	return &ResolutionError{Requested: stack[0], Stack: stack, Cause: ErrNotFound}
+}
+
+// resolutionError to create error of resolving 'serviceType' which failed in the call with panic 'r'.
+func (call *resolveCall) resolutionError(serviceType reflect.Type, r any) *ResolutionError {
+	stack := call.failed
+	var cause error
+	switch err := r.(type) {
+	case *ResolutionError:
+		// not found in strict mode, and the error of call started by factory is after services failed by it
+		if !call.notFound {
+			stack = append(stack, err.Stack...)
+		}
+		cause = err.Cause
+	case error:
+		cause = err
+	default:
+		cause = fmt.Errorf("panic: %v", r)
+	}
+	return newResolutionError(serviceType, stack, cause)
+}
+
+// newResolutionError to create error of resolving 'serviceType', and the stack starts from it.
+func newResolutionError(serviceType reflect.Type, stack []reflect.Type, cause error) *ResolutionError {
+	if len(stack) == 0 || stack[0] != serviceType {
+		stack = append([]reflect.Type{serviceType}, stack...)
+	}
+	return &ResolutionError{Requested: serviceType, Stack: stack, Cause: cause}
+}
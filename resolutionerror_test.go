@@ -0,0 +1,141 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type orderService struct {
+	Payment *paymentClient `ioc-inject:"true"`
+}
+
+type paymentClient struct {
+	Dep service3 `ioc-inject:"true"`
+}
+
+func TestResolutionError(t *testing.T) {
+	t.Run("stack should be from requested down to the missing one", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		AddSingleton[*orderService](&orderService{})
+		AddSingleton[*paymentClient](&paymentClient{})
+		_, err := GetServiceE[*orderService]()
+		var resolutionErr *ResolutionError
+		if !errors.As(err, &resolutionErr) {
+			t.Errorf("error should be resolution error, but %v", err)
+			return
+		}
+		expected := []reflect.Type{TypeOf[*orderService](), TypeOf[*paymentClient](), TypeOf[service3]()}
+		if resolutionErr.Requested != expected[0] || !reflect.DeepEqual(resolutionErr.Stack, expected) {
+			t.Errorf("stack should be %v, but %v", expected, resolutionErr.Stack)
+			return
+		}
+		if !errors.Is(err, ErrNotFound) || err.Error() != "*ioc.orderService -> *ioc.paymentClient -> ioc.service3: not found" {
+			t.Errorf("error should be not found, but %v", err)
+			return
+		}
+	})
+
+	t.Run("dependency not found should fail without strict mode", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*orderService](&orderService{})
+		AddSingleton[*paymentClient](&paymentClient{})
+		val, err := GetServiceE[*orderService]()
+		var resolutionErr *ResolutionError
+		if val != nil || !errors.As(err, &resolutionErr) || !errors.Is(err, ErrNotFound) {
+			t.Errorf("dependency not found should fail, but %v", err)
+			return
+		}
+		if expected := []reflect.Type{TypeOf[*orderService](), TypeOf[*paymentClient](), TypeOf[service3]()}; !reflect.DeepEqual(resolutionErr.Stack, expected) {
+			t.Errorf("stack should be %v, but %v", expected, resolutionErr.Stack)
+			return
+		}
+		if GetService[*orderService]() == nil {
+			t.Error("resolve should not fail without strict mode")
+			return
+		}
+	})
+
+	t.Run("stack should include transients created by factories", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		AddSingleton[*orderService](&orderService{})
+		AddTransient[*paymentClient](func() *paymentClient {
+			return &paymentClient{Dep: GetService[service3]()}
+		})
+		_, err := GetServiceE[*orderService]()
+		var resolutionErr *ResolutionError
+		if !errors.As(err, &resolutionErr) || len(resolutionErr.Stack) != 3 || resolutionErr.Stack[1] != TypeOf[*paymentClient]() {
+			t.Errorf("transient should be in stack, but %v", err)
+			return
+		}
+	})
+
+	t.Run("panic of factory should be the cause", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*orderService](&orderService{})
+		AddTransient[*paymentClient](func() *paymentClient { panic(errFactory) })
+		_, err := GetServiceE[*orderService]()
+		var resolutionErr *ResolutionError
+		if !errors.As(err, &resolutionErr) || !errors.Is(err, errFactory) || errors.Is(err, ErrNotFound) {
+			t.Errorf("factory error should be the cause, but %v", err)
+			return
+		}
+		if expected := []reflect.Type{TypeOf[*orderService](), TypeOf[*paymentClient]()}; !reflect.DeepEqual(resolutionErr.Stack, expected) {
+			t.Errorf("stack should be %v, but %v", expected, resolutionErr.Stack)
+			return
+		}
+	})
+
+	t.Run("service not found should be resolution error", func(t *testing.T) {
+		globalContainer = New()
+		_, err := GetServiceE[service1]()
+		var resolutionErr *ResolutionError
+		if !errors.As(err, &resolutionErr) || !errors.Is(err, ErrNotFound) || len(resolutionErr.Stack) != 1 {
+			t.Errorf("service not found should be resolution error, but %v", err)
+			return
+		}
+	})
+
+	t.Run("stack should not leak after recovered", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StrictResolver).SetStrictResolve(true)
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		globalContainer.(ErrorResolver).SetLogger(&recordLogger{})
+		AddTransient[*paymentClient](func() *paymentClient {
+			return &paymentClient{Dep: GetService[service3]()}
+		})
+		AddTransient[service1](func() service1 {
+			GetService[*paymentClient]()
+			panic("boom")
+		})
+		_, err := GetServiceE[service1]()
+		var resolutionErr *ResolutionError
+		if !errors.As(err, &resolutionErr) || len(resolutionErr.Stack) != 1 {
+			t.Errorf("stack of recovered panic should be discarded, but %v", err)
+			return
+		}
+	})
+}
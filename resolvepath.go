@@ -0,0 +1,202 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// resolveCall is a call resolving service from container, shared by all services resolved for it.
+type resolveCall struct {
+	waiting    *serviceBinding                   // binding initializing by another call which current is waiting for, guarded by 'initWaits'
+	goroutine  uint64                            // id of goroutine running the call, recorded when it starts initializing, guarded by 'initWaits'
+	invoking   *resolvePath                      // path of the factory being invoked, only accessed by the goroutine running the call
+	invoked    reflect.Type                      // service created by the factory being invoked, accessed as 'invoking'
+	failed     []reflect.Type                    // services being created when the call failed, the failed one last
+	notFound   bool                              // failed as required service not found
+	cause      error                             // panic of factory recovered when the call failed, see 'SetRecoverFactoryPanics'
+	recorder   *injectRecorder                   // records injection for report, nil if not reporting
+	provenance *provenanceRecorder               // records provenance of the requested service, nil if not recording
+	dry        map[*serviceBinding]reflect.Value // copies of singletons initialized by the call, non-nil if it caches nothing, see 'VerifyContainer'
+}
+
+// resolvePath is services being created in a call, from the requested one to the current one.
+// It's immutable and passed explicitly through resolving and injecting, so each call has its own path
+// without state kept for goroutines.
+type resolvePath struct {
+	call    *resolveCall
+	prev    *resolvePath
+	binding *serviceBinding
+}
+
+// newResolvePath to start a new call with no service being created.
+func newResolvePath() *resolvePath {
+	return &resolvePath{call: &resolveCall{}}
+}
+
+// push to get path with the binding being created appended, and it starts a new call if path is nil.
+func (p *resolvePath) push(b *serviceBinding) *resolvePath {
+	if p == nil {
+		return &resolvePath{call: &resolveCall{}, binding: b}
+	}
+	return &resolvePath{call: p.call, prev: p, binding: b}
+}
+
+// callOf to get the call of path, nil if path is nil.
+func (p *resolvePath) callOf() *resolveCall {
+	if p == nil {
+		return nil
+	}
+	return p.call
+}
+
+// types to get services in the path, the requested one first.
+func (p *resolvePath) types() []reflect.Type {
+	var types []reflect.Type
+	for node := p; node != nil && node.binding != nil; node = node.prev {
+		types = append(types, node.binding.ServiceType)
+	}
+	for i, j := 0, len(types)-1; i < j; i, j = i+1, j-1 {
+		types[i], types[j] = types[j], types[i]
+	}
+	return types
+}
+
+// typesAfter to get services created after the binding in the path, all of them if it's not in the path.
+func (p *resolvePath) typesAfter(b *serviceBinding) []reflect.Type {
+	var types []reflect.Type
+	for node := p; node != nil && node.binding != nil && node.binding != b; node = node.prev {
+		types = append([]reflect.Type{node.binding.ServiceType}, types...)
+	}
+	return types
+}
+
+// cycleOf to get error of cycle reference if the binding is being created in the path, otherwise nil.
+func (p *resolvePath) cycleOf(b *serviceBinding) error {
+	for node := p; node != nil; node = node.prev {
+		if node.binding == b {
+			types := append([]reflect.Type{b.ServiceType}, p.typesAfter(b)...)
+			return cycleError(append(types, b.ServiceType))
+		}
+	}
+	return nil
+}
+
+// cycleError to describe services referring each other, the first one is the same as the last one.
+func cycleError(types []reflect.Type) error {
+	names := make([]string, len(types))
+	for i, serviceType := range types {
+		names[i] = fmt.Sprint(serviceType)
+	}
+	return fmt.Errorf("cycle reference: %s", strings.Join(names, " -> "))
+}
+
+// waitGraph is calls waiting for bindings initializing by other calls, to find calls waiting for each other.
+// It guards 'resolveCall.waiting' and 'serviceBinding.initializer', and it's locked after the binding's lock if both are held.
+type waitGraph struct {
+	locker sync.Mutex
+}
+
+var initWaits = &waitGraph{}
+
+// start to record the binding is initializing by the call of 'path', and the goroutine running the call.
+func (g *waitGraph) start(path *resolvePath, binding *serviceBinding) {
+	var gid uint64
+	if path.call.goroutine == 0 {
+		gid = goroutineID()
+	}
+	g.locker.Lock()
+	binding.initializer = path.call
+	if gid != 0 {
+		path.call.goroutine = gid
+	}
+	g.locker.Unlock()
+}
+
+// finish to record the binding is not initializing.
+func (g *waitGraph) finish(binding *serviceBinding) {
+	g.locker.Lock()
+	binding.initializer = nil
+	g.locker.Unlock()
+}
+
+// wait to record the call of 'path' is waiting for the binding initializing by another call, and returns error
+// without recording if it makes a cycle, that is the binding waits for the call transitively, or for a call of
+// current goroutine, which is invoking the factory starting the call of 'path' and can't finish before it.
+// 'early' is true if the cycle is made by waiting calls of different goroutines, then the instance being initialized
+// can be used instead of waiting. The binding's lock should be held, and 'done' should be called after waiting.
+func (g *waitGraph) wait(path *resolvePath, binding *serviceBinding) (early bool, err error) {
+	defer g.locker.Unlock()
+	g.locker.Lock()
+	var gid uint64
+	types := []reflect.Type{binding.ServiceType}
+	for b := binding; b.initializer != nil; {
+		if b.initializer == path.call {
+			// the call is creating 'b', and the services it creates after 'b' close the cycle
+			types = append(types, path.typesAfter(b)...)
+			return b != binding, cycleError(append(types, binding.ServiceType))
+		}
+		if gid == 0 {
+			gid = goroutineID()
+		}
+		if b.initializer.goroutine == gid {
+			// the factory invoked by the call creating 'b' resolves it again in the call of 'path'
+			types = append(types, b.initializer.invoking.typesAfter(b)...)
+			if b.initializer.invoked != nil {
+				types = append(types, b.initializer.invoked)
+			}
+			types = append(types, path.types()...)
+			return false, cycleError(append(types, binding.ServiceType))
+		}
+		if b = b.initializer.waiting; b == nil {
+			break
+		}
+		types = append(types, b.ServiceType)
+	}
+	path.call.waiting = binding
+	return false, nil
+}
+
+func (g *waitGraph) done(path *resolvePath) {
+	g.locker.Lock()
+	path.call.waiting = nil
+	g.locker.Unlock()
+}
+
+// goroutineID to get id of current goroutine, parsed from header of its stack as "goroutine 1 [running]:".
+// It's for goroutine-scoped instances, and for cycles through factories resolving in new calls of the same goroutine.
+func goroutineID() uint64 {
+	var buf [64]byte
+	stack := buf[:runtime.Stack(buf[:], false)]
+	stack = bytes.TrimPrefix(stack, []byte("goroutine "))
+	if i := bytes.IndexByte(stack, ' '); i >= 0 {
+		stack = stack[:i]
+	}
+	id, _ := strconv.ParseUint(string(stack), 10, 64)
+	return id
+}
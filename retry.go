@@ -0,0 +1,67 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"time"
+)
+
+// resolveRetry is the retry policy of factories, see 'RetryResolver.SetResolveRetry'.
+type resolveRetry struct {
+	attempts int
+	backoff  time.Duration
+}
+
+// RetryResolver is implemented by container to retry failed factories.
+type RetryResolver interface {
+	// SetResolveRetry to retry factories of transient, scoped and lazy singleton services registered in current container,
+	// which panic or return null, up to 'attempts' times in total with 'backoff' between attempts.
+	// The last attempt fails as without retry, and cached singletons are never created again after success.
+	// Retries add latency to resolving, use it sparingly, such as for connection pool not ready at first resolving.
+	// 'attempts' less than 2 disables retry.
+	SetResolveRetry(attempts int, backoff time.Duration)
+}
+
+var _ RetryResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetResolveRetry(attempts int, backoff time.Duration) {
+	if backoff < 0 {
+		backoff = 0
+	}
+	c.retry.Store(resolveRetry{attempts: attempts, backoff: backoff})
+}
+
+// tryFactory to call factory, and returns error if it panics or returns null.
+func tryFactory(factory func() any) (instance reflect.Value, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			instance, err = reflect.Value{}, panicError("factory panic", r)
+		}
+	}()
+	instance = reflect.ValueOf(factory())
+	if !instance.IsValid() || instance.IsZero() {
+		return reflect.Value{}, errors.New("factory returns null")
+	}
+	return instance, nil
+}
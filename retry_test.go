@@ -0,0 +1,101 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetResolveRetry(t *testing.T) {
+	t.Run("factory should be retried until success", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(RetryResolver).SetResolveRetry(3, time.Millisecond)
+		calls := 0
+		AddTransient[service1](func() service1 {
+			calls++
+			if calls == 1 {
+				panic(errors.New("pool not ready"))
+			}
+			if calls == 2 {
+				return nil
+			}
+			return &serviceInstance1{name: "ready"}
+		})
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "ready" || calls != 3 {
+			t.Errorf("factory should succeed at third attempt, but called %d times", calls)
+			return
+		}
+	})
+
+	t.Run("lazy singleton should not be created again after success", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(RetryResolver).SetResolveRetry(3, 0)
+		calls := 0
+		AddTransient[service1](func() service1 {
+			calls++
+			if calls == 1 {
+				panic("not ready")
+			}
+			return &serviceInstance1{name: "lazy"}
+		})
+		Rebind[service1](LifetimeSingleton)
+		first := GetService[service1]()
+		if first == nil || GetService[service1]() != first || calls != 2 {
+			t.Errorf("lazy singleton should be created once after retry, but called %d times", calls)
+			return
+		}
+	})
+
+	t.Run("last attempt should fail as without retry", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(RetryResolver).SetResolveRetry(2, 0)
+		calls := 0
+		AddTransient[service1](func() service1 {
+			calls++
+			panic("always fail")
+		})
+		func() {
+			defer func() {
+				if r := recover(); r == nil || calls != 2 {
+					t.Errorf("panic of last attempt should be propagated, but called %d times", calls)
+				}
+			}()
+			GetService[service1]()
+		}()
+
+		calls = 0
+		globalContainer.(ErrorResolver).SetRecoverFactoryPanics(true)
+		if GetService[service1]() != nil || calls != 2 {
+			t.Errorf("panic of last attempt should be recovered, but called %d times", calls)
+			return
+		}
+
+		calls = 0
+		globalContainer.(RetryResolver).SetResolveRetry(0, 0)
+		if GetService[service1]() != nil || calls != 1 {
+			t.Errorf("retry should be disabled, but called %d times", calls)
+			return
+		}
+	})
+}
@@ -0,0 +1,287 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// Disposable is a service that can be disposed by 'ScopeContainer.Dispose'.
+type Disposable interface {
+	// Dispose to release resources of the service.
+	Dispose() error
+}
+
+// disposableInstance is the instance tracked for disposing with it's order.
+type disposableInstance struct {
+	instance reflect.Value
+	order    int
+}
+
+// scopedInstance is the instance of scoped service cached in a container.
+type scopedInstance struct {
+	locker   sync.Mutex
+	created  bool
+	instance reflect.Value
+}
+
+// AddScoped to add scoped service instance factory to global container.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddScoped[TService any](instanceFactory func() TService) {
+	AddScopedToC[TService](globalContainer, instanceFactory)
+}
+
+// AddScopedToC to add scoped service instance factory to container.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddScopedToC[TService any](container Container, instanceFactory func() TService) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	err := container.(ScopeContainer).AddScoped(TypeOf[TService](), func() any {
+		return instanceFactory()
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ScopeContainer is implemented by container to add scoped services, create scopes and dispose them.
+type ScopeContainer interface {
+	// AddScoped to add scoped service instance factory, the instance is created once and cached in each scope,
+	// that is the container where resolving started, such as the scope created by 'NewScope'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ScopeContainer).AddScoped(TypeOf[UnitOfWork](), func() any {
+	//      return &UnitOfWorkImpl{}
+	//  })
+	AddScoped(serviceType reflect.Type, instanceFactory func() any) error
+
+	// NewScope to create child container as scope, which is tracked by current weakly until it's disposed,
+	// so scope not disposed can still be collected after it's unreachable (since go1.24).
+	// Scope should always be disposed to release scoped instances and stop tracking.
+	//
+	//  scope := container.(ioc.ScopeContainer).NewScope()
+	//  defer scope.(ioc.ScopeContainer).Dispose()
+	NewScope() Container
+
+	// Dispose to dispose live child scopes first, and then instances owned by current that implement 'ioc.Disposable'
+	// by 'ioc.DisposeOrder' and then in reverse initialization order, includes initialized singletons registered in current and scoped instances cached in current.
+	// Singletons of parent are not disposed by child scope. Each instance is disposed once even if 'Dispose' is invoked again,
+	// and scoped instances cached in current are released.
+	Dispose() error
+}
+
+var _ ScopeContainer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddScoped(serviceType reflect.Type, instanceFactory func() any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, InstanceFactory: instanceFactory, Scoped: true})
+}
+
+func (c *defaultContainer) NewScope() Container {
+	scope := &defaultContainer{scopeParent: c}
+	scope.AddSingleton(resolverType, scope)
+	scope.SetParent(c)
+
+	c.locker.Lock()
+	c.trackScope(scope)
+	c.locker.Unlock()
+	return scope
+}
+
+// trackScope to track child scope weakly, and prune the collected ones once count of scopes tracked doubles.
+func (c *defaultContainer) trackScope(scope *defaultContainer) {
+	if c.scopes == nil {
+		c.scopes = make(map[weakRef[defaultContainer]]uint64)
+	}
+	if len(c.scopes) >= 2*c.scopesLive+16 {
+		for ref := range c.scopes {
+			if ref.get() == nil {
+				delete(c.scopes, ref)
+			}
+		}
+		c.scopesLive = len(c.scopes)
+	}
+	c.scopeSeq++
+	c.scopes[makeWeakRef(scope)] = c.scopeSeq
+}
+
+func (c *defaultContainer) Dispose() error {
+	if !atomic.CompareAndSwapInt32(&c.disposed, 0, 1) {
+		return nil
+	}
+	c.locker.Lock()
+	scopes := c.liveScopes()
+	disposables := c.disposables
+	c.scopes = nil
+	c.disposables = nil
+	c.locker.Unlock()
+	c.scopedInstances.Range(func(key, _ any) bool {
+		c.scopedInstances.Delete(key)
+		return true
+	})
+
+	var errs []error
+	// child scopes first, in reverse creation order
+	for i := len(scopes) - 1; i >= 0; i-- {
+		if err := scopes[i].Dispose(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	// then instances owned by current by ascending dispose order, and reverse initialization order for ties
+	for i, j := 0, len(disposables)-1; i < j; i, j = i+1, j-1 {
+		disposables[i], disposables[j] = disposables[j], disposables[i]
+	}
+	sort.SliceStable(disposables, func(i, j int) bool {
+		return disposables[i].order < disposables[j].order
+	})
+	disposed := make(map[disposableIdentity]struct{}, len(disposables))
+	for _, tracked := range disposables {
+		if identity, ok := identityOf(tracked.instance); ok {
+			if _, exists := disposed[identity]; exists {
+				continue
+			}
+			disposed[identity] = struct{}{}
+		}
+		disposable := tracked.instance.Interface().(Disposable)
+		if err := disposable.Dispose(); err != nil {
+			errs = append(errs, fmt.Errorf("dispose service '%v' fail: %w", tracked.instance.Type(), err))
+		}
+	}
+	if parent := c.scopeParent; parent != nil {
+		parent.removeScope(c)
+	}
+	return aggregateErrors(errs)
+}
+
+// disposableIdentity is the identity of instance tracked for disposing, see 'identityOf'.
+type disposableIdentity struct {
+	instanceType reflect.Type
+	pointer      uintptr
+}
+
+// identityOf to get identity of instance by the pointer, returns false if it's not a kind of pointer,
+// which is disposed each time it's tracked. Instance is never used as map key, since it may be unhashable.
+func identityOf(instance reflect.Value) (disposableIdentity, bool) {
+	for instance.Kind() == reflect.Interface && !instance.IsNil() {
+		instance = instance.Elem()
+	}
+	switch instance.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Chan, reflect.UnsafePointer:
+		return disposableIdentity{instanceType: instance.Type(), pointer: instance.Pointer()}, true
+	}
+	return disposableIdentity{}, false
+}
+
+// liveScopes to get child scopes not collected in creation order, must be called with lock held.
+func (c *defaultContainer) liveScopes() []*defaultContainer {
+	type liveScope struct {
+		scope *defaultContainer
+		seq   uint64
+	}
+	live := make([]liveScope, 0, len(c.scopes))
+	for ref, seq := range c.scopes {
+		if scope := ref.get(); scope != nil {
+			live = append(live, liveScope{scope: scope, seq: seq})
+		}
+	}
+	sort.Slice(live, func(i, j int) bool {
+		return live[i].seq < live[j].seq
+	})
+	scopes := make([]*defaultContainer, len(live))
+	for i, s := range live {
+		scopes[i] = s.scope
+	}
+	return scopes
+}
+
+// removeScope to stop tracking the disposed child scope.
+func (c *defaultContainer) removeScope(scope *defaultContainer) {
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	delete(c.scopes, makeWeakRef(scope))
+}
+
+// trackDisposable to track instance owned by current for disposing, if it implements 'ioc.Disposable'.
+func (c *defaultContainer) trackDisposable(instance reflect.Value, order int) {
+	if !instance.IsValid() || !instance.CanInterface() {
+		return
+	}
+	if _, ok := instance.Interface().(Disposable); !ok {
+		return
+	}
+	defer c.locker.Unlock()
+	c.locker.Lock()
+	c.disposables = append(c.disposables, disposableInstance{instance: instance, order: order})
+}
+
+// resolveScoped to get instance of scoped binding cached in current container in the call of 'path',
+// and create it if not exists.
+func (c *defaultContainer) resolveScoped(b *serviceBinding, owner *defaultContainer, path *resolvePath) reflect.Value {
+	if c.valuesOf != nil {
+		// scoped instance is cached, so it's not created with values
+		return c.valuesOf.resolveScoped(b, owner, path)
+	}
+	val, _ := c.scopedInstances.LoadOrStore(b, &scopedInstance{})
+	scoped := val.(*scopedInstance)
+	defer scoped.locker.Unlock()
+	scoped.locker.Lock()
+	if scoped.created {
+		return scoped.instance
+	}
+	if provenance := path.provenanceOf(); provenance != nil {
+		provenance.construct("")
+	}
+	created := false
+	defer func() {
+		if !created && path != nil {
+			// panic during creating, and it's created again on next resolving
+			path.call.fail(append(path.types(), b.ServiceType), false)
+		}
+	}()
+	instance, ok := owner.callFactory(b, c, path)
+	created = true
+	if !ok {
+		// not cache recovered panic of factory
+		return reflect.Value{}
+	}
+	c.trackDisposable(instance, b.DisposeOrder)
+	instance = owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, instance, c))
+	if !instance.IsValid() {
+		// normalize nil returns of factory to zero value of service type
+		instance = reflect.Zero(b.ServiceType)
+	}
+	scoped.instance, scoped.created = instance, true
+	return instance
+}
@@ -0,0 +1,52 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.24
+
+package ioc
+
+import (
+	"runtime"
+	"testing"
+)
+
+func TestScopeCollected(t *testing.T) {
+	t.Run("scope not disposed should be collected after unreachable", func(t *testing.T) {
+		globalContainer = New()
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		func() {
+			scope := globalContainer.(ScopeContainer).NewScope()
+			_ = GetServiceFromC[*disposableService](scope)
+		}()
+		parent := globalContainer.(*defaultContainer)
+		for i := 0; i < 10; i++ {
+			runtime.GC()
+			parent.locker.Lock()
+			live := len(parent.liveScopes())
+			parent.locker.Unlock()
+			if live == 0 {
+				return
+			}
+		}
+		t.Error("scope not disposed should be collected")
+	})
+}
@@ -0,0 +1,213 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestScope(t *testing.T) {
+	t.Run("resolve scoped service should be shared in scope", func(t *testing.T) {
+		globalContainer = New()
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		scope1 := globalContainer.(ScopeContainer).NewScope()
+		scope2 := globalContainer.(ScopeContainer).NewScope()
+		defer scope1.(ScopeContainer).Dispose()
+		defer scope2.(ScopeContainer).Dispose()
+
+		first := GetServiceFromC[*disposableService](scope1)
+		if first == nil || first != GetServiceFromC[*disposableService](scope1) {
+			t.Error("scoped service should be shared in the same scope")
+			return
+		}
+		if first == GetServiceFromC[*disposableService](scope2) {
+			t.Error("scoped service should be different in different scopes")
+			return
+		}
+		if _, lifetime, _ := scope1.(LifetimeResolver).ResolveWithInfo(reflect.TypeOf(first)); lifetime != LifetimeScoped {
+			t.Error("lifetime should be scoped")
+			return
+		}
+	})
+
+	t.Run("dispose scope should only dispose scoped instances once", func(t *testing.T) {
+		globalContainer = New()
+		singleton := &disposableService{}
+		AddSingleton[*disposableService](singleton)
+		AddScoped[service1](func() service1 { return &disposableService{} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		if GetServiceFromC[*disposableService](scope) != singleton {
+			t.Error("singleton should be resolved from parent")
+			return
+		}
+		scoped := GetServiceFromC[service1](scope).(*disposableService)
+
+		if err := scope.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose scope fail: %v", err)
+			return
+		}
+		if err := scope.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose scope again fail: %v", err)
+			return
+		}
+		if scoped.disposed != 1 {
+			t.Error("scoped instance should be disposed exactly once")
+			return
+		}
+		if singleton.disposed != 0 {
+			t.Error("singleton of parent should not be disposed by scope")
+			return
+		}
+		if err := globalContainer.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose parent fail: %v", err)
+			return
+		}
+		if singleton.disposed != 1 || scoped.disposed != 1 {
+			t.Error("singleton should be disposed by it's owner")
+			return
+		}
+	})
+
+	t.Run("dispose parent should dispose live child scopes first", func(t *testing.T) {
+		globalContainer = New()
+		var disposed []string
+		AddSingleton[*disposableService](&disposableService{name: "singleton", log: &disposed})
+		AddScoped[service1](func() service1 { return &disposableService{name: "scoped", log: &disposed} })
+		_ = GetService[*disposableService]()
+		scope := globalContainer.(ScopeContainer).NewScope()
+		child := scope.(ScopeContainer).NewScope()
+		_ = GetServiceFromC[service1](child)
+		_ = GetServiceFromC[service1](scope)
+		ended := globalContainer.(ScopeContainer).NewScope()
+		_ = GetServiceFromC[service1](ended)
+		_ = ended.(ScopeContainer).Dispose()
+
+		disposed = nil
+		if err := globalContainer.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose fail: %v", err)
+			return
+		}
+		if strings.Join(disposed, ",") != "scoped,scoped,singleton" {
+			t.Errorf("child scopes should be disposed before parent, but %v", disposed)
+			return
+		}
+	})
+
+	t.Run("dispose should follow dispose order and then reverse initialization order", func(t *testing.T) {
+		globalContainer = New()
+		var disposed []string
+		serviceType := TypeOf[*disposableService]()
+		Register(&disposableService{name: "db", log: &disposed}, Keyed("db"), DisposeOrder(1))
+		Register(&disposableService{name: "cache", log: &disposed}, Keyed("cache"), DisposeOrder(-1))
+		Register(&disposableService{name: "a", log: &disposed}, Keyed("a"))
+		Register(&disposableService{name: "b", log: &disposed}, Keyed("b"))
+		for _, key := range []string{"db", "cache", "a", "b"} {
+			_ = globalContainer.(KeyedContainer).ResolveKeyed(key, serviceType)
+		}
+
+		if err := globalContainer.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose fail: %v", err)
+			return
+		}
+		if strings.Join(disposed, ",") != "cache,b,a,db" {
+			t.Errorf("lower dispose order should be disposed first, but %v", disposed)
+			return
+		}
+	})
+
+	t.Run("unhashable disposable should be disposed", func(t *testing.T) {
+		globalContainer = New()
+		var disposed []string
+		AddScoped[Disposable](func() Disposable { return unhashableDisposable{log: &disposed, names: []string{"unhashable"}} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		_ = GetServiceFromC[Disposable](scope)
+		if err := scope.(ScopeContainer).Dispose(); err != nil {
+			t.Errorf("dispose fail: %v", err)
+			return
+		}
+		if strings.Join(disposed, ",") != "unhashable" {
+			t.Errorf("unhashable disposable should be disposed, but %v", disposed)
+			return
+		}
+	})
+
+	t.Run("dispose scope should release scoped instances", func(t *testing.T) {
+		globalContainer = New()
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		scoped := GetServiceFromC[*disposableService](scope)
+		_ = scope.(ScopeContainer).Dispose()
+		cached := 0
+		scope.(*defaultContainer).scopedInstances.Range(func(_, _ any) bool {
+			cached++
+			return true
+		})
+		if cached != 0 || scoped.disposed != 1 {
+			t.Error("scoped instances should be released after disposed")
+			return
+		}
+	})
+
+	t.Run("dispose error should be aggregated", func(t *testing.T) {
+		globalContainer = New()
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{err: errors.New("fail")} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		_ = GetServiceFromC[*disposableService](scope)
+		if err := globalContainer.(ScopeContainer).Dispose(); err == nil {
+			t.Error("dispose error should be returned")
+			return
+		}
+	})
+}
+
+type disposableService struct {
+	name     string
+	log      *[]string
+	err      error
+	disposed int
+}
+
+func (s *disposableService) GetName() string {
+	return s.name
+}
+
+// unhashableDisposable is disposable which can't be used as map key.
+type unhashableDisposable struct {
+	log   *[]string
+	names []string
+}
+
+func (s unhashableDisposable) Dispose() error {
+	*s.log = append(*s.log, s.names...)
+	return nil
+}
+
+func (s *disposableService) Dispose() error {
+	s.disposed++
+	if s.log != nil {
+		*s.log = append(*s.log, s.name)
+	}
+	return s.err
+}
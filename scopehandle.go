@@ -0,0 +1,71 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+// Scope is the typed handle of scope created by 'ScopeContainer.NewScope', which should be disposed when it's done.
+// Singletons are resolved from the container creating it, and scoped instances are cached in the scope,
+// which are disposed with it. It's safe to use from multiple goroutines, such as those serving the same request.
+//
+//	scope := ioc.NewScope()
+//	defer scope.Dispose()
+//	uow := ioc.ResolveInScope[UnitOfWork](scope)
+type Scope struct {
+	container Container
+}
+
+// NewScope to create scope of global container.
+func NewScope() Scope {
+	return NewScopeFromC(globalContainer)
+}
+
+// NewScopeFromC to create scope of container.
+//
+// It will panic if 'container' is nil.
+func NewScopeFromC(container Container) Scope {
+	if container == nil {
+		panic("param 'container' is null")
+	}
+	return Scope{container: container.(ScopeContainer).NewScope()}
+}
+
+// Container of the scope, which is nil if scope isn't created by 'NewScope'.
+func (s Scope) Container() Container {
+	return s.container
+}
+
+// Dispose to dispose scoped instances cached in the scope, and stop tracking it by the container creating it.
+func (s Scope) Dispose() error {
+	if s.container == nil {
+		return nil
+	}
+	return s.container.(ScopeContainer).Dispose()
+}
+
+// ResolveInScope to get service 'TService' in scope, the same instance of scoped service is got in the same scope.
+//
+// It will panic if scope isn't created by 'NewScope'.
+func ResolveInScope[TService any](s Scope) TService {
+	if s.container == nil {
+		panic("scope should be created by 'ioc.NewScope'")
+	}
+	return GetServiceFromC[TService](s.container)
+}
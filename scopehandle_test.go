@@ -0,0 +1,108 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestResolveInScope(t *testing.T) {
+	t.Run("scoped service should be shared in scope and differ across scopes", func(t *testing.T) {
+		globalContainer = New()
+		singleton := &serviceInstance1{name: "singleton"}
+		AddSingleton[service1](singleton)
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		scope1, scope2 := NewScope(), NewScope()
+		defer scope1.Dispose()
+		defer scope2.Dispose()
+
+		first := ResolveInScope[*disposableService](scope1)
+		if first == nil || first != ResolveInScope[*disposableService](scope1) {
+			t.Error("scoped service should be shared in the same scope")
+			return
+		}
+		if first == ResolveInScope[*disposableService](scope2) {
+			t.Error("scoped service should differ across scopes")
+			return
+		}
+		if ResolveInScope[service1](scope1) != singleton || ResolveInScope[service1](scope2) != singleton {
+			t.Error("singleton should be resolved from root")
+			return
+		}
+	})
+
+	t.Run("scope should be safe for multiple goroutines", func(t *testing.T) {
+		globalContainer = New()
+		AddScoped[*disposableService](func() *disposableService { return &disposableService{} })
+		scope := NewScope()
+		defer scope.Dispose()
+		instances := make([]*disposableService, 10)
+		var wg sync.WaitGroup
+		for i := range instances {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				instances[i] = ResolveInScope[*disposableService](scope)
+			}(i)
+		}
+		wg.Wait()
+		for _, instance := range instances {
+			if instance != instances[0] {
+				t.Error("scoped service should be shared by goroutines in the same scope")
+				return
+			}
+		}
+	})
+
+	t.Run("dispose should only dispose scope-local instances", func(t *testing.T) {
+		globalContainer = New()
+		singleton := &disposableService{name: "singleton"}
+		AddSingleton[*disposableService](singleton)
+		AddScoped[service1](func() service1 { return &disposableService{name: "scoped"} })
+		scope := NewScope()
+		ResolveInScope[*disposableService](scope)
+		scoped := ResolveInScope[service1](scope).(*disposableService)
+		if err := scope.Dispose(); err != nil {
+			t.Error(err)
+			return
+		}
+		if scoped.disposed != 1 || singleton.disposed != 0 {
+			t.Error("dispose should only dispose scope-local instances")
+			return
+		}
+	})
+
+	t.Run("zero scope should panic on resolving", func(t *testing.T) {
+		var scope Scope
+		if scope.Dispose() != nil || scope.Container() != nil {
+			t.Error("zero scope should do nothing on disposing")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("zero scope should panic on resolving")
+			}
+		}()
+		ResolveInScope[service1](scope)
+	})
+}
@@ -0,0 +1,95 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync/atomic"
+)
+
+// sealUsed is 1 if any container has sealed singletons, so injecting doesn't walk containers for sealed instances if unused.
+var sealUsed int32
+
+// sealInstances to seal singletons initialized by current container, the ones initialized already and after sealing.
+func (c *defaultContainer) sealInstances() {
+	atomic.StoreInt32(&sealUsed, 1)
+	if !atomic.CompareAndSwapInt32(&c.seal, 0, 1) {
+		return
+	}
+	track := func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		binding.initializerLocker.Lock()
+		if binding.state == bindingInitialized && binding.ServiceType != resolverType {
+			c.trackInitialized(binding.injected)
+		}
+		binding.initializerLocker.Unlock()
+		return true
+	}
+	c.bindings.Range(track)
+	c.keyedBindings.Range(track)
+	var bindings []*serviceBinding
+	c.locker.Lock()
+	for _, profiled := range c.profiled {
+		bindings = append(bindings, profiled...)
+	}
+	for _, group := range c.groups {
+		bindings = append(bindings, group...)
+	}
+	c.locker.Unlock()
+	for _, binding := range bindings {
+		track(nil, binding)
+	}
+}
+
+// trackInitialized to track singleton instance initialized by current container after sealing, see 'Seal'.
+func (c *defaultContainer) trackInitialized(instance reflect.Value) {
+	if atomic.LoadInt32(&c.seal) == 1 && instance.Kind() == reflect.Pointer && instance.CanInterface() {
+		c.initialized.Store(instance.Interface(), struct{}{})
+	}
+}
+
+// untrackInitialized to stop tracking instance dropped by 'Invalidate', in current container and ancestors.
+func (c *defaultContainer) untrackInitialized(instance reflect.Value) {
+	if instance.Kind() != reflect.Pointer || !instance.CanInterface() {
+		return
+	}
+	for current := c; current != nil; current, _ = current.Parent().(*defaultContainer) {
+		current.initialized.Delete(instance.Interface())
+	}
+}
+
+// isSealed to check whether instance is sealed by container or it's ancestors.
+func isSealed(container Container, instance reflect.Value) bool {
+	if atomic.LoadInt32(&sealUsed) == 0 || !instance.CanInterface() {
+		return false
+	}
+	c, _ := container.(*defaultContainer)
+	for c != nil {
+		if atomic.LoadInt32(&c.seal) == 1 {
+			if _, ok := c.initialized.Load(instance.Interface()); ok {
+				return true
+			}
+		}
+		c, _ = c.Parent().(*defaultContainer)
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+type sealedClient struct {
+	S1 service1 `ioc-inject:"true"`
+}
+
+func TestSealInstances(t *testing.T) {
+	t.Run("singleton should be injected once even if resolved concurrently", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(Sealer).Seal()
+		var injected int32
+		AddTransient[service1](func() service1 {
+			atomic.AddInt32(&injected, 1)
+			return &serviceInstance1{}
+		})
+		client := &sealedClient{}
+		AddSingleton[*sealedClient](client)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_ = GetService[*sealedClient]()
+			}()
+		}
+		wg.Wait()
+		_ = GetService[*sealedClient]()
+		if atomic.LoadInt32(&injected) != 1 {
+			t.Errorf("singleton should be injected once, but %d", injected)
+			return
+		}
+	})
+
+	t.Run("sealed singleton should not be injected again", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		client := &sealedClient{}
+		AddSingleton[*sealedClient](client)
+		injected := GetService[*sealedClient]().S1
+		globalContainer.(Sealer).Seal()
+		if injected == nil {
+			t.Error("singleton should be injected when initializing")
+			return
+		}
+
+		Inject(client)
+		InjectFromC(globalContainer.(ScopeContainer).NewScope(), client)
+		if client.S1 != injected {
+			t.Error("sealed singleton should not be injected again")
+			return
+		}
+
+		Invalidate[*sealedClient]()
+		if initialized := GetService[*sealedClient](); initialized == client || initialized.S1 == injected || client.S1 != injected {
+			t.Error("invalidated singleton should be initialized again as a new instance")
+			return
+		}
+	})
+
+	t.Run("singletons should be tracked only after sealing and until invalidated", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		AddSingleton[*sealedClient](&sealedClient{})
+		_ = GetService[*sealedClient]()
+		tracked := func() int {
+			count := 0
+			globalContainer.(*defaultContainer).initialized.Range(func(key, val any) bool {
+				count++
+				return true
+			})
+			return count
+		}
+		if count := tracked(); count != 0 {
+			t.Errorf("singletons should not be tracked before sealing, but %d", count)
+			return
+		}
+		globalContainer.(Sealer).Seal()
+		for i := 0; i < 3; i++ {
+			Invalidate[*sealedClient]()
+			_ = GetService[*sealedClient]()
+		}
+		if count := tracked(); count != 1 {
+			t.Errorf("only the singleton initialized last should be tracked, but %d", count)
+			return
+		}
+	})
+
+	t.Run("singleton should be injected again if it's not sealed", func(t *testing.T) {
+		globalContainer = New()
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		client := &sealedClient{}
+		AddSingleton[*sealedClient](client)
+		injected := GetService[*sealedClient]().S1
+		Inject(client)
+		if client.S1 == injected {
+			t.Error("singleton not sealed should be injected again")
+			return
+		}
+	})
+}
@@ -0,0 +1,99 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// sealedBinding is binding in the snapshot of 'Sealer.Seal', with the container it belongs to.
+type sealedBinding struct {
+	binding *serviceBinding
+	owner   *defaultContainer
+}
+
+// sealedResolver is the read-only snapshot of container, see 'Sealer.Seal'.
+type sealedResolver struct {
+	container *defaultContainer
+	bindings  map[reflect.Type]sealedBinding // never changed after sealing, so it's read without lock
+	parent    Resolver                       // the first ancestor which is not created by 'ioc.New'
+}
+
+// Sealer is implemented by container to get read-only resolver with snapshot of services.
+type Sealer interface {
+	// Seal to get a read-only resolver with the snapshot of services in current container and it's parent chain,
+	// which is backed by a plain map for the fastest resolving, after all services are added at startup.
+	// Current container is still mutable, but services added or replaced after sealing don't appear in the snapshot.
+	// Resolving with the snapshot is the same as with current container, except that strict mode is ignored,
+	// and features and profiles are applied as they are when sealing.
+	// Singletons initialized by current container, before or after sealing, are sealed too: injecting with current container
+	// or it's children to them is ignored, so fields injected won't be overwritten.
+	//
+	//  resolver := container.(ioc.Sealer).Seal()
+	//  svc := resolver.Resolve(reflect.TypeOf((*Service1)(nil)).Elem())
+	Seal() Resolver
+}
+
+var _ Sealer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Seal() Resolver {
+	c.sealInstances()
+	sealed := &sealedResolver{container: c, bindings: make(map[reflect.Type]sealedBinding)}
+	for current := c; current != nil; {
+		current.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+			if _, shadowed := sealed.bindings[binding.ServiceType]; shadowed || binding.NotInherited && current != c {
+				return true
+			}
+			if current != c && len(c.overrides) > 0 {
+				if overriding := c.getOverridingBinding(binding.ServiceType); overriding != nil {
+					sealed.bindings[binding.ServiceType] = sealedBinding{overriding, c}
+					return true
+				}
+			}
+			sealed.bindings[binding.ServiceType] = sealedBinding{binding, current}
+			return true
+		})
+		parent := current.Parent()
+		if current, _ = parent.(*defaultContainer); current == nil {
+			sealed.parent = parent
+		}
+	}
+	return sealed
+}
+
+func (s *sealedResolver) SetParent(parent Resolver) {
+	panic(errors.New("sealed resolver is read-only, it can't have parent"))
+}
+
+func (s *sealedResolver) Resolve(serviceType reflect.Type) reflect.Value {
+	if sealed, ok := s.bindings[serviceType]; ok {
+		return sealed.binding.resolve(sealed.owner, s.container, nil)
+	}
+	if val, ok := s.container.matchResolver(serviceType); ok {
+		return val
+	}
+	if s.parent != nil {
+		return s.parent.Resolve(serviceType)
+	}
+	return reflect.Value{}
+}
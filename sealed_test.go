@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestSeal(t *testing.T) {
+	t.Run("sealed resolver should resolve the snapshot", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "parent"})
+		Register(&serviceInstance3{name: "not inherited"}, NotInherited())
+		child := globalContainer.(ScopeContainer).NewScope()
+		AddSingletonToC[service2](child, &serviceInstance2{name: "child"})
+		AddTransientToC[*serviceInstance1](child, func() *serviceInstance1 { return &serviceInstance1{} })
+
+		sealed := child.(Sealer).Seal()
+		if val := sealed.Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface() != GetServiceFromC[service1](child) {
+			t.Error("service of parent should be resolved the same as container")
+			return
+		}
+		if val := sealed.Resolve(TypeOf[service2]()); !val.IsValid() || val.Interface() != GetServiceFromC[service2](child) {
+			t.Error("service of current should be resolved the same as container")
+			return
+		}
+		if first := sealed.Resolve(TypeOf[*serviceInstance1]()); !first.IsValid() || first.Interface() == sealed.Resolve(TypeOf[*serviceInstance1]()).Interface() {
+			t.Error("transient should be created for each resolving")
+			return
+		}
+		if val := sealed.Resolve(TypeOf[Resolver]()); !val.IsValid() || val.Interface() != child {
+			t.Error("resolver should be the sealed container")
+			return
+		}
+		if sealed.Resolve(TypeOf[*serviceInstance3]()).IsValid() {
+			t.Error("not inherited service of parent should not be resolved")
+			return
+		}
+
+		AddSingletonToC[*serviceInstance4](child, &serviceInstance4{})
+		if sealed.Resolve(TypeOf[*serviceInstance4]()).IsValid() {
+			t.Error("service added after sealing should not appear")
+			return
+		}
+	})
+
+	t.Run("sealed resolver should be read-only", func(t *testing.T) {
+		globalContainer = New()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("setting parent of sealed resolver should panic")
+			}
+		}()
+		globalContainer.(Sealer).Seal().SetParent(New())
+	})
+}
@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// AddFactorySelector to add service which is resolved as the keyed service selected by 'selector' for each resolving.
+//
+// It will panic if 'TService' or 'selector' is invalid.
+func AddFactorySelector[TService any](selector func(resolver Resolver) string) {
+	AddFactorySelectorToC[TService](globalContainer, selector)
+}
+
+// AddFactorySelectorToC to add service which is resolved as the keyed service selected by 'selector' for each resolving.
+//
+// It will panic if 'TService' or 'selector' is invalid.
+func AddFactorySelectorToC[TService any](container Container, selector func(resolver Resolver) string) {
+	if err := container.(SelectorAdder).AddFactorySelector(TypeOf[TService](), selector); err != nil {
+		panic(err)
+	}
+}
+
+// SelectorAdder is implemented by container to add service resolved as keyed service selected at runtime.
+type SelectorAdder interface {
+	// AddFactorySelector to add service which is resolved as the keyed service selected by 'selector' for each resolving.
+	// It's resolved as invalid value (not found) if the selected key is not registered in current container or parents.
+	//
+	//  err := container.(ioc.KeyedContainer).AddKeyedSingleton("email", TypeOf[NotificationSender](), &EmailSender{})
+	//  err = container.(ioc.KeyedContainer).AddKeyedSingleton("sms", TypeOf[NotificationSender](), &SmsSender{})
+	//  err = container.(ioc.SelectorAdder).AddFactorySelector(TypeOf[NotificationSender](), func(resolver ioc.Resolver) string {
+	//      return resolver.Resolve(TypeOf[Config]()).Interface().(Config).Channel()
+	//  })
+	AddFactorySelector(serviceType reflect.Type, selector func(resolver Resolver) string) error
+}
+
+var _ SelectorAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddFactorySelector(serviceType reflect.Type, selector func(resolver Resolver) string) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if selector == nil {
+		return errors.New("param 'selector' is null")
+	}
+	return c.addBinding(&serviceBinding{ServiceType: serviceType, Selector: selector})
+}
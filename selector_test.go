@@ -0,0 +1,94 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddFactorySelector(t *testing.T) {
+	t.Run("resolve selected keyed service should success", func(t *testing.T) {
+		globalContainer = New()
+		config := &selectorConfig{channel: "email"}
+		AddSingleton[*selectorConfig](config)
+		senderType := reflect.TypeOf((*notificationSender)(nil)).Elem()
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton("email", senderType, &emailSender{})
+		_ = globalContainer.(KeyedContainer).AddKeyedTransient("sms", senderType, func() any { return &smsSender{} })
+		AddFactorySelector[notificationSender](func(resolver Resolver) string {
+			return resolver.Resolve(reflect.TypeOf((*selectorConfig)(nil))).Interface().(*selectorConfig).channel
+		})
+
+		if sender := GetService[notificationSender](); sender == nil || sender.Channel() != "email" {
+			t.Error("keyed service 'email' should be selected")
+			return
+		}
+		config.channel = "sms"
+		if sender := GetService[notificationSender](); sender == nil || sender.Channel() != "sms" {
+			t.Error("keyed service 'sms' should be selected")
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(senderType); lifetime != LifetimeTransient {
+			t.Error("lifetime of selector should be transient")
+			return
+		}
+		config.channel = "push"
+		if globalContainer.Resolve(senderType).IsValid() {
+			t.Error("unregistered key should be resolved as invalid value")
+			return
+		}
+	})
+
+	t.Run("invalid selector should fail", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(SelectorAdder).AddFactorySelector(nil, func(resolver Resolver) string { return "" }); err == nil {
+			t.Error("null service type should fail")
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("null selector should fail")
+			}
+		}()
+		AddFactorySelector[notificationSender](nil)
+	})
+}
+
+type selectorConfig struct {
+	channel string
+}
+
+type notificationSender interface {
+	Channel() string
+}
+
+type emailSender struct{}
+
+func (s *emailSender) Channel() string {
+	return "email"
+}
+
+type smsSender struct{}
+
+func (s *smsSender) Channel() string {
+	return "sms"
+}
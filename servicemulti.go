@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+)
+
+// GetServiceMulti to get service from global container, with other registered interfaces it's dynamic type implements,
+// for detecting extra capabilities of the instance.
+//
+//	store, capabilities := ioc.GetServiceMulti[Store]()
+//	for _, capability := range capabilities {
+//	    if capability == reflect.TypeOf((*Flushable)(nil)).Elem() {
+//	        store.(Flushable).Flush()
+//	    }
+//	}
+func GetServiceMulti[TService any]() (TService, []reflect.Type) {
+	return GetServiceMultiFromC[TService](globalContainer)
+}
+
+// GetServiceMultiFromC to get service from container, with other interfaces it's dynamic type implements.
+// Interfaces are the ones registered as service with or without key in container and it's ancestors,
+// except 'TService' and 'ioc.Resolver', and sorted by name. They are empty if service not found.
+func GetServiceMultiFromC[TService any](container Container) (TService, []reflect.Type) {
+	instance := GetServiceFromC[TService](container)
+	instanceVal := reflect.ValueOf(instance)
+	if !instanceVal.IsValid() {
+		return instance, nil
+	}
+	c, _ := container.(*defaultContainer)
+	if c == nil {
+		return instance, nil
+	}
+	serviceType := TypeOf[TService]()
+	var implemented []reflect.Type
+	for _, interfaceType := range c.knownInterfaces() {
+		if interfaceType != serviceType && instanceVal.Type().Implements(interfaceType) {
+			implemented = append(implemented, interfaceType)
+		}
+	}
+	return instance, implemented
+}
+
+// knownInterfaces to get interfaces registered as service in current container and it's ancestors, sorted by name.
+func (c *defaultContainer) knownInterfaces() []reflect.Type {
+	known := make(map[reflect.Type]struct{})
+	collect := func(serviceType reflect.Type) {
+		if serviceType.Kind() == reflect.Interface && serviceType != resolverType {
+			known[serviceType] = struct{}{}
+		}
+	}
+	for current := c; current != nil; {
+		current.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+			collect(binding.ServiceType)
+			return true
+		})
+		current.keyedBindings.Range(func(key, val any) bool {
+			collect(key.(bindingKey).ServiceType)
+			return true
+		})
+		current, _ = current.Parent().(*defaultContainer)
+	}
+	interfaces := make([]reflect.Type, 0, len(known))
+	for interfaceType := range known {
+		interfaces = append(interfaces, interfaceType)
+	}
+	sort.Slice(interfaces, func(i, j int) bool {
+		return interfaces[i].String() < interfaces[j].String()
+	})
+	return interfaces
+}
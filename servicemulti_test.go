@@ -0,0 +1,63 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetServiceMulti(t *testing.T) {
+	t.Run("registered interfaces implemented should be returned", func(t *testing.T) {
+		globalContainer = New()
+		svc2 := &serviceInstance2{name: "instance2"}
+		AddSingleton[service2](svc2)
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		child := globalContainer.(ScopeContainer).NewScope()
+		_ = child.(KeyedContainer).AddKeyedSingleton("a", TypeOf[service3](), &serviceInstance3{})
+		AddSingletonToC[Stoppable](child, &runnableInstance{})
+
+		svc, capabilities := GetServiceMultiFromC[service2](child)
+		if svc != svc2 {
+			t.Error("service should be resolved")
+			return
+		}
+		expected := []reflect.Type{TypeOf[service1](), TypeOf[service3]()}
+		if !reflect.DeepEqual(capabilities, expected) {
+			t.Errorf("capabilities should be %v, but %v", expected, capabilities)
+			return
+		}
+		if _, capabilities = GetServiceMulti[service2](); !reflect.DeepEqual(capabilities, expected[:1]) {
+			t.Errorf("interfaces of child should not be known by parent, but %v", capabilities)
+			return
+		}
+	})
+
+	t.Run("service not found should return nothing", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{})
+		if svc, capabilities := GetServiceMulti[service2](); svc != nil || capabilities != nil {
+			t.Error("nothing should be returned if not found")
+			return
+		}
+	})
+}
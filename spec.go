@@ -0,0 +1,182 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// RegistrationSpec is the registration metadata of a service, see 'RegistrationPorter.ExportRegistrations'.
+type RegistrationSpec struct {
+	ServiceType  reflect.Type
+	Lifetime     Lifetime
+	Key          any // key of keyed service
+	HasKey       bool
+	Group        string        // group name if it's added to group
+	Order        int           // order in group and 'ResolveAll'
+	DisposeOrder int           // order of disposing
+	NotInherited bool          // not resolved by child containers
+	Primary      bool          // the primary one resolved without key
+	AliasOf      reflect.Type  // service resolved instead, for alias and interfaces added by 'AutoRegister'
+	CacheFor     time.Duration // duration of caching instance of transient, see 'CacheFor'
+	Weak         bool          // singleton held by weak reference, see 'Weak'
+}
+
+// RegistrationPorter is implemented by container to export registration metadata and import it.
+type RegistrationPorter interface {
+	// ExportRegistrations to get registration metadata in current container in registration order, without live instances.
+	// Services added by 'AddFactorySelector' and config values are not exported.
+	ExportRegistrations() []RegistrationSpec
+
+	// ImportRegistrations to rebuild registrations of specs exported by 'ExportRegistrations' in current container,
+	// with factories keyed by service type. Singletons are created by factories when importing, others when resolving.
+	// It continues on error, and returns the aggregated errors.
+	//
+	//  specs := source.(ioc.RegistrationPorter).ExportRegistrations()
+	//  err := target.(ioc.RegistrationPorter).ImportRegistrations(specs, map[reflect.Type]func() any{
+	//      reflect.TypeOf((*Service1)(nil)).Elem(): func() any { return &ServiceInstance1{} },
+	//  })
+	ImportRegistrations(specs []RegistrationSpec, factories map[reflect.Type]func() any) error
+}
+
+var _ RegistrationPorter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) ExportRegistrations() []RegistrationSpec {
+	type exported struct {
+		binding *serviceBinding
+		spec    RegistrationSpec
+	}
+	var bindings []exported
+	keyed := make(map[*serviceBinding]bool)
+	c.keyedBindings.Range(func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		keyed[binding] = true
+		bindings = append(bindings, exported{binding, RegistrationSpec{Key: key.(bindingKey).Key, HasKey: true}})
+		return true
+	})
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		if binding.ServiceType != resolverType && !keyed[binding] {
+			bindings = append(bindings, exported{binding: binding})
+		}
+		return true
+	})
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].binding.Seq < bindings[j].binding.Seq
+	})
+
+	c.locker.Lock()
+	groupNames := make([]string, 0, len(c.groups))
+	for groupName := range c.groups {
+		groupNames = append(groupNames, groupName)
+	}
+	sort.Strings(groupNames)
+	for _, groupName := range groupNames {
+		for _, binding := range c.groups[groupName] {
+			bindings = append(bindings, exported{binding, RegistrationSpec{Group: groupName}})
+		}
+	}
+	c.locker.Unlock()
+
+	specs := make([]RegistrationSpec, 0, len(bindings))
+	for _, item := range bindings {
+		binding, spec := item.binding, item.spec
+		if binding.Selector != nil {
+			continue
+		}
+		spec.ServiceType = binding.ServiceType
+		spec.Lifetime = binding.lifetime()
+		spec.Order = binding.Order
+		spec.DisposeOrder = binding.DisposeOrder
+		spec.NotInherited = binding.NotInherited
+		spec.Primary = binding.Primary
+		if binding.TTL != nil {
+			spec.CacheFor = binding.TTL.ttl
+		}
+		spec.Weak = binding.Weak != nil
+		if binding.Target != nil {
+			spec.AliasOf = binding.Target.ServiceType
+		} else {
+			spec.AliasOf = binding.AliasOf
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func (c *defaultContainer) ImportRegistrations(specs []RegistrationSpec, factories map[reflect.Type]func() any) error {
+	var errs []error
+	for i, spec := range specs {
+		if err := c.importRegistration(spec, factories); err != nil {
+			errs = append(errs, fmt.Errorf("spec[%d] '%v': %w", i, spec.ServiceType, err))
+		}
+	}
+	return aggregateErrors(errs)
+}
+
+// importRegistration to add registration of spec with factory of it's service type.
+func (c *defaultContainer) importRegistration(spec RegistrationSpec, factories map[reflect.Type]func() any) error {
+	if spec.ServiceType == nil {
+		return errors.New("service type is null")
+	}
+	if spec.AliasOf != nil {
+		return c.Alias(spec.ServiceType, spec.AliasOf)
+	}
+	factory := factories[spec.ServiceType]
+	if factory == nil {
+		return errors.New("factory not found")
+	}
+
+	var binding *serviceBinding
+	switch {
+	case spec.Weak:
+		binding = &serviceBinding{ServiceType: spec.ServiceType, InstanceFactory: factory, Lazy: true, Weak: &weakCache{}}
+	case spec.Lifetime == LifetimeSingleton:
+		instance := factory()
+		if err := checkInstance(instance); err != nil {
+			return err
+		}
+		var err error
+		if binding, err = newSingletonBinding(spec.ServiceType, instance); err != nil {
+			return err
+		}
+	case spec.Lifetime == LifetimeTransient:
+		binding = &serviceBinding{ServiceType: spec.ServiceType, InstanceFactory: factory}
+		if spec.CacheFor > 0 {
+			binding.TTL = &ttlCache{ttl: spec.CacheFor}
+		}
+	case spec.Lifetime == LifetimeScoped:
+		binding = &serviceBinding{ServiceType: spec.ServiceType, InstanceFactory: factory, Scoped: true}
+	case spec.Lifetime == LifetimeGoroutine:
+		binding = &serviceBinding{ServiceType: spec.ServiceType, InstanceFactory: factory, GoroutineScoped: true}
+	default:
+		return fmt.Errorf("lifetime '%v' can't be imported", spec.Lifetime)
+	}
+	binding.Order = spec.Order
+	binding.DisposeOrder = spec.DisposeOrder
+	binding.NotInherited = spec.NotInherited
+	reg := &registration{Key: spec.Key, HasKey: spec.HasKey, Group: spec.Group, Primary: spec.Primary}
+	return c.addRegistration(reg, binding)
+}
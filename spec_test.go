@@ -0,0 +1,93 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExportRegistrations(t *testing.T) {
+	t.Run("shape of container should be round-tripped", func(t *testing.T) {
+		source := New()
+		globalContainer = source
+		Register(&serviceInstance1{name: "default"}, As(TypeOf[service1]()), Order(2), DisposeOrder(-1))
+		Register(nil, As(TypeOf[service2]()), Keyed("k"), Transient(func() any { return &serviceInstance2{} }))
+		Register(&serviceInstance2{name: "primary"}, As(TypeOf[service2]()), Keyed("p"), AsPrimary())
+		AddScoped[*serviceInstance3](func() *serviceInstance3 { return &serviceInstance3{} })
+		Register(&serviceInstance4{name: "grouped"}, InGroup("g"), NotInherited())
+		Alias[service5, service1]()
+
+		specs := source.(RegistrationPorter).ExportRegistrations()
+		if len(specs) != 6 {
+			t.Errorf("all registrations should be exported, but %d", len(specs))
+			return
+		}
+		if specs[0].ServiceType != TypeOf[service1]() || specs[0].Lifetime != LifetimeSingleton || specs[0].Order != 2 || specs[0].DisposeOrder != -1 {
+			t.Errorf("metadata should be exported in registration order, but %+v", specs[0])
+			return
+		}
+		if specs[5].Group != "g" || specs[4].AliasOf != TypeOf[service1]() {
+			t.Errorf("group and alias should be exported, but %+v, %+v", specs[5], specs[4])
+			return
+		}
+
+		target := New()
+		err := target.(RegistrationPorter).ImportRegistrations(specs, map[reflect.Type]func() any{
+			TypeOf[service1]():          func() any { return &serviceInstance1{name: "imported"} },
+			TypeOf[service2]():          func() any { return &serviceInstance2{name: "imported"} },
+			TypeOf[*serviceInstance3](): func() any { return &serviceInstance3{} },
+			TypeOf[*serviceInstance4](): func() any { return &serviceInstance4{} },
+		})
+		if err != nil {
+			t.Errorf("import fail: %v", err)
+			return
+		}
+		if imported := target.(RegistrationPorter).ExportRegistrations(); !reflect.DeepEqual(imported, specs) {
+			t.Errorf("imported shape should be the same, but %+v", imported)
+			return
+		}
+		if svc := GetServiceFromC[service5](target); svc == nil || svc.GetName() != "imported" {
+			t.Error("imported services should be resolved")
+			return
+		}
+		if len(target.(Grouper).ResolveGroup("g")) != 1 || !target.(KeyedContainer).ResolveKeyed("k", TypeOf[service2]()).IsValid() {
+			t.Error("imported group and keyed services should be resolved")
+			return
+		}
+	})
+
+	t.Run("spec without factory should fail", func(t *testing.T) {
+		globalContainer = New()
+		specs := []RegistrationSpec{
+			{ServiceType: TypeOf[service1](), Lifetime: LifetimeTransient},
+			{ServiceType: TypeOf[service2](), Lifetime: LifetimeTransient},
+		}
+		err := globalContainer.(RegistrationPorter).ImportRegistrations(specs, map[reflect.Type]func() any{
+			TypeOf[service1](): func() any { return &serviceInstance1{} },
+		})
+		if err == nil || GetService[service1]() == nil {
+			t.Error("spec without factory should fail, and others should be imported")
+			return
+		}
+	})
+}
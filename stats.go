@@ -0,0 +1,86 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+	"time"
+)
+
+// TypeTiming is the duration of initializing singleton of service type, see 'StatsRecorder.SlowestInitializers'.
+type TypeTiming struct {
+	ServiceType reflect.Type
+	Duration    time.Duration
+}
+
+// StatsRecorder is implemented by container to collect statistics of resolving.
+type StatsRecorder interface {
+	// SetStatsEnabled to collect statistics of resolving without publishing them, default is disabled.
+	// Disabling stops collecting, and statistics collected are discarded.
+	SetStatsEnabled(enabled bool)
+
+	// SlowestInitializers to get top 'n' singletons in current container by duration of the last initializing,
+	// which is injecting and invoking initializer on first resolving, slowest first. It returns all if 'n' is not positive,
+	// and nil if statistics are not collected, see 'SetStatsEnabled'.
+	//
+	//  for _, timing := range container.(ioc.StatsRecorder).SlowestInitializers(5) {
+	//      log.Printf("'%v' initialized in %v", timing.ServiceType, timing.Duration)
+	//  }
+	SlowestInitializers(n int) []TypeTiming
+}
+
+var _ StatsRecorder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) SetStatsEnabled(enabled bool) {
+	if !enabled {
+		c.stats.Store((*resolveStats)(nil))
+		return
+	}
+	expvarLocker.Lock()
+	if c.loadStats() == nil {
+		c.stats.Store(&resolveStats{})
+	}
+	expvarLocker.Unlock()
+}
+
+func (c *defaultContainer) SlowestInitializers(n int) []TypeTiming {
+	stats := c.loadStats()
+	if stats == nil {
+		return nil
+	}
+	var timings []TypeTiming
+	stats.initDurations.Range(func(key, val any) bool {
+		timings = append(timings, TypeTiming{ServiceType: key.(reflect.Type), Duration: val.(time.Duration)})
+		return true
+	})
+	sort.Slice(timings, func(i, j int) bool {
+		if timings[i].Duration != timings[j].Duration {
+			return timings[i].Duration > timings[j].Duration
+		}
+		return timings[i].ServiceType.String() < timings[j].ServiceType.String()
+	})
+	if n > 0 && len(timings) > n {
+		timings = timings[:n]
+	}
+	return timings
+}
@@ -0,0 +1,75 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+	"time"
+)
+
+type slowInitializer struct {
+	delay time.Duration
+}
+
+func (s *slowInitializer) Initialize() {
+	time.Sleep(s.delay)
+}
+
+func TestSlowestInitializers(t *testing.T) {
+	t.Run("slow initializer should be the slowest", func(t *testing.T) {
+		globalContainer = New()
+		globalContainer.(StatsRecorder).SetStatsEnabled(true)
+		AddSingleton[*slowInitializer](&slowInitializer{delay: 20 * time.Millisecond})
+		AddSingleton[service1](&serviceInstance1{name: "fast"})
+		GetService[*slowInitializer]()
+		GetService[service1]()
+
+		timings := globalContainer.(StatsRecorder).SlowestInitializers(1)
+		if len(timings) != 1 || timings[0].ServiceType != TypeOf[*slowInitializer]() || timings[0].Duration < 20*time.Millisecond {
+			t.Errorf("slow initializer should be the slowest, but %v", timings)
+			return
+		}
+		if timings = globalContainer.(StatsRecorder).SlowestInitializers(0); len(timings) != 2 || timings[1].ServiceType != TypeOf[service1]() {
+			t.Errorf("all initializers should be reported if n is not positive, but %v", timings)
+			return
+		}
+	})
+
+	t.Run("nothing should be reported if stats are disabled", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*slowInitializer](&slowInitializer{})
+		GetService[*slowInitializer]()
+		if timings := globalContainer.(StatsRecorder).SlowestInitializers(1); timings != nil {
+			t.Errorf("stats are disabled by default, but %v", timings)
+			return
+		}
+
+		globalContainer.(StatsRecorder).SetStatsEnabled(true)
+		globalContainer.(StatsRecorder).SetStatsEnabled(false)
+		AddSingleton[service1](&serviceInstance1{})
+		GetService[service1]()
+		if timings := globalContainer.(StatsRecorder).SlowestInitializers(1); timings != nil {
+			t.Errorf("stats should not be collected after disabled, but %v", timings)
+			return
+		}
+	})
+}
@@ -0,0 +1,105 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// Swapper is the handle to swap instance of singleton at runtime, see 'SwappableAdder.AddSwappable'.
+type Swapper interface {
+	// Swap to replace the instance resolved, it returns error if 'newInstance' is null or can't be assigned to the service.
+	Swap(newInstance any) error
+	// Load to get the current instance.
+	Load() any
+}
+
+// swappable is the instance of singleton which can be swapped at runtime.
+type swappable struct {
+	serviceType reflect.Type
+	value       atomic.Value // reflect.Value
+}
+
+// AddSwappable to add singleton service to global container, whose instance can be swapped at runtime, see 'SwappableAdder.AddSwappable'.
+//
+// It will panic if 'TService' or 'initial' is invalid.
+func AddSwappable[TService any](initial TService) Swapper {
+	return AddSwappableToC[TService](globalContainer, initial)
+}
+
+// AddSwappableToC to add singleton service to container, whose instance can be swapped at runtime, see 'SwappableAdder.AddSwappable'.
+//
+// It will panic if 'TService' or 'initial' is invalid.
+func AddSwappableToC[TService any](container Container, initial TService) Swapper {
+	swapper, err := container.(SwappableAdder).AddSwappable(TypeOf[TService](), initial)
+	if err != nil {
+		panic(err)
+	}
+	return swapper
+}
+
+// SwappableAdder is implemented by container to add singleton which can be swapped at runtime.
+type SwappableAdder interface {
+	// AddSwappable to add singleton service whose instance can be swapped at runtime by the returned 'Swapper',
+	// such as feature flag provider reloaded from remote, and resolving always gets the current instance atomically.
+	// Consumers holding the instance resolved before swapping keep the old one, so it helps those resolving for each use,
+	// such as by field of 'func() T'. The instance is resolved as is, without injecting, initializer or decorators.
+	//
+	//  swapper, err := container.(ioc.SwappableAdder).AddSwappable(reflect.TypeOf((*Flags)(nil)).Elem(), loadFlags())
+	//  err = swapper.Swap(reloadFlags())
+	AddSwappable(serviceType reflect.Type, initial any) (Swapper, error)
+}
+
+var _ SwappableAdder = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddSwappable(serviceType reflect.Type, initial any) (Swapper, error) {
+	if serviceType == nil {
+		return nil, errors.New("param 'serviceType' is null")
+	}
+	swapper := &swappable{serviceType: serviceType}
+	if err := swapper.Swap(initial); err != nil {
+		return nil, err
+	}
+	if err := c.addBinding(&serviceBinding{ServiceType: serviceType, Swappable: swapper}); err != nil {
+		return nil, err
+	}
+	return swapper, nil
+}
+
+func (s *swappable) Swap(newInstance any) error {
+	if err := checkInstance(newInstance); err != nil {
+		return err
+	}
+	instance := reflect.ValueOf(newInstance)
+	if !instance.Type().AssignableTo(s.serviceType) {
+		return fmt.Errorf("instance should implement the service '%v'", s.serviceType)
+	}
+	s.value.Store(instance)
+	return nil
+}
+
+func (s *swappable) Load() any {
+	return s.value.Load().(reflect.Value).Interface()
+}
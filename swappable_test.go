@@ -0,0 +1,92 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAddSwappable(t *testing.T) {
+	t.Run("resolving should get the instance swapped", func(t *testing.T) {
+		globalContainer = New()
+		swapper := AddSwappable[service1](&serviceInstance1{name: "v1"})
+		held := GetService[service1]()
+		if held.GetName() != "v1" || swapper.Load().(service1).GetName() != "v1" {
+			t.Error("initial instance should be resolved")
+			return
+		}
+		if err := swapper.Swap(&serviceInstance1{name: "v2"}); err != nil {
+			t.Error(err)
+			return
+		}
+		if GetService[service1]().GetName() != "v2" || swapper.Load().(service1).GetName() != "v2" {
+			t.Error("instance swapped should be resolved")
+			return
+		}
+		if held.GetName() != "v1" {
+			t.Error("instance held before swapping should not change")
+			return
+		}
+		if _, lifetime, _ := globalContainer.(LifetimeResolver).ResolveWithInfo(TypeOf[service1]()); lifetime != LifetimeSingleton {
+			t.Errorf("swappable should be singleton, but '%v'", lifetime)
+			return
+		}
+	})
+
+	t.Run("invalid instance should be rejected", func(t *testing.T) {
+		globalContainer = New()
+		if _, err := globalContainer.(SwappableAdder).AddSwappable(TypeOf[service1](), nil); err == nil {
+			t.Error("null initial instance should be rejected")
+			return
+		}
+		swapper := AddSwappable[service1](&serviceInstance1{name: "v1"})
+		if err := swapper.Swap(struct{}{}); err == nil {
+			t.Error("instance not implementing the service should be rejected")
+			return
+		}
+		if GetService[service1]().GetName() != "v1" {
+			t.Error("instance should not change if swapping failed")
+			return
+		}
+	})
+
+	t.Run("swapping should be safe with concurrent resolving", func(t *testing.T) {
+		globalContainer = New()
+		swapper := AddSwappable[service1](&serviceInstance1{name: "v"})
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				_ = swapper.Swap(&serviceInstance1{name: "v"})
+			}()
+			go func() {
+				defer wg.Done()
+				if GetService[service1]().GetName() != "v" {
+					t.Error("resolving should get a complete instance")
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
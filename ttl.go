@@ -0,0 +1,98 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// timeNow to get current time, which is replaced by fake clock in tests.
+var timeNow = time.Now
+
+// ttlCache is the cache of transient instance which expires after ttl, see 'CacheFor'.
+type ttlCache struct {
+	ttl time.Duration
+
+	locker  sync.Mutex
+	entry   *ttlEntry     // the current one, created or being created
+	expired reflect.Value // the one created before, resolved while the current one is being created
+}
+
+// ttlEntry is instance cached for an expiry, which is created once.
+type ttlEntry struct {
+	once      sync.Once
+	instance  reflect.Value
+	created   bool      // guarded by locker of cache
+	expiresAt time.Time // guarded by locker of cache
+}
+
+// get instance if it's fresh, otherwise create a new one. Only one is created for each expiry, and others resolving
+// at the same time get the expired one, or wait for it at first. So factory can resolve it's own service when refreshing.
+// Invalid instance is not cached.
+func (t *ttlCache) get(create func() reflect.Value) reflect.Value {
+	t.locker.Lock()
+	entry := t.entry
+	if entry == nil || entry.created && !timeNow().Before(entry.expiresAt) {
+		if entry != nil {
+			t.expired = entry.instance
+		}
+		entry = &ttlEntry{}
+		t.entry = entry
+	} else if !entry.created && t.expired.IsValid() {
+		expired := t.expired
+		t.locker.Unlock()
+		return expired
+	}
+	t.locker.Unlock()
+
+	// create outside of lock, so factory can resolve other services
+	created := false
+	defer func() {
+		if !created {
+			// not cache panic of factory, so it's created again on next resolving
+			t.remove(entry)
+		}
+	}()
+	entry.once.Do(func() {
+		entry.instance = create()
+		t.locker.Lock()
+		entry.created, entry.expiresAt = true, timeNow().Add(t.ttl)
+		t.locker.Unlock()
+	})
+	created = true
+	if !entry.instance.IsValid() {
+		// not cache failure, such as recovered panic of factory
+		t.remove(entry)
+	}
+	return entry.instance
+}
+
+// remove to remove entry if it's not replaced.
+func (t *ttlCache) remove(entry *ttlEntry) {
+	t.locker.Lock()
+	if t.entry == entry {
+		t.entry = nil
+	}
+	t.locker.Unlock()
+}
@@ -0,0 +1,154 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock to replace 'timeNow' in tests, and restores it by the returned func.
+func fakeClock(now time.Time) (advance func(d time.Duration), restore func()) {
+	var locker sync.Mutex
+	timeNow = func() time.Time {
+		defer locker.Unlock()
+		locker.Lock()
+		return now
+	}
+	advance = func(d time.Duration) {
+		defer locker.Unlock()
+		locker.Lock()
+		now = now.Add(d)
+	}
+	return advance, func() { timeNow = time.Now }
+}
+
+func TestCacheFor(t *testing.T) {
+	t.Run("instance should be cached until expiry and then refreshed", func(t *testing.T) {
+		advance, restore := fakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		defer restore()
+		globalContainer = New()
+		var count int32
+		Register(nil, As(TypeOf[*serviceInstance1]()), Transient(func() any {
+			atomic.AddInt32(&count, 1)
+			return &serviceInstance1{name: "config"}
+		}), CacheFor(30*time.Second))
+
+		first := GetService[*serviceInstance1]()
+		advance(29 * time.Second)
+		if GetService[*serviceInstance1]() != first {
+			t.Error("instance should be cached before expiry")
+			return
+		}
+		advance(time.Second)
+		refreshed := GetService[*serviceInstance1]()
+		if refreshed == first || atomic.LoadInt32(&count) != 2 {
+			t.Error("instance should be refreshed after expiry")
+			return
+		}
+		if GetService[*serviceInstance1]() != refreshed {
+			t.Error("refreshed instance should be cached")
+			return
+		}
+	})
+
+	t.Run("only one instance should be created for each expiry", func(t *testing.T) {
+		_, restore := fakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		defer restore()
+		globalContainer = New()
+		var count int32
+		Register(nil, As(TypeOf[*serviceInstance1]()), Transient(func() any {
+			atomic.AddInt32(&count, 1)
+			time.Sleep(5 * time.Millisecond)
+			return &serviceInstance1{name: "config"}
+		}), CacheFor(time.Minute))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				GetService[*serviceInstance1]()
+			}()
+		}
+		wg.Wait()
+		if c := atomic.LoadInt32(&count); c != 1 {
+			t.Errorf("only one instance should be created for each expiry, but created %d", c)
+			return
+		}
+	})
+
+	t.Run("factory should resolve expired instance of it's own service when refreshing", func(t *testing.T) {
+		advance, restore := fakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+		defer restore()
+		globalContainer = New()
+		version := 0
+		Register(nil, As(TypeOf[*serviceInstance1]()), Transient(func() any {
+			if version++; version > 1 {
+				previous := GetService[*serviceInstance1]()
+				return &serviceInstance1{name: previous.name + "+"}
+			}
+			return &serviceInstance1{name: "config"}
+		}), CacheFor(time.Minute))
+
+		first := GetService[*serviceInstance1]()
+		advance(time.Minute)
+		if refreshed := GetService[*serviceInstance1](); refreshed == first || refreshed.name != "config+" {
+			t.Error("factory should get the expired instance when refreshing")
+			return
+		}
+	})
+
+	t.Run("panic of factory should not be cached", func(t *testing.T) {
+		globalContainer = New()
+		calls := 0
+		Register(nil, As(TypeOf[*serviceInstance1]()), Transient(func() any {
+			if calls++; calls == 1 {
+				panic("not ready")
+			}
+			return &serviceInstance1{name: "config"}
+		}), CacheFor(time.Minute))
+
+		if _, err := GetServiceE[*serviceInstance1](); err == nil {
+			t.Error("panic of factory should fail")
+			return
+		}
+		if svc := GetService[*serviceInstance1](); svc == nil || svc != GetService[*serviceInstance1]() || calls != 2 {
+			t.Errorf("instance should be created again and cached, but factory called %d times", calls)
+			return
+		}
+	})
+
+	t.Run("cache duration should be rejected for singleton", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, CacheFor(time.Second)); err == nil {
+			t.Error("cache duration should be rejected for singleton")
+			return
+		}
+		if err := globalContainer.(Registerer).Register(nil, As(TypeOf[*serviceInstance1]()), Transient(func() any { return &serviceInstance1{} }), CacheFor(0)); err == nil {
+			t.Error("non-positive cache duration should fail")
+			return
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+)
+
+// unionResolver is the read-only union of containers, see 'Union'.
+type unionResolver struct {
+	members []Container
+}
+
+// Union to compose containers into a read-only resolver, which resolves service from each container in order,
+// and returns the first one found. Unlike parent chain, containers are independent and not changed,
+// such as a core container with several feature containers.
+//
+// Members and their parent chains are searched breadth-first, so the nearest registration wins, such as in
+// diamond-shaped graph whose members share the same ancestor. Members are at distance 0, their parents at distance 1,
+// and so on, and registrations at the same distance are broken by order of members, leftmost first.
+// Ancestor shared by members is searched once, and parent which is union is expanded to it's members in place.
+//
+// Services can't be added to the union, and it can be parent of container but can't have parent.
+//
+//	resolver := ioc.Union(core, billing, reporting)
+//	app := ioc.New()
+//	app.SetParent(resolver)
+func Union(containers ...Container) Resolver {
+	union := &unionResolver{}
+	for _, container := range containers {
+		if container != nil {
+			union.members = append(union.members, container)
+		}
+	}
+	return union
+}
+
+func (u *unionResolver) SetParent(parent Resolver) {
+	panic(errors.New("union resolver is read-only, it can't have parent"))
+}
+
+// unionNode is resolver to search in union, with the member which it's reached from.
+type unionNode struct {
+	resolver Resolver
+	origin   *defaultContainer // member which the resolving started from, nil if member is not created by 'ioc.New'
+}
+
+func (u *unionResolver) Resolve(serviceType reflect.Type) reflect.Value {
+	visited := make(map[Resolver]bool)
+	var level []unionNode
+	for _, member := range u.expand(u.resolvers(), visited) {
+		origin, _ := member.(*defaultContainer)
+		level = append(level, unionNode{resolver: member, origin: origin})
+	}
+	for len(level) > 0 {
+		var next []unionNode
+		for _, node := range level {
+			c, ok := node.resolver.(*defaultContainer)
+			if !ok {
+				// search resolver not created by 'ioc.New' as a whole
+				var val reflect.Value
+				if container, isContainer := node.resolver.(Container); isContainer {
+					val, _ = SafeResolve(container, serviceType)
+				} else {
+					val = node.resolver.Resolve(serviceType)
+				}
+				if val.IsValid() {
+					return val
+				}
+				continue
+			}
+			if val, found := c.resolveHere(serviceType, node.origin, nil); found {
+				if val.IsValid() {
+					return val
+				}
+				// not resolved from parents as it's registered
+				continue
+			}
+			if parent := c.Parent(); parent != nil {
+				node.origin.notifyFallthrough(serviceType, c)
+				for _, resolver := range u.expand([]Resolver{parent}, visited) {
+					next = append(next, unionNode{resolver: resolver, origin: node.origin})
+				}
+			}
+		}
+		level = next
+	}
+
+	// pointer value adapted by member as it resolves by itself
+	for _, member := range u.members {
+		if c, ok := member.(*defaultContainer); ok && c.isPointerValueAdapt() {
+			if val := c.adaptPointerValue(serviceType, nil); val.IsValid() {
+				return val
+			}
+		}
+	}
+	return reflect.Value{}
+}
+
+// expand to replace union with it's members recursively, and skip containers and unions visited.
+func (u *unionResolver) expand(resolvers []Resolver, visited map[Resolver]bool) []Resolver {
+	var expanded []Resolver
+	for _, resolver := range resolvers {
+		switch r := resolver.(type) {
+		case *defaultContainer:
+			if !visited[r] {
+				visited[r] = true
+				expanded = append(expanded, r)
+			}
+		case *unionResolver:
+			if !visited[r] {
+				visited[r] = true
+				expanded = append(expanded, u.expand(r.resolvers(), visited)...)
+			}
+		default:
+			expanded = append(expanded, resolver)
+		}
+	}
+	return expanded
+}
+
+// resolvers to get members as resolvers.
+func (u *unionResolver) resolvers() []Resolver {
+	resolvers := make([]Resolver, 0, len(u.members))
+	for _, member := range u.members {
+		resolvers = append(resolvers, member)
+	}
+	return resolvers
+}
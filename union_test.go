@@ -0,0 +1,126 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"testing"
+)
+
+func TestUnion(t *testing.T) {
+	t.Run("first container found should win", func(t *testing.T) {
+		globalContainer = New()
+		core := New()
+		AddSingletonToC[service1](core, &serviceInstance1{name: "core"})
+		feature1 := New()
+		feature1.(StrictResolver).SetStrictResolve(true)
+		AddSingletonToC[service1](feature1, &serviceInstance1{name: "feature1"})
+		AddSingletonToC[service2](feature1, &serviceInstance2{name: "feature1"})
+		feature2 := New()
+		AddSingletonToC[*serviceInstance3](feature2, &serviceInstance3{name: "feature2"})
+
+		union := Union(core, nil, feature1, feature2)
+		if val := union.Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface().(service1).GetName() != "core" {
+			t.Error("service in former container should win")
+			return
+		}
+		if val := union.Resolve(TypeOf[service2]()); !val.IsValid() || val.Interface().(service2).GetName() != "feature1" {
+			t.Error("service in latter container should be resolved")
+			return
+		}
+		if val := union.Resolve(TypeOf[*serviceInstance3]()); !val.IsValid() || val.Interface().(*serviceInstance3).GetName() != "feature2" {
+			t.Error("service in last container should be resolved without panic of strict one")
+			return
+		}
+		if union.Resolve(TypeOf[*serviceInstance4]()).IsValid() {
+			t.Error("service not found should be invalid")
+			return
+		}
+
+		app := New()
+		app.SetParent(union)
+		if svc := GetServiceFromC[service2](app); svc == nil || svc.GetName() != "feature1" {
+			t.Error("union should be parent of container")
+			return
+		}
+		if GetServiceFromC[service1](core) == nil || GetServiceFromC[service2](core) != nil {
+			t.Error("members should not be changed")
+			return
+		}
+	})
+
+	t.Run("nearest registration should win in diamond", func(t *testing.T) {
+		globalContainer = New()
+		root := New()
+		AddSingletonToC[service1](root, &serviceInstance1{name: "root"})
+		AddSingletonToC[service3](root, &serviceInstance2{name: "root"})
+		left := New()
+		left.SetParent(root)
+		right := New()
+		right.SetParent(root)
+		AddSingletonToC[service1](right, &serviceInstance1{name: "right"})
+		AddSingletonToC[service2](left, &serviceInstance2{name: "left"})
+		AddSingletonToC[service2](right, &serviceInstance2{name: "right"})
+		child := New()
+		child.SetParent(Union(left, right))
+
+		if svc := GetServiceFromC[service1](child); svc == nil || svc.GetName() != "right" {
+			t.Error("registration nearer than the shared ancestor should win")
+			return
+		}
+		if svc := GetServiceFromC[service2](child); svc == nil || svc.GetName() != "left" {
+			t.Error("tie of the same distance should be broken by the leftmost member")
+			return
+		}
+		if svc := GetServiceFromC[service3](child); svc == nil || svc.(*serviceInstance2).name != "root" {
+			t.Error("shared ancestor should be resolved if not found in members")
+			return
+		}
+	})
+
+	t.Run("union as parent should be expanded in place", func(t *testing.T) {
+		globalContainer = New()
+		grandLeft, grandRight := New(), New()
+		AddSingletonToC[service1](grandRight, &serviceInstance1{name: "grandRight"})
+		left := New()
+		left.SetParent(Union(grandLeft, grandRight))
+		right := New()
+		far := New()
+		AddSingletonToC[service1](far, &serviceInstance1{name: "far"})
+		middle := New()
+		middle.SetParent(far)
+		right.SetParent(middle)
+		if val := Union(left, right).Resolve(TypeOf[service1]()); !val.IsValid() || val.Interface().(service1).GetName() != "grandRight" {
+			t.Error("members of union as parent should be at the distance of the union")
+			return
+		}
+	})
+
+	t.Run("union should be read-only", func(t *testing.T) {
+		globalContainer = New()
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("setting parent of union should panic")
+			}
+		}()
+		Union(New()).SetParent(New())
+	})
+}
@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+	"sync/atomic"
+)
+
+// UnusedReporter is implemented by container to find services registered but never resolved.
+type UnusedReporter interface {
+	// UnusedRegistrations to get services registered in current container but never resolved, includes keyed ones,
+	// in registration order. It's for pruning dead registrations after the app has run for a while.
+	// Resolving by injection also counts, such as singleton resolved only for injecting to others.
+	//
+	//  for _, serviceType := range container.(ioc.UnusedReporter).UnusedRegistrations() {
+	//      log.Printf("service '%v' is never resolved", serviceType)
+	//  }
+	UnusedRegistrations() []reflect.Type
+}
+
+var _ UnusedReporter = (*defaultContainer)(nil)
+
+func (c *defaultContainer) UnusedRegistrations() []reflect.Type {
+	var unused []*serviceBinding
+	seen := make(map[*serviceBinding]bool)
+	collect := func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		// keyed primary is stored both with and without key
+		if !seen[binding] && binding.ServiceType != resolverType && atomic.LoadInt32(&binding.Used) == 0 {
+			seen[binding] = true
+			unused = append(unused, binding)
+		}
+		return true
+	}
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		return collect(serviceType, binding)
+	})
+	c.keyedBindings.Range(collect)
+	sort.Slice(unused, func(i, j int) bool {
+		return unused[i].Seq < unused[j].Seq
+	})
+	serviceTypes := make([]reflect.Type, 0, len(unused))
+	for _, binding := range unused {
+		serviceTypes = append(serviceTypes, binding.ServiceType)
+	}
+	return serviceTypes
+}
@@ -0,0 +1,71 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnusedRegistrations(t *testing.T) {
+	t.Run("services never resolved should be unused", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		AddTransient[service1](func() service1 { return &serviceInstance1{} })
+		AddTransient[service2](func() service2 { return &serviceInstance2{} })
+		_ = globalContainer.(KeyedContainer).AddKeyedSingleton("key", reflect.TypeOf((*serviceInstance7)(nil)), &serviceInstance7{})
+
+		unused := globalContainer.(UnusedReporter).UnusedRegistrations()
+		if len(unused) != 5 || unused[0] != reflect.TypeOf((*serviceInstance7)(nil)) || unused[3] != reflect.TypeOf((*service2)(nil)).Elem() {
+			t.Errorf("all services should be unused in registration order, but %v", unused)
+			return
+		}
+
+		// serviceInstance7 is resolved by initializing serviceInstance8
+		GetService[*serviceInstance8]()
+		GetService[service2]()
+		unused = globalContainer.(UnusedReporter).UnusedRegistrations()
+		if len(unused) != 2 || unused[0] != reflect.TypeOf((*service1)(nil)).Elem() || unused[1] != reflect.TypeOf((*serviceInstance7)(nil)) {
+			t.Errorf("services resolved including by injection should be used, but %v", unused)
+			return
+		}
+
+		globalContainer.(KeyedContainer).ResolveKeyed("key", reflect.TypeOf((*serviceInstance7)(nil)))
+		if unused = globalContainer.(UnusedReporter).UnusedRegistrations(); len(unused) != 1 {
+			t.Errorf("keyed service resolved should be used, but %v", unused)
+			return
+		}
+	})
+
+	t.Run("services resolved through child should be used", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		child := New()
+		child.SetParent(globalContainer)
+		child.Resolve(reflect.TypeOf((*service1)(nil)).Elem())
+		if unused := globalContainer.(UnusedReporter).UnusedRegistrations(); len(unused) != 0 {
+			t.Errorf("service resolved through child should be used, but %v", unused)
+			return
+		}
+	})
+}
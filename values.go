@@ -0,0 +1,124 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"sync/atomic"
+)
+
+// AddTransientWithResolver to add transient service instance factory with resolver to global container.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddTransientWithResolver[TService any](instanceFactory func(resolver Resolver) TService) {
+	AddTransientWithResolverToC[TService](globalContainer, instanceFactory)
+}
+
+// AddTransientWithResolverToC to add transient service instance factory with resolver to container.
+//
+// It will panic if 'TService' or 'instanceFactory' is invalid.
+func AddTransientWithResolverToC[TService any](container Container, instanceFactory func(resolver Resolver) TService) {
+	if instanceFactory == nil {
+		panic("param 'instanceFactory' is null")
+	}
+	err := container.(ValuesResolver).AddTransientWithResolver(TypeOf[TService](), func(resolver Resolver) any {
+		return instanceFactory(resolver)
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ValuesResolver is implemented by container to resolve with values available only for a call.
+type ValuesResolver interface {
+	// AddTransientWithResolver to add transient service instance factory, which gets resolver of the container where resolving started,
+	// such as child container and values of 'ResolveWithValues'.
+	//
+	//  var container ioc.Container
+	//  err := container.(ioc.ValuesResolver).AddTransientWithResolver(reflect.TypeOf((*Handler)(nil)).Elem(), func(resolver ioc.Resolver) any {
+	//      return &HandlerImpl{user: ioc.GetServiceFromC[*User](resolver.(ioc.Container))}
+	//  })
+	AddTransientWithResolver(serviceType reflect.Type, instanceFactory func(resolver Resolver) any) error
+
+	// ResolveWithValues to resolve service with values available only for this call, which are resolved by their types
+	// before services registered in container, but not registered in container. Values are for factories getting resolver,
+	// such as 'AddTransientWithResolver', 'AddFactoryFor' and constructors of 'Provide', and their transient dependencies.
+	// Cached instances, such as singletons and scoped ones, are created with services of the container owning them,
+	// so they never keep values even if created during this call.
+	//
+	//  var container ioc.Container
+	//  val := container.(ioc.ValuesResolver).ResolveWithValues(reflect.TypeOf((*Handler)(nil)).Elem(), map[reflect.Type]any{
+	//      reflect.TypeOf((*User)(nil)): currentUser,
+	//  })
+	ResolveWithValues(serviceType reflect.Type, values map[reflect.Type]any) reflect.Value
+}
+
+var _ ValuesResolver = (*defaultContainer)(nil)
+
+func (c *defaultContainer) AddTransientWithResolver(serviceType reflect.Type, instanceFactory func(resolver Resolver) any) error {
+	if serviceType == nil {
+		return errors.New("param 'serviceType' is null")
+	}
+	if instanceFactory == nil {
+		return errors.New("param 'instanceFactory' is null")
+	}
+	return c.addBinding(&serviceBinding{
+		ServiceType: serviceType,
+		// used by scope and the others that call factory without resolver
+		InstanceFactory: func() any {
+			return instanceFactory(c)
+		},
+		ResolverFactory: instanceFactory,
+	})
+}
+
+func (c *defaultContainer) ResolveWithValues(serviceType reflect.Type, values map[reflect.Type]any) reflect.Value {
+	if serviceType == nil {
+		return reflect.Value{}
+	}
+	if len(values) == 0 {
+		return c.Resolve(serviceType)
+	}
+	// values are resolved by the temporary child container before current one, and instances cached are created
+	// by the container owning them without values
+	child := &defaultContainer{parent: c, valuesOf: c}
+	child.bindings.Store(resolverType, newValueBinding(resolverType, resolverValueOf(reflect.ValueOf(child))))
+	for valueType, value := range values {
+		valueVal := reflect.ValueOf(value)
+		if valueType == nil || !valueVal.IsValid() || !valueVal.Type().AssignableTo(valueType) {
+			// skip invalid value
+			continue
+		}
+		child.bindings.Store(valueType, newValueBinding(valueType, valueVal))
+	}
+	child.strict = atomic.LoadInt32(&c.strict)
+	child.pointerAdapt = atomic.LoadInt32(&c.pointerAdapt)
+	return child.Resolve(serviceType)
+}
+
+// newValueBinding to create binding of the value as initialized singleton, which won't be injected or initialized.
+func newValueBinding(serviceType reflect.Type, value reflect.Value) *serviceBinding {
+	binding := &serviceBinding{ServiceType: serviceType, Instance: value, state: bindingInitialized}
+	binding.InitializedInstance.Store(value)
+	return binding
+}
@@ -0,0 +1,125 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestResolveWithValues(t *testing.T) {
+	t.Run("transient factory should consume per-call values", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "registered"})
+		AddTransientWithResolver[service1](func(resolver Resolver) service1 {
+			user := GetServiceFromC[*serviceInstance7](resolver.(Container))
+			return &serviceInstance1{name: user.name}
+		})
+		AddTransientWithResolver[service2](func(resolver Resolver) service2 {
+			s1 := GetServiceFromC[service1](resolver.(Container))
+			return &serviceInstance2{name: "with " + s1.GetName()}
+		})
+
+		values := map[reflect.Type]any{reflect.TypeOf((*serviceInstance7)(nil)): &serviceInstance7{name: "value"}}
+		val := globalContainer.(ValuesResolver).ResolveWithValues(reflect.TypeOf((*service1)(nil)).Elem(), values)
+		if !val.IsValid() || val.Interface().(service1).GetName() != "value" {
+			t.Error("value should take precedence over registered service")
+			return
+		}
+		val = globalContainer.(ValuesResolver).ResolveWithValues(reflect.TypeOf((*service2)(nil)).Elem(), values)
+		if !val.IsValid() || val.Interface().(service2).GetName() != "with value" {
+			t.Error("value should be available to transitive dependencies")
+			return
+		}
+		if svc := GetService[service1](); svc.GetName() != "registered" {
+			t.Error("value should live only for the call")
+			return
+		}
+		if GetService[*serviceInstance7]().name != "registered" {
+			t.Error("value should not be registered")
+			return
+		}
+	})
+
+	t.Run("factories getting resolver should consume per-call values", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "registered"})
+		AddFactoryFor[service1](func(r Resolver) (service1, error) {
+			return &serviceInstance1{name: GetServiceFromC[*serviceInstance7](r.(Container)).name}, nil
+		})
+		Provide[service2](func(s7 *serviceInstance7) service2 { return &serviceInstance2{name: s7.name} })
+
+		values := map[reflect.Type]any{reflect.TypeOf((*serviceInstance7)(nil)): &serviceInstance7{name: "value"}}
+		if val := globalContainer.(ValuesResolver).ResolveWithValues(TypeOf[service1](), values); !val.IsValid() || val.Interface().(service1).GetName() != "value" {
+			t.Error("factory for should consume per-call values")
+			return
+		}
+		if val := globalContainer.(ValuesResolver).ResolveWithValues(TypeOf[service2](), values); !val.IsValid() || val.Interface().(service2).GetName() != "value" {
+			t.Error("constructor should consume per-call values")
+			return
+		}
+	})
+
+	t.Run("cached instances created during the call should not keep values", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "registered"})
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		AddScoped[service1](func() service1 { return &serviceInstance1{name: "scoped"} })
+		scope := globalContainer.(ScopeContainer).NewScope()
+		defer scope.(ScopeContainer).Dispose()
+
+		values := map[reflect.Type]any{reflect.TypeOf((*serviceInstance7)(nil)): &serviceInstance7{name: "value"}}
+		val := globalContainer.(ValuesResolver).ResolveWithValues(reflect.TypeOf((*serviceInstance8)(nil)), values)
+		if !val.IsValid() || val.Interface().(*serviceInstance8).GetS7Name() != "registered" {
+			t.Error("singleton initialized during the call should not keep values")
+			return
+		}
+		if GetService[*serviceInstance8]().GetS7Name() != "registered" {
+			t.Error("singleton initialized during the call should not keep values")
+			return
+		}
+		val = scope.(ValuesResolver).ResolveWithValues(TypeOf[service1](), values)
+		if !val.IsValid() || val.Interface() != GetServiceFromC[service1](scope) {
+			t.Error("scoped instance should be cached by scope")
+			return
+		}
+	})
+
+	t.Run("invalid values should be skipped", func(t *testing.T) {
+		globalContainer = New()
+		AddTransientWithResolver[service1](func(resolver Resolver) service1 {
+			return &serviceInstance1{name: "instance1"}
+		})
+		values := map[reflect.Type]any{
+			reflect.TypeOf((*serviceInstance7)(nil)): &serviceInstance1{},
+			reflect.TypeOf((*service2)(nil)).Elem():  nil,
+		}
+		if val := globalContainer.(ValuesResolver).ResolveWithValues(reflect.TypeOf((*service1)(nil)).Elem(), values); !val.IsValid() {
+			t.Error("service should be resolved")
+			return
+		}
+		if globalContainer.(ValuesResolver).ResolveWithValues(reflect.TypeOf((*serviceInstance7)(nil)), values).IsValid() {
+			t.Error("value not assignable should be skipped")
+			return
+		}
+	})
+}
@@ -0,0 +1,207 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+)
+
+// VerifyContainer to verify wiring of all services registered in container without initializing them, for use in tests.
+// It returns '*ioc.AggregateError' listing every problem: dependencies can't be resolved, fields can't be injected,
+// dependency cycles, and failures of creating services by factories.
+//
+// Services created by factories are resolved in a throwaway child container, where singletons not initialized yet
+// are initialized as copies, and instances are not cached, so the container and it's parents are not changed.
+// Services resolved by factories from containers they captured are not verified this way, and deferred registrations
+// not run yet are resolved as zero values.
+//
+//	func TestWiring(t *testing.T) {
+//	    if err := ioc.VerifyContainer(container); err != nil {
+//	        t.Error(err)
+//	    }
+//	}
+func VerifyContainer(c Container) error {
+	verifier, ok := c.(interface{ verify() []error })
+	if !ok {
+		return errors.New("container should be created by 'ioc.New'")
+	}
+	return aggregateErrors(verifier.verify())
+}
+
+func (c *defaultContainer) verify() []error {
+	var errs []error
+	bindings := c.singletonBindings()
+	c.locker.Lock()
+	for _, groupBindings := range c.groups {
+		bindings = append(bindings, groupBindings...)
+	}
+	c.locker.Unlock()
+
+	for _, binding := range bindings {
+		invalidTypes := make(map[reflect.Type]bool)
+		for _, field := range getFieldsToInject(binding.Instance.Type()) {
+			if !isInjectableField(field) {
+				invalidTypes[field.FieldType] = true
+				errs = append(errs, fmt.Errorf("service '%v'%s: field '%s' of type '%v' can't be injected",
+					binding.ServiceType, binding.registeredAtSuffix(), field.FieldName, field.FieldType))
+			}
+		}
+		for _, missing := range c.missingDependencies(binding) {
+			if invalidTypes[missing] {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("service '%v'%s: dependency '%v' can't be resolved",
+				binding.ServiceType, binding.registeredAtSuffix(), missing))
+		}
+	}
+
+	// dependency cycles of services
+	visiting := make(map[*serviceBinding]bool)
+	visited := make(map[*serviceBinding]bool)
+	var path []reflect.Type
+	var visit func(binding *serviceBinding)
+	visit = func(binding *serviceBinding) {
+		if visited[binding] {
+			return
+		}
+		if visiting[binding] {
+			cycle := []string{binding.ServiceType.String()}
+			for i := len(path) - 1; i >= 0 && path[i] != binding.ServiceType; i-- {
+				cycle = append([]string{path[i].String()}, cycle...)
+			}
+			cycle = append([]string{binding.ServiceType.String()}, cycle...)
+			errs = append(errs, fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> ")))
+			return
+		}
+		visiting[binding] = true
+		path = append(path, binding.ServiceType)
+		for _, depType := range binding.dependencies() {
+			if depBinding := c.lookupBinding(depType); depBinding != nil && depBinding.ServiceType != resolverType {
+				visit(depBinding)
+			}
+		}
+		path = path[:len(path)-1]
+		visiting[binding] = false
+		visited[binding] = true
+	}
+	for _, binding := range bindings {
+		visit(binding)
+	}
+
+	child := &defaultContainer{parent: c}
+	child.pointerAdapt = atomic.LoadInt32(&c.pointerAdapt)
+	for _, binding := range c.factoryBindings() {
+		path := newResolvePath()
+		path.call.dry = make(map[*serviceBinding]reflect.Value)
+		_, err := checkResolved(binding.ServiceType, path, func() reflect.Value {
+			return binding.resolve(c, child, path)
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("service '%v'%s: %w", binding.ServiceType, binding.registeredAtSuffix(), err))
+		}
+	}
+	return errs
+}
+
+// factoryBindings to get bindings whose instances are created by factories for resolving, including keyed ones,
+// sorted by sequence of registration.
+func (c *defaultContainer) factoryBindings() []*serviceBinding {
+	var bindings []*serviceBinding
+	collect := func(key, val any) bool {
+		binding := val.(*serviceBinding)
+		if !binding.Instance.IsValid() && (binding.InstanceFactory != nil || binding.ResolverFactory != nil) &&
+			binding.ContextScoped == nil && binding.Async == nil && binding.Swappable == nil {
+			bindings = append(bindings, binding)
+		}
+		return true
+	}
+	c.rangeBindings(func(serviceType reflect.Type, binding *serviceBinding) bool {
+		return collect(serviceType, binding)
+	})
+	c.keyedBindings.Range(func(key, val any) bool {
+		// keyed primary is also stored without key
+		if val.(*serviceBinding).Primary {
+			return true
+		}
+		return collect(key, val)
+	})
+	sort.Slice(bindings, func(i, j int) bool {
+		return bindings[i].Seq < bindings[j].Seq
+	})
+	return bindings
+}
+
+// isDry to check whether the call of 'path' caches nothing, see 'VerifyContainer'.
+func (p *resolvePath) isDry() bool {
+	return p != nil && p.call.dry != nil
+}
+
+// dryInitialize to initialize copy of singleton in the dry call of 'path', so the singleton is not changed.
+// The copy is shared in the call, and it has the values set before registering.
+func (b *serviceBinding) dryInitialize(owner *defaultContainer, path *resolvePath) reflect.Value {
+	if err := path.cycleOf(b); err != nil {
+		panic(err)
+	}
+	if instance, ok := path.call.dry[b]; ok {
+		return instance
+	}
+	instance := b.copyInstance()
+	path = path.push(b)
+	injectFrom(owner, instance, path)
+	if b.InstanceInitializer.IsValid() {
+		injectFrom(owner, instance.MethodByName(b.InstanceInitializerName), path)
+	}
+	instance = owner.filterInstance(b.ServiceType, owner.decorateInstance(b.ServiceType, instance, owner))
+	path.call.dry[b] = instance
+	return instance
+}
+
+// isInjectableField to check whether type of field can be injected.
+func isInjectableField(field structField) bool {
+	if field.OptionalOf != nil || field.ProviderOf != nil || field.HasConfig {
+		return true
+	}
+	switch field.FieldType.Kind() {
+	case reflect.Interface, reflect.Map:
+		return true
+	case reflect.Pointer:
+		return field.FieldType.Elem().Kind() == reflect.Struct
+	case reflect.Slice:
+		elemType := field.FieldType.Elem()
+		return field.Group != "" || elemType.Kind() == reflect.Interface ||
+			elemType.Kind() == reflect.Pointer && elemType.Elem().Kind() == reflect.Struct
+	}
+	return false
+}
+
+// registeredAtSuffix to describe registration site of binding if known.
+func (b *serviceBinding) registeredAtSuffix() string {
+	if b.RegisteredAt == "" {
+		return ""
+	}
+	return fmt.Sprintf(" registered at %s", b.RegisteredAt)
+}
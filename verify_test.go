@@ -0,0 +1,136 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestVerifyContainer(t *testing.T) {
+	t.Run("verify valid container should success", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		svc8 := &serviceInstance8{}
+		AddSingleton[*serviceInstance8](svc8)
+		AddSingleton[service1](&serviceInstance1{name: "instance1"})
+		AddSingleton[*invalidateClient](&invalidateClient{})
+
+		if err := VerifyContainer(globalContainer); err != nil {
+			t.Errorf("verify should success, but %v", err)
+			return
+		}
+		if svc8.s7 != nil {
+			t.Error("verify should not initialize singletons")
+			return
+		}
+	})
+
+	t.Run("verify should resolve transients without changing container or parents", func(t *testing.T) {
+		parent := New()
+		svc8 := &serviceInstance8{}
+		AddSingletonToC[*serviceInstance7](parent, &serviceInstance7{name: "instance7"})
+		AddSingletonToC[*serviceInstance8](parent, svc8)
+		globalContainer = New()
+		SetParent(parent)
+		// created once by verifying
+		created := 0
+		AddTransientWithResolver[*verifyTransient](func(r Resolver) *verifyTransient {
+			created++
+			s8 := r.Resolve(TypeOf[*serviceInstance8]()).Interface().(*serviceInstance8)
+			if created == 1 && (s8 == svc8 || s8.GetS7Name() != "instance7") {
+				t.Error("singleton resolved by verifying should be an initialized copy")
+			}
+			return &verifyTransient{S8: s8}
+		})
+		_ = globalContainer.(Memoizer).Memoize(TypeOf[*verifyTransient](), func() any { return "key" })
+		AddTransientWithResolver[service1](func(r Resolver) service1 {
+			r.Resolve(TypeOf[service2]())
+			return &serviceInstance1{}
+		})
+
+		err := VerifyContainer(globalContainer)
+		var aggregate *AggregateError
+		if !errors.As(err, &aggregate) || len(aggregate.Errors) != 1 || !strings.Contains(err.Error(), "service 'ioc.service1'") ||
+			!errors.Is(err, ErrNotFound) {
+			t.Errorf("missing dependency of transient should be returned, but %v", err)
+			return
+		}
+		if created != 1 || svc8.s7 != nil {
+			t.Error("transient should be created once without initializing singletons")
+			return
+		}
+		if len(globalContainer.(UnusedReporter).UnusedRegistrations()) != 2 {
+			t.Error("verifying should not mark registrations used")
+			return
+		}
+		if GetService[*verifyTransient]() == nil || created != 2 {
+			t.Error("transient created by verifying should not be cached")
+			return
+		}
+	})
+
+	t.Run("verify invalid container should list all problems", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance8](&serviceInstance8{})
+		AddSingleton[*invalidateClient](&invalidateClient{})
+		AddSingleton[*verifyCycleA](&verifyCycleA{})
+		AddSingleton[*verifyCycleB](&verifyCycleB{})
+		AddToGroup[*verifyInvalidField]("group1", &verifyInvalidField{})
+
+		err := VerifyContainer(globalContainer)
+		var aggregate *AggregateError
+		if !errors.As(err, &aggregate) || len(aggregate.Errors) != 4 {
+			t.Errorf("4 problems should be returned, but %v", err)
+			return
+		}
+		msg := err.Error()
+		for _, expected := range []string{
+			"dependency '*ioc.serviceInstance7' can't be resolved",
+			"dependency 'ioc.service1' can't be resolved",
+			"field 'Name' of type 'string' can't be injected",
+			"dependency cycle: *ioc.verifyCycleA -> *ioc.verifyCycleB -> *ioc.verifyCycleA",
+		} {
+			if !strings.Contains(msg, expected) {
+				t.Errorf("problem '%s' should be returned, but %v", expected, err)
+				return
+			}
+		}
+	})
+}
+
+type verifyTransient struct {
+	S8 *serviceInstance8 `ioc-inject:"true"`
+}
+
+type verifyCycleA struct {
+	B *verifyCycleB `ioc-inject:"true"`
+}
+
+type verifyCycleB struct {
+	A *verifyCycleA `ioc-inject:"true"`
+}
+
+type verifyInvalidField struct {
+	Name string `ioc-inject:"true"`
+}
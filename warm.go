@@ -0,0 +1,72 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// Warm to resolve service 'TService' from global container, so it's initialized before first use.
+//
+// It will panic if 'TService' not found or failed to resolve.
+func Warm[TService any]() {
+	WarmFromC[TService](globalContainer)
+}
+
+// WarmFromC to resolve service 'TService' from container, so it's initialized before first use.
+//
+// It will panic if 'TService' not found or failed to resolve.
+func WarmFromC[TService any](container Container) {
+	if err := container.(Warmer).Warm(TypeOf[TService]()); err != nil {
+		panic(err)
+	}
+}
+
+// Warmer is implemented by container to initialize singletons before first use.
+type Warmer interface {
+	// Warm to resolve services so singletons and their dependencies are initialized before first use,
+	// and returns the aggregated errors of services not found or failed to resolve.
+	//
+	//  err := container.(ioc.Warmer).Warm(reflect.TypeOf((*Service1)(nil)).Elem(), reflect.TypeOf((*Service2)(nil)).Elem())
+	Warm(serviceTypes ...reflect.Type) error
+}
+
+var _ Warmer = (*defaultContainer)(nil)
+
+func (c *defaultContainer) Warm(serviceTypes ...reflect.Type) error {
+	var errs []error
+	for _, serviceType := range serviceTypes {
+		if serviceType == nil {
+			errs = append(errs, errors.New("param 'serviceTypes' has null service type"))
+			continue
+		}
+		val, err := SafeResolve(c, serviceType)
+		if err != nil {
+			errs = append(errs, err)
+		} else if !val.IsValid() {
+			errs = append(errs, fmt.Errorf("service '%v' not found", serviceType))
+		}
+	}
+	return aggregateErrors(errs)
+}
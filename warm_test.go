@@ -0,0 +1,63 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestWarm(t *testing.T) {
+	t.Run("warm should initialize singletons", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		svc8 := &serviceInstance8{}
+		AddSingleton[*serviceInstance8](svc8)
+
+		Warm[*serviceInstance8]()
+		if svc8.s7 == nil || svc8.GetS7Name() != "instance7" {
+			t.Error("initialize method should run when warming")
+			return
+		}
+	})
+
+	t.Run("warm should aggregate errors", func(t *testing.T) {
+		globalContainer = New()
+		AddSingleton[*serviceInstance7](&serviceInstance7{name: "instance7"})
+		AddTransient[service1](func() service1 { panic("fail") })
+
+		err := globalContainer.(Warmer).Warm(reflect.TypeOf((*serviceInstance7)(nil)), reflect.TypeOf((*service1)(nil)).Elem(),
+			reflect.TypeOf((*service2)(nil)).Elem(), nil)
+		var aggregate *AggregateError
+		if !errors.As(err, &aggregate) || len(aggregate.Errors) != 3 {
+			t.Errorf("3 errors should be returned, but %v", err)
+			return
+		}
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("warm service not found should panic")
+			}
+		}()
+		Warm[service2]()
+	})
+}
@@ -0,0 +1,67 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// weakCache is the weak reference of singleton created by factory, see 'Weak'.
+//
+// Instance is referred by 'weakRef' of it's first byte, which refers the whole object and doesn't keep it alive,
+// so no finalizer is set on instances. It's a strong reference before go1.24, so instance is cached as lazy singleton.
+type weakCache struct {
+	locker      sync.Mutex
+	ref         weakRef[byte]
+	instanceTyp reflect.Type // pointer type of instance, nil if no instance referred
+}
+
+// get instance if it's not collected, otherwise create a new one and hold it by weak reference.
+// Instance which is not a pointer is returned without caching.
+func (w *weakCache) get(create func() reflect.Value) reflect.Value {
+	defer w.locker.Unlock()
+	w.locker.Lock()
+	if w.instanceTyp != nil {
+		if ptr := w.ref.get(); ptr != nil {
+			return reflect.NewAt(w.instanceTyp.Elem(), unsafe.Pointer(ptr))
+		}
+	}
+	instance := create()
+	for instance.IsValid() && instance.Kind() == reflect.Interface {
+		instance = instance.Elem()
+	}
+	if !instance.IsValid() || instance.Kind() != reflect.Pointer || instance.IsNil() || instance.Type().Elem().Size() == 0 {
+		return instance
+	}
+	w.ref = makeWeakRef((*byte)(instance.UnsafePointer()))
+	w.instanceTyp = instance.Type()
+	return instance
+}
+
+// reset to drop the instance, so a new one is created on next resolving.
+func (w *weakCache) reset() {
+	w.locker.Lock()
+	w.ref, w.instanceTyp = weakRef[byte]{}, nil
+	w.locker.Unlock()
+}
@@ -0,0 +1,57 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+//go:build go1.24
+
+package ioc
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWeakCollected(t *testing.T) {
+	t.Run("collected instance should be created again", func(t *testing.T) {
+		var count int32
+		registerWeak(&count)
+		if svc := GetService[service1](); svc == nil || svc.GetName() != "weak" {
+			t.Error("weak service should be resolved")
+			return
+		}
+		weak := globalContainer.(*defaultContainer).getBinding(TypeOf[service1]()).Weak
+		collected := func() bool {
+			defer weak.locker.Unlock()
+			weak.locker.Lock()
+			return weak.ref.get() == nil
+		}
+		for i := 0; i < 100 && !collected(); i++ {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+		}
+		if svc := GetService[service1](); svc == nil || atomic.LoadInt32(&count) != 2 {
+			t.Error("collected instance should be created again")
+			return
+		}
+	})
+}
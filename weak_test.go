@@ -0,0 +1,112 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type weakInstance struct {
+	name string
+	data []byte
+}
+
+func (w *weakInstance) GetName() string {
+	return w.name
+}
+
+// registerWeak to register weak service to new global container, which counts instances created.
+func registerWeak(count *int32) {
+	globalContainer = New()
+	Register(nil, As(TypeOf[service1]()), Transient(func() any {
+		atomic.AddInt32(count, 1)
+		return &weakInstance{name: "weak", data: make([]byte, 1024)}
+	}), Weak())
+}
+
+func TestWeak(t *testing.T) {
+	t.Run("referenced instance should be kept", func(t *testing.T) {
+		var count int32
+		registerWeak(&count)
+		svc := GetService[service1]()
+		for i := 0; i < 3; i++ {
+			runtime.GC()
+			time.Sleep(time.Millisecond)
+		}
+		if GetService[service1]() != svc || atomic.LoadInt32(&count) != 1 {
+			t.Error("referenced instance should be kept")
+			return
+		}
+		if globalContainer.(Flattener).Flatten()[TypeOf[service1]()] != LifetimeSingleton {
+			t.Error("weak service should be singleton")
+			return
+		}
+		runtime.KeepAlive(svc)
+	})
+
+	t.Run("invalidated instance should be created again", func(t *testing.T) {
+		var count int32
+		registerWeak(&count)
+		svc := GetService[service1]()
+		Invalidate[service1]()
+		if GetService[service1]() == svc || atomic.LoadInt32(&count) != 2 {
+			t.Error("invalidated instance should be created again")
+			return
+		}
+		runtime.KeepAlive(svc)
+	})
+
+	t.Run("interior pointer and instance with finalizer should be held", func(t *testing.T) {
+		globalContainer = New()
+		type outer struct {
+			header   int64
+			instance weakInstance
+		}
+		Register(nil, As(TypeOf[service1]()), Transient(func() any {
+			return &(&outer{}).instance
+		}), Weak())
+		Register(nil, As(TypeOf[*weakInstance]()), Transient(func() any {
+			instance := &weakInstance{name: "finalized"}
+			runtime.SetFinalizer(instance, func(*weakInstance) {})
+			return instance
+		}), Weak())
+		if svc := GetService[service1](); svc == nil || GetService[service1]() != svc {
+			t.Error("interior pointer should be held")
+			return
+		}
+		if svc := GetService[*weakInstance](); svc == nil || GetService[*weakInstance]() != svc {
+			t.Error("instance with finalizer should be held")
+			return
+		}
+	})
+
+	t.Run("weak reference should be rejected without factory", func(t *testing.T) {
+		globalContainer = New()
+		if err := globalContainer.(Registerer).Register(&serviceInstance1{}, Weak()); err == nil {
+			t.Error("weak reference should be rejected without factory")
+			return
+		}
+	})
+}
@@ -0,0 +1,68 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Weighted is implemented by service which declares it's own position in slice injected without group,
+// such as middleware or plugin.
+type Weighted interface {
+	// Weight of the instance, sorted by ascending weight.
+	Weight() int
+}
+
+// sortByWeight to sort instances implement 'ioc.Weighted' by ascending weight at front, and then the others.
+// It's stable, so instances with the same weight and the unweighted ones keep the order of 'ResolveAll'.
+func sortByWeight(instances []reflect.Value) []reflect.Value {
+	weightOf := func(instance reflect.Value) (int, bool) {
+		if !instance.IsValid() || !instance.CanInterface() {
+			return 0, false
+		}
+		if weighted, ok := instance.Interface().(Weighted); ok && weighted != nil {
+			return weighted.Weight(), true
+		}
+		return 0, false
+	}
+	type weightedInstance struct {
+		instance reflect.Value
+		weight   int
+		weighted bool
+	}
+	items := make([]weightedInstance, len(instances))
+	for i, instance := range instances {
+		weight, weighted := weightOf(instance)
+		items[i] = weightedInstance{instance, weight, weighted}
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].weighted != items[j].weighted {
+			return items[i].weighted
+		}
+		return items[i].weight < items[j].weight
+	})
+	for i, item := range items {
+		instances[i] = item.instance
+	}
+	return instances
+}
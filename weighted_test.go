@@ -0,0 +1,66 @@
+// The MIT License (MIT)
+//
+// # Copyright (c) 2016 Jerry Bai
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+package ioc
+
+import (
+	"strings"
+	"testing"
+)
+
+type weightedHandler struct {
+	name   string
+	weight int
+}
+
+func (h *weightedHandler) GetName() string {
+	return h.name
+}
+
+func (h *weightedHandler) Weight() int {
+	return h.weight
+}
+
+type weightedClient struct {
+	Handlers []service1 `ioc-inject:"true"`
+}
+
+func TestWeighted(t *testing.T) {
+	t.Run("slice should be sorted by weight, and unweighted at the end", func(t *testing.T) {
+		globalContainer = New()
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "plain1"}, As(TypeOf[service1]()), Keyed(1))
+		_ = globalContainer.(Registerer).Register(&weightedHandler{name: "w10", weight: 10}, As(TypeOf[service1]()), Keyed(2))
+		_ = globalContainer.(Registerer).Register(&serviceInstance1{name: "plain2"}, As(TypeOf[service1]()), Keyed(3))
+		_ = globalContainer.(Registerer).Register(&weightedHandler{name: "w-5", weight: -5}, As(TypeOf[service1]()), Keyed(4))
+		_ = globalContainer.(Registerer).Register(&weightedHandler{name: "w10b", weight: 10}, As(TypeOf[service1]()), Keyed(5))
+
+		client := &weightedClient{}
+		Inject(client)
+		var names []string
+		for _, handler := range client.Handlers {
+			names = append(names, handler.GetName())
+		}
+		if strings.Join(names, ",") != "w-5,w10,w10b,plain1,plain2" {
+			t.Errorf("handlers should be sorted by weight, but %v", names)
+			return
+		}
+	})
+}